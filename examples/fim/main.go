@@ -50,13 +50,15 @@ func main() {
 		log.Fatal(err)
 	}
 
-	for chunk := range stream {
-		if chunk.Error != nil {
-			log.Fatal(chunk.Error)
-		}
-		if len(chunk.Choices) > 0 {
+	defer stream.Close()
+
+	for stream.Next() {
+		if chunk := stream.Current(); len(chunk.Choices) > 0 {
 			fmt.Print(chunk.Choices[0].Delta.Content)
 		}
 	}
+	if err := stream.Err(); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Println()
 }
@@ -18,16 +18,17 @@ func main() {
 	log.Printf("Chat completion: %+v\n", chatRes)
 
 	// Example: Using Chat Completions Stream
-	chatResChan, err := client.ChatStream("mistral-small-latest", []sdk.ChatMessage{{Content: "Hello, world!", Role: sdk.RoleUser}}, nil)
+	chatStream, err := client.ChatStream("mistral-small-latest", []sdk.ChatMessage{{Content: "Hello, world!", Role: sdk.RoleUser}}, nil)
 	if err != nil {
 		log.Fatalf("Error getting chat completion stream: %v", err)
 	}
+	defer chatStream.Close()
 
-	for chatResChunk := range chatResChan {
-		if chatResChunk.Error != nil {
-			log.Fatalf("Error while streaming response: %v", chatResChunk.Error)
-		}
-		log.Printf("Chat completion stream part: %+v\n", chatResChunk)
+	for chatStream.Next() {
+		log.Printf("Chat completion stream part: %+v\n", chatStream.Current())
+	}
+	if err := chatStream.Err(); err != nil {
+		log.Fatalf("Error while streaming response: %v", err)
 	}
 
 	// Example: Using Embeddings
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/ZaguanLabs/mistral-go/v2/sdk"
 )
@@ -76,4 +78,23 @@ func main() {
 
 		fmt.Printf("\nFinal Response: %s\n", finalResponse.Choices[0].Message.Content)
 	}
+
+	// The same exchange with a ToolRunner, which generates the schema, runs the calls and loops
+	fmt.Println("\n=== Tool Runner Example ===")
+	runner := sdk.NewToolRunner(client, sdk.WithToolTimeout(10*time.Second))
+	err = sdk.RegisterTool(runner, "get_weather", "Get the current weather in a location",
+		func(ctx context.Context, args struct {
+			Location string `json:"location" description:"The city and state, e.g. San Francisco, CA"`
+		}) (map[string]any, error) {
+			return map[string]any{"location": args.Location, "temperature": 22, "condition": "sunny"}, nil
+		})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := runner.Run("mistral-large-latest", []sdk.ChatMessage{sdk.UserMessage("What's the weather like in Paris?")}, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Final Response after %d calls: %s\n", result.Iterations, result.Response.Choices[0].Message.Content)
 }
@@ -0,0 +1,24 @@
+module github.com/ZaguanLabs/mistral-go/v2/otelmistral
+
+// OpenTelemetry v1.28 needs Go 1.21, one release above the core SDK's minimum.
+go 1.21
+
+require (
+	github.com/ZaguanLabs/mistral-go/v2 v2.4.13
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	nhooyr.io/websocket v1.8.17 // indirect
+)
+
+// Builds against the core SDK in this repository. Go ignores the replace for users of the module,
+// so every release of it requires the core SDK release it is tagged with.
+replace github.com/ZaguanLabs/mistral-go/v2 => ../
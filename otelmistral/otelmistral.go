@@ -0,0 +1,274 @@
+// Package otelmistral traces Mistral SDK calls with OpenTelemetry.
+//
+// Every API call becomes one client span that follows the OpenTelemetry GenAI semantic conventions,
+// and the W3C trace context is injected into every outgoing request:
+//
+//	client := sdk.NewClient(sdk.WithAPIKey(key), otelmistral.Tracing())
+//
+// The package is a separate module so the core SDK does not depend on OpenTelemetry.
+package otelmistral
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer spans are recorded with.
+const InstrumentationName = "github.com/ZaguanLabs/mistral-go/v2/otelmistral"
+
+// System is the gen_ai.system value of every span.
+const System = "mistral_ai"
+
+type config struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// Option configures a Tracer.
+type Option func(*config)
+
+// WithTracerProvider records spans with provider instead of the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithPropagator injects trace context with propagator instead of the global text map propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Tracing returns a client option that records a span for every API call and propagates the trace context.
+func Tracing(opts ...Option) sdk.Option {
+	tracer := NewTracer(opts...)
+	return func(c *sdk.MistralClient) {
+		sdk.WithObserver(tracer)(c)
+		sdk.WithMiddleware(tracer.Middleware)(c)
+	}
+}
+
+// Tracer is an sdk.Observer that records API calls as spans.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer creates a Tracer. Register it with sdk.WithObserver, and Middleware with sdk.WithMiddleware,
+// or use Tracing to do both.
+func NewTracer(opts ...Option) *Tracer {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.provider == nil {
+		cfg.provider = otel.GetTracerProvider()
+	}
+	if cfg.propagator == nil {
+		cfg.propagator = otel.GetTextMapPropagator()
+	}
+	return &Tracer{
+		tracer:     cfg.provider.Tracer(InstrumentationName, trace.WithInstrumentationVersion(sdk.Version)),
+		propagator: cfg.propagator,
+	}
+}
+
+// Middleware injects the trace context of the call span into the headers of every HTTP attempt.
+func (t *Tracer) Middleware(next sdk.Handler) sdk.Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		t.propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+		return next(req)
+	}
+}
+
+// CallStart starts the client span of call.
+func (t *Tracer) CallStart(ctx context.Context, call *sdk.Call) context.Context {
+	operation := genAIOperation(call)
+	name := operation
+	if call.Model != "" {
+		name += " " + call.Model
+	} else if agentID, ok := call.Request["agent_id"].(string); ok {
+		name += " " + agentID
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("gen_ai.operation.name", operation),
+		attribute.String("gen_ai.system", System),
+		attribute.String("mistral.operation", call.Operation),
+		attribute.String("server.address", call.ServerAddress),
+		attribute.Int("server.port", call.ServerPort),
+	}
+	ctx, _ = t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(call.Start),
+		trace.WithAttributes(append(attrs, requestAttributes(call)...)...),
+	)
+	return ctx
+}
+
+// CallRetry adds a retry event to the call span.
+func (t *Tracer) CallRetry(ctx context.Context, call *sdk.Call, attempt *sdk.RetryAttempt, delay time.Duration) {
+	attrs := []attribute.KeyValue{
+		attribute.Int("mistral.retry.attempt", attempt.Attempt+1),
+		attribute.Float64("mistral.retry.delay", delay.Seconds()),
+	}
+	if attempt.Response != nil {
+		attrs = append(attrs, attribute.Int("http.response.status_code", attempt.Response.StatusCode))
+	}
+	if attempt.Err != nil {
+		attrs = append(attrs, attribute.String("error.type", errorType(attempt.Err)))
+	}
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(attrs...))
+}
+
+// CallChunk does nothing; the first chunk is recorded from the call result when the span ends.
+func (t *Tracer) CallChunk(ctx context.Context, call *sdk.Call, at time.Time) {}
+
+// CallEnd records the response, usage and error of call and ends its span.
+func (t *Tracer) CallEnd(ctx context.Context, call *sdk.Call, result *sdk.CallResult) {
+	span := trace.SpanFromContext(ctx)
+
+	var attrs []attribute.KeyValue
+	if result.StatusCode != 0 {
+		attrs = append(attrs, attribute.Int("http.response.status_code", result.StatusCode))
+	}
+	if result.RequestID != "" {
+		attrs = append(attrs, attribute.String("mistral.request_id", result.RequestID))
+	}
+	if result.ResponseID != "" {
+		attrs = append(attrs, attribute.String("gen_ai.response.id", result.ResponseID))
+	}
+	if result.ResponseModel != "" {
+		attrs = append(attrs, attribute.String("gen_ai.response.model", result.ResponseModel))
+	}
+	if len(result.FinishReasons) > 0 {
+		attrs = append(attrs, attribute.StringSlice("gen_ai.response.finish_reasons", result.FinishReasons))
+	}
+	if result.Usage != nil {
+		attrs = append(attrs,
+			attribute.Int("gen_ai.usage.input_tokens", result.Usage.PromptTokens),
+			attribute.Int("gen_ai.usage.output_tokens", result.Usage.CompletionTokens),
+		)
+	}
+	if result.Retries > 0 {
+		attrs = append(attrs, attribute.Int("mistral.retries", result.Retries))
+	}
+	if call.Stream {
+		attrs = append(attrs, attribute.Int("mistral.stream.chunks", result.Chunks))
+		if result.Chunks > 0 {
+			attrs = append(attrs, attribute.Float64("mistral.time_to_first_token", result.FirstChunk.Seconds()))
+			span.AddEvent("first_token", trace.WithTimestamp(call.Start.Add(result.FirstChunk)))
+		}
+	}
+	if result.Err != nil {
+		attrs = append(attrs, attribute.String("error.type", errorType(result.Err)))
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+
+	span.SetAttributes(attrs...)
+	span.End(trace.WithTimestamp(call.Start.Add(result.Duration)))
+}
+
+// genAIOperation maps an SDK operation to a gen_ai.operation.name value. Operations without a
+// GenAI equivalent keep their SDK name.
+func genAIOperation(call *sdk.Call) string {
+	operation := strings.TrimSuffix(call.Operation, ".stream")
+	switch operation {
+	case "chat.completions":
+		return "chat"
+	case "fim.completions":
+		return "text_completion"
+	case "embeddings.create":
+		return "embeddings"
+	case "agents.completions":
+		return "invoke_agent"
+	case "beta.agents.create":
+		return "create_agent"
+	case "conversations.start", "conversations.append", "conversations.restart":
+		if _, ok := call.Request["agent_id"]; ok {
+			return "invoke_agent"
+		}
+		return "chat"
+	}
+	return call.Operation
+}
+
+// requestAttributes returns the gen_ai.request.* attributes found in the JSON payload of call.
+func requestAttributes(call *sdk.Call) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if call.Model != "" {
+		attrs = append(attrs, attribute.String("gen_ai.request.model", call.Model))
+	}
+	for _, field := range []struct{ name, key string }{
+		{"temperature", "gen_ai.request.temperature"},
+		{"top_p", "gen_ai.request.top_p"},
+		{"frequency_penalty", "gen_ai.request.frequency_penalty"},
+		{"presence_penalty", "gen_ai.request.presence_penalty"},
+	} {
+		if value, ok := call.Request[field.name].(float64); ok {
+			attrs = append(attrs, attribute.Float64(field.key, value))
+		}
+	}
+	for _, field := range []struct{ name, key string }{
+		{"max_tokens", "gen_ai.request.max_tokens"},
+		{"random_seed", "gen_ai.request.seed"},
+		{"n", "gen_ai.request.choice.count"},
+	} {
+		if value, ok := call.Request[field.name].(float64); ok {
+			attrs = append(attrs, attribute.Int64(field.key, int64(value)))
+		}
+	}
+	switch stop := call.Request["stop"].(type) {
+	case string:
+		attrs = append(attrs, attribute.StringSlice("gen_ai.request.stop_sequences", []string{stop}))
+	case []any:
+		sequences := make([]string, 0, len(stop))
+		for _, sequence := range stop {
+			if s, ok := sequence.(string); ok {
+				sequences = append(sequences, s)
+			}
+		}
+		attrs = append(attrs, attribute.StringSlice("gen_ai.request.stop_sequences", sequences))
+	}
+	if agentID, ok := call.Request["agent_id"].(string); ok {
+		attrs = append(attrs, attribute.String("gen_ai.agent.id", agentID))
+	}
+	return attrs
+}
+
+// errorType returns a low-cardinality error.type value: the HTTP status for API errors, or a short class name.
+func errorType(err error) string {
+	var apiErr *sdk.MistralAPIError
+	var connErr *sdk.MistralConnectionError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.HTTPStatus != 0 {
+			return strconv.Itoa(apiErr.HTTPStatus)
+		}
+		if apiErr.Type != "" {
+			return apiErr.Type
+		}
+		return "stream_error"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &connErr):
+		return "connection_error"
+	}
+	return "_OTHER"
+}
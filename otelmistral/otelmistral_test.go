@@ -0,0 +1,135 @@
+package otelmistral
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracedClient(t *testing.T, handler http.HandlerFunc, opts ...sdk.Option) (*sdk.MistralClient, *sdk.MockHTTPServer, *tracetest.SpanRecorder) {
+	t.Helper()
+	mock := sdk.NewMockHTTPServer(t, handler)
+	t.Cleanup(mock.Close)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := sdk.NewClient(append([]sdk.Option{
+		sdk.WithBaseURL(mock.Server.URL),
+		sdk.WithMaxRetries(1),
+		Tracing(WithTracerProvider(provider), WithPropagator(propagation.TraceContext{})),
+	}, opts...)...)
+	return client, mock, recorder
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestChatSpan(t *testing.T) {
+	client, mock, recorder := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sdk.MockChatResponse().Write(w)
+	})
+
+	temperature := 0.3
+	_, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, &sdk.ChatRequestParams{Temperature: &temperature, MaxTokens: sdk.IntPtr(64)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "chat mistral-small-latest" || span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("unexpected span %q kind %v", span.Name(), span.SpanKind())
+	}
+	if attr(span, "gen_ai.operation.name").AsString() != "chat" || attr(span, "gen_ai.system").AsString() != System ||
+		attr(span, "gen_ai.request.model").AsString() != "mistral-small-latest" ||
+		attr(span, "gen_ai.request.temperature").AsFloat64() != 0.3 || attr(span, "gen_ai.request.max_tokens").AsInt64() != 64 {
+		t.Errorf("unexpected request attributes %v", span.Attributes())
+	}
+	if attr(span, "gen_ai.response.id").AsString() != "chat-123" || attr(span, "gen_ai.usage.input_tokens").AsInt64() != 10 ||
+		attr(span, "gen_ai.usage.output_tokens").AsInt64() != 20 {
+		t.Errorf("unexpected response attributes %v", span.Attributes())
+	}
+	if reasons := attr(span, "gen_ai.response.finish_reasons").AsStringSlice(); len(reasons) != 1 || reasons[0] != "stop" {
+		t.Errorf("unexpected finish reasons %v", reasons)
+	}
+
+	traceparent := mock.Requests[0].Header.Get("Traceparent")
+	if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+		t.Errorf("expected traceparent for trace %s, got %q", span.SpanContext().TraceID(), traceparent)
+	}
+}
+
+func TestStreamSpanRecordsFirstToken(t *testing.T) {
+	client, _, recorder := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"model\":\"codestral-latest\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"},\"finish_reason\":\"stop\"}],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2,\"total_tokens\":5}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+
+	stream, err := client.FIMStreamChan(&sdk.FIMRequestParams{Model: "codestral-latest", Prompt: "def"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range stream {
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "text_completion codestral-latest" || attr(span, "mistral.stream.chunks").AsInt64() != 2 ||
+		attr(span, "gen_ai.usage.output_tokens").AsInt64() != 2 {
+		t.Errorf("unexpected stream span %q %v", span.Name(), span.Attributes())
+	}
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "first_token" || attr(span, "mistral.time_to_first_token").AsFloat64() <= 0 {
+		t.Errorf("expected a first_token event, got %v", events)
+	}
+}
+
+func TestErrorSpan(t *testing.T) {
+	policy := sdk.NewDefaultRetryPolicy(2)
+	policy.BaseDelay = time.Millisecond
+	client, _, recorder := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After-Ms", "1")
+		sdk.MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+	}, sdk.WithRetryPolicy(policy))
+
+	if _, err := client.Embeddings("mistral-embed", []string{"a"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Status().Code != codes.Error || attr(span, "error.type").AsString() != "429" ||
+		attr(span, "gen_ai.operation.name").AsString() != "embeddings" || attr(span, "http.response.status_code").AsInt64() != 429 {
+		t.Errorf("unexpected error span %v %v", span.Status(), span.Attributes())
+	}
+	if attr(span, "mistral.retries").AsInt64() != 1 {
+		t.Errorf("expected the retried attempt to be counted, got %v", span.Attributes())
+	}
+}
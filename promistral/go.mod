@@ -0,0 +1,24 @@
+module github.com/ZaguanLabs/mistral-go/v2/promistral
+
+go 1.20
+
+require (
+	github.com/ZaguanLabs/mistral-go/v2 v2.4.13
+	github.com/prometheus/client_golang v1.19.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	nhooyr.io/websocket v1.8.17 // indirect
+)
+
+// Builds against the core SDK in this repository. Go ignores the replace for users of the module,
+// so every release of it requires the core SDK release it is tagged with.
+replace github.com/ZaguanLabs/mistral-go/v2 => ../
@@ -0,0 +1,157 @@
+// Package promistral exposes Mistral SDK metrics in the Prometheus format.
+//
+//	collector := promistral.New()
+//	prometheus.MustRegister(collector)
+//	client := sdk.NewClient(sdk.WithAPIKey(key), sdk.WithMetrics(collector))
+//
+// The package is a separate module so the core SDK does not depend on the Prometheus client.
+package promistral
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultLatencyBuckets suit completion calls, which often take several seconds.
+var DefaultLatencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 40, 80}
+
+// DefaultTokenLatencyBuckets suit time-to-first-token and inter-token latency.
+var DefaultTokenLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type config struct {
+	namespace           string
+	constLabels         prometheus.Labels
+	latencyBuckets      []float64
+	tokenLatencyBuckets []float64
+}
+
+// Option configures a Collector.
+type Option func(*config)
+
+// WithNamespace prefixes metric names with namespace instead of "mistral".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithConstLabels adds labels, such as the service name, to every metric.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = labels
+	}
+}
+
+// WithBuckets replaces the histogram buckets of request latency and of stream token timings.
+func WithBuckets(latency, tokenLatency []float64) Option {
+	return func(c *config) {
+		c.latencyBuckets = latency
+		c.tokenLatencyBuckets = tokenLatency
+	}
+}
+
+// Collector implements sdk.Metrics and prometheus.Collector. It exports:
+//
+//	mistral_requests_total{operation,model,status}
+//	mistral_request_duration_seconds{operation,model}
+//	mistral_time_to_first_token_seconds{operation,model}
+//	mistral_inter_token_latency_seconds{operation,model}
+//	mistral_tokens_total{operation,model,type}     type is "prompt" or "completion"
+//	mistral_retries_total{operation,model,status}
+//
+// status is the HTTP status code, or "error" when no response was received.
+type Collector struct {
+	requests   *prometheus.CounterVec
+	latency    *prometheus.HistogramVec
+	firstToken *prometheus.HistogramVec
+	interToken *prometheus.HistogramVec
+	tokens     *prometheus.CounterVec
+	retries    *prometheus.CounterVec
+}
+
+// New creates a Collector. Register it with a prometheus.Registerer and pass it to sdk.WithMetrics.
+func New(opts ...Option) *Collector {
+	cfg := config{
+		namespace:           "mistral",
+		latencyBuckets:      DefaultLatencyBuckets,
+		tokenLatencyBuckets: DefaultTokenLatencyBuckets,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	counter := func(name, help, label string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace, Name: name, Help: help, ConstLabels: cfg.constLabels,
+		}, []string{"operation", "model", label})
+	}
+	histogram := func(name, help string, buckets []float64) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace, Name: name, Help: help, ConstLabels: cfg.constLabels, Buckets: buckets,
+		}, []string{"operation", "model"})
+	}
+
+	return &Collector{
+		requests:   counter("requests_total", "Mistral API calls by operation, model and final HTTP status.", "status"),
+		latency:    histogram("request_duration_seconds", "Duration of Mistral API calls, including retries and stream consumption.", cfg.latencyBuckets),
+		firstToken: histogram("time_to_first_token_seconds", "Time from the start of a streaming call to its first event.", cfg.tokenLatencyBuckets),
+		interToken: histogram("inter_token_latency_seconds", "Time between consecutive events of a stream.", cfg.tokenLatencyBuckets),
+		tokens:     counter("tokens_total", "Prompt and completion tokens reported by the Mistral API.", "type"),
+		retries:    counter("retries_total", "Retried Mistral API attempts by the HTTP status that caused them.", "status"),
+	}
+}
+
+func statusLabel(status int) string {
+	if status == 0 {
+		return "error"
+	}
+	return strconv.Itoa(status)
+}
+
+// RequestDone implements sdk.Metrics.
+func (c *Collector) RequestDone(labels sdk.MetricLabels, status int, err error, latency time.Duration) {
+	c.requests.WithLabelValues(labels.Operation, labels.Model, statusLabel(status)).Inc()
+	c.latency.WithLabelValues(labels.Operation, labels.Model).Observe(latency.Seconds())
+}
+
+// Retry implements sdk.Metrics.
+func (c *Collector) Retry(labels sdk.MetricLabels, status int) {
+	c.retries.WithLabelValues(labels.Operation, labels.Model, statusLabel(status)).Inc()
+}
+
+// TimeToFirstToken implements sdk.Metrics.
+func (c *Collector) TimeToFirstToken(labels sdk.MetricLabels, d time.Duration) {
+	c.firstToken.WithLabelValues(labels.Operation, labels.Model).Observe(d.Seconds())
+}
+
+// InterTokenLatency implements sdk.Metrics.
+func (c *Collector) InterTokenLatency(labels sdk.MetricLabels, d time.Duration) {
+	c.interToken.WithLabelValues(labels.Operation, labels.Model).Observe(d.Seconds())
+}
+
+// Tokens implements sdk.Metrics.
+func (c *Collector) Tokens(labels sdk.MetricLabels, prompt, completion int) {
+	c.tokens.WithLabelValues(labels.Operation, labels.Model, "prompt").Add(float64(prompt))
+	c.tokens.WithLabelValues(labels.Operation, labels.Model, "completion").Add(float64(completion))
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.latency, c.firstToken, c.interToken, c.tokens, c.retries}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
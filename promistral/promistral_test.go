@@ -0,0 +1,70 @@
+package promistral
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollectorExportsClientMetrics(t *testing.T) {
+	attempts := 0
+	mock := sdk.NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After-Ms", "1")
+			sdk.MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+			return
+		}
+		sdk.MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	collector := New(WithConstLabels(prometheus.Labels{"service": "test"}))
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	client := sdk.NewClient(sdk.WithBaseURL(mock.Server.URL), sdk.WithMaxRetries(3), sdk.WithMetrics(collector))
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `
+# HELP mistral_requests_total Mistral API calls by operation, model and final HTTP status.
+# TYPE mistral_requests_total counter
+mistral_requests_total{model="mistral-small-latest",operation="chat.completions",service="test",status="200"} 1
+# HELP mistral_retries_total Retried Mistral API attempts by the HTTP status that caused them.
+# TYPE mistral_retries_total counter
+mistral_retries_total{model="mistral-small-latest",operation="chat.completions",service="test",status="429"} 1
+# HELP mistral_tokens_total Prompt and completion tokens reported by the Mistral API.
+# TYPE mistral_tokens_total counter
+mistral_tokens_total{model="mistral-small-latest",operation="chat.completions",service="test",type="completion"} 20
+mistral_tokens_total{model="mistral-small-latest",operation="chat.completions",service="test",type="prompt"} 10
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"mistral_requests_total", "mistral_retries_total", "mistral_tokens_total"); err != nil {
+		t.Error(err)
+	}
+	if count := testutil.CollectAndCount(collector, "mistral_request_duration_seconds"); count != 1 {
+		t.Errorf("expected one latency series, got %d", count)
+	}
+}
+
+func TestCollectorRecordsStreamTimings(t *testing.T) {
+	collector := New(WithNamespace("llm"))
+	labels := sdk.MetricLabels{Operation: "chat.completions.stream", Model: "mistral-small-latest"}
+	collector.TimeToFirstToken(labels, 0)
+	collector.InterTokenLatency(labels, 0)
+	collector.InterTokenLatency(labels, 0)
+	collector.RequestDone(labels, 0, nil, 0)
+
+	if count := testutil.CollectAndCount(collector, "llm_time_to_first_token_seconds", "llm_inter_token_latency_seconds"); count != 2 {
+		t.Errorf("expected one series per stream histogram, got %d", count)
+	}
+	if got := testutil.ToFloat64(collector.requests.WithLabelValues(labels.Operation, labels.Model, "error")); got != 1 {
+		t.Errorf("expected transport failures to be labelled \"error\", got %v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -71,19 +72,14 @@ type DeleteAccessResponse struct {
 
 // ListLibraryAccesses lists all shares for a library.
 func (c *MistralClient) ListLibraryAccesses(libraryID string) (*AccessListResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/share", libraryID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.ListLibraryAccessesCtx(context.Background(), libraryID)
+}
 
+// ListLibraryAccessesCtx is like ListLibraryAccesses but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListLibraryAccessesCtx(ctx context.Context, libraryID string) (*AccessListResponse, error) {
+	ctx = withOperation(ctx, "libraries.accesses.list")
 	var listResponse AccessListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/share", libraryID), &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +88,12 @@ func (c *MistralClient) ListLibraryAccesses(libraryID string) (*AccessListRespon
 
 // UpdateOrCreateLibraryAccess updates or creates a library share entry.
 func (c *MistralClient) UpdateOrCreateLibraryAccess(libraryID string, req *UpdateAccessRequest) (*LibraryShare, error) {
+	return c.UpdateOrCreateLibraryAccessCtx(context.Background(), libraryID, req)
+}
+
+// UpdateOrCreateLibraryAccessCtx is like UpdateOrCreateLibraryAccess but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateOrCreateLibraryAccessCtx(ctx context.Context, libraryID string, req *UpdateAccessRequest) (*LibraryShare, error) {
+	ctx = withOperation(ctx, "libraries.accesses.update_or_create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -114,19 +116,8 @@ func (c *MistralClient) UpdateOrCreateLibraryAccess(libraryID string, req *Updat
 		reqMap["org_id"] = *req.OrgID
 	}
 
-	response, err := c.request(http.MethodPut, reqMap, fmt.Sprintf("v1/libraries/%s/share", libraryID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var access LibraryShare
-	err = mapToStruct(respData, &access)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPut, reqMap, fmt.Sprintf("v1/libraries/%s/share", libraryID), &access); err != nil {
 		return nil, err
 	}
 
@@ -148,6 +139,12 @@ func (c *MistralClient) UpdateOrCreateLibraryAccess(libraryID string, req *Updat
 
 // DeleteLibraryShare removes a share entry from a library.
 func (c *MistralClient) DeleteLibraryShare(libraryID, shareWithUUID string, shareWithType EntityType, orgID *string) (*DeleteAccessResponse, error) {
+	return c.DeleteLibraryShareCtx(context.Background(), libraryID, shareWithUUID, shareWithType, orgID)
+}
+
+// DeleteLibraryShareCtx is like DeleteLibraryShare but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteLibraryShareCtx(ctx context.Context, libraryID, shareWithUUID string, shareWithType EntityType, orgID *string) (*DeleteAccessResponse, error) {
+	ctx = withOperation(ctx, "libraries.accesses.delete_share")
 	query := url.Values{}
 	query.Add("share_with_uuid", shareWithUUID)
 	query.Add("share_with_type", string(shareWithType))
@@ -156,35 +153,31 @@ func (c *MistralClient) DeleteLibraryShare(libraryID, shareWithUUID string, shar
 	}
 
 	path := fmt.Sprintf("v1/libraries/%s/share?%s", libraryID, query.Encode())
-	response, err := c.request(http.MethodDelete, nil, path, false, nil)
-	if err != nil {
+	// The API may answer with an empty body, which leaves deleteResponse nil.
+	var deleteResponse *DeleteAccessResponse
+	if err := c.requestInto(ctx, http.MethodDelete, nil, path, &deleteResponse); err != nil {
 		return nil, err
 	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	if len(respData) == 0 {
+	if deleteResponse == nil {
 		return nil, nil
 	}
 
-	var deleteResponse DeleteAccessResponse
-	err = mapToStruct(respData, &deleteResponse)
-	if err != nil {
-		return nil, err
-	}
-
 	// Fill backward-compatible fields.
 	if deleteResponse.UserID == "" {
 		deleteResponse.UserID = deleteResponse.ShareWithUUID
 	}
 
-	return &deleteResponse, nil
+	return deleteResponse, nil
 }
 
 // DeleteLibraryAccess removes a user share entry from a library.
 // Kept for backward compatibility.
 func (c *MistralClient) DeleteLibraryAccess(libraryID, userID string) (*DeleteAccessResponse, error) {
-	return c.DeleteLibraryShare(libraryID, userID, EntityTypeUser, nil)
+	return c.DeleteLibraryAccessCtx(context.Background(), libraryID, userID)
+}
+
+// DeleteLibraryAccessCtx is like DeleteLibraryAccess but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteLibraryAccessCtx(ctx context.Context, libraryID, userID string) (*DeleteAccessResponse, error) {
+	ctx = withOperation(ctx, "libraries.accesses.delete")
+	return c.DeleteLibraryShareCtx(ctx, libraryID, userID, EntityTypeUser, nil)
 }
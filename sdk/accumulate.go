@@ -0,0 +1,245 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+)
+
+// StreamAccumulator merges the chunks of a chat, agent or FIM stream into the response the
+// matching non-streaming call returns: content is concatenated per choice, content chunks such as
+// thinking are merged, tool call fragments are merged by ID or index, and the finish reasons and
+// final usage are kept.
+//
+// The zero value is ready to use. A StreamAccumulator is not safe for concurrent use.
+type StreamAccumulator struct {
+	// OnContent, when set, is called with every content delta as it arrives.
+	OnContent func(choice int, delta string)
+	// OnToolCall, when set, is called once per tool call when its arguments are complete: when the
+	// next tool call of the choice starts, when the choice finishes, or from Response.
+	OnToolCall func(choice int, call ToolCall)
+
+	id      string
+	model   string
+	created int
+	usage   UsageInfo
+	choices map[int]*accumulatedChoice
+}
+
+type accumulatedChoice struct {
+	role         string
+	content      strings.Builder
+	parts        []ContentPart // Set once a delta arrives as content chunks
+	toolCalls    []ToolCall
+	reported     int // Tool calls already passed to OnToolCall
+	finishReason FinishReason
+}
+
+// Add merges a chat or agent stream chunk.
+func (a *StreamAccumulator) Add(chunk ChatCompletionStreamResponse) {
+	a.addHeader(chunk.ID, chunk.Model, chunk.Created, chunk.Usage)
+	for _, choice := range chunk.Choices {
+		a.addDelta(choice.Index, choice.Delta, choice.FinishReason)
+	}
+}
+
+// AddFIM merges a FIM stream chunk.
+func (a *StreamAccumulator) AddFIM(chunk FIMCompletionStreamResponse) {
+	a.addHeader(chunk.ID, chunk.Model, chunk.Created, chunk.Usage)
+	for _, choice := range chunk.Choices {
+		a.addDelta(choice.Index, choice.Delta, choice.FinishReason)
+	}
+}
+
+func (a *StreamAccumulator) addHeader(id, model string, created int, usage UsageInfo) {
+	if a.id == "" {
+		a.id = id
+	}
+	if a.model == "" {
+		a.model = model
+	}
+	if a.created == 0 {
+		a.created = created
+	}
+	if usage != (UsageInfo{}) {
+		a.usage = usage
+	}
+}
+
+func (a *StreamAccumulator) addDelta(index int, delta DeltaMessage, finishReason FinishReason) {
+	if a.choices == nil {
+		a.choices = make(map[int]*accumulatedChoice)
+	}
+	choice, ok := a.choices[index]
+	if !ok {
+		choice = &accumulatedChoice{}
+		a.choices[index] = choice
+	}
+
+	if delta.Role != "" {
+		choice.role = delta.Role
+	}
+	if delta.ContentParts != nil && choice.parts == nil && choice.content.Len() > 0 {
+		choice.parts = []ContentPart{TextPart(choice.content.String())}
+	}
+	if delta.ContentParts != nil || choice.parts != nil {
+		parts := delta.ContentParts
+		if parts == nil && delta.Content != "" {
+			parts = []ContentPart{TextPart(delta.Content)}
+		}
+		choice.parts = mergeContentParts(choice.parts, parts)
+	}
+	if delta.Content != "" {
+		choice.content.WriteString(delta.Content)
+		if a.OnContent != nil {
+			a.OnContent(index, delta.Content)
+		}
+	}
+	for _, call := range delta.ToolCalls {
+		if choice.mergeToolCall(call) {
+			// A new tool call starts once the previous ones are complete.
+			a.reportToolCalls(index, choice, len(choice.toolCalls)-1)
+		}
+	}
+	if finishReason != "" {
+		choice.finishReason = finishReason
+		a.reportToolCalls(index, choice, len(choice.toolCalls))
+	}
+}
+
+// mergeToolCall appends the fragment call to the tool call with the same ID, or the same index
+// when the fragment has no ID. It reports whether call started a new tool call.
+func (c *accumulatedChoice) mergeToolCall(call ToolCall) bool {
+	for i := len(c.toolCalls) - 1; i >= 0; i-- {
+		existing := &c.toolCalls[i]
+		if call.Id != "" && existing.Id != call.Id || call.Id == "" && existing.Index != call.Index {
+			continue
+		}
+		if existing.Type == "" {
+			existing.Type = call.Type
+		}
+		if existing.Function.Name == "" {
+			existing.Function.Name = call.Function.Name
+		}
+		existing.Function.Arguments += call.Function.Arguments
+		return false
+	}
+	c.toolCalls = append(c.toolCalls, call)
+	return true
+}
+
+// mergeContentParts appends the chunks of a delta to parts, extending the last chunk when it has
+// the same type: text is concatenated and thinking chunks are merged.
+func mergeContentParts(parts, delta []ContentPart) []ContentPart {
+	if parts == nil {
+		parts = []ContentPart{}
+	}
+	for _, part := range delta {
+		if n := len(parts); n > 0 && parts[n-1].Type == part.Type {
+			switch part.Type {
+			case ContentPartText:
+				parts[n-1].Text += part.Text
+				continue
+			case ContentPartThinking:
+				parts[n-1].Thinking = mergeContentParts(parts[n-1].Thinking, part.Thinking)
+				if part.Closed != nil {
+					parts[n-1].Closed = part.Closed
+				}
+				continue
+			}
+		}
+		if part.Type == ContentPartThinking {
+			part.Thinking = mergeContentParts(nil, part.Thinking)
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// reportToolCalls passes the tool calls of choice up to end that were not reported yet to
+// OnToolCall.
+func (a *StreamAccumulator) reportToolCalls(index int, choice *accumulatedChoice, end int) {
+	for ; choice.reported < end; choice.reported++ {
+		if a.OnToolCall != nil {
+			a.OnToolCall(index, choice.toolCalls[choice.reported])
+		}
+	}
+}
+
+// Response returns the chat completion built from the chunks added so far. Tool calls that were
+// not reported to OnToolCall yet are reported first.
+func (a *StreamAccumulator) Response() *ChatCompletionResponse {
+	resp := &ChatCompletionResponse{
+		ID:      a.id,
+		Object:  "chat.completion",
+		Created: a.created,
+		Model:   a.model,
+		Choices: []ChatCompletionResponseChoice{},
+		Usage:   a.usage,
+	}
+	indexes := make([]int, 0, len(a.choices))
+	for index := range a.choices {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		choice := a.choices[index]
+		a.reportToolCalls(index, choice, len(choice.toolCalls))
+		role := choice.role
+		if role == "" {
+			role = RoleAssistant
+		}
+		message := ChatMessage{
+			Role:      role,
+			Content:   choice.content.String(),
+			ToolCalls: append([]ToolCall(nil), choice.toolCalls...),
+		}
+		if choice.parts != nil {
+			message.ContentParts = mergeContentParts(nil, choice.parts)
+		}
+		resp.Choices = append(resp.Choices, ChatCompletionResponseChoice{Index: index, Message: message, FinishReason: choice.finishReason})
+	}
+	return resp
+}
+
+// FIMResponse returns the FIM completion built from the chunks added so far, like Response.
+func (a *StreamAccumulator) FIMResponse() *FIMCompletionResponse {
+	chat := a.Response()
+	resp := &FIMCompletionResponse{
+		ID:      chat.ID,
+		Object:  chat.Object,
+		Created: chat.Created,
+		Model:   chat.Model,
+		Choices: make([]FIMCompletionResponseChoice, len(chat.Choices)),
+		Usage:   chat.Usage,
+	}
+	for i, choice := range chat.Choices {
+		resp.Choices[i] = FIMCompletionResponseChoice(choice)
+	}
+	return resp
+}
+
+// AccumulateChatStream reads a chat or agent stream to the end and closes it. It returns the
+// response built from the chunks received, together with the error that ended the stream, if any.
+// acc may be nil when no callbacks are needed.
+func AccumulateChatStream(stream *Stream[ChatCompletionStreamResponse], acc *StreamAccumulator) (*ChatCompletionResponse, error) {
+	if acc == nil {
+		acc = &StreamAccumulator{}
+	}
+	defer stream.Close()
+	for stream.Next() {
+		acc.Add(stream.Current())
+	}
+	return acc.Response(), stream.Err()
+}
+
+// AccumulateFIMStream is like AccumulateChatStream for FIM streams.
+func AccumulateFIMStream(stream *Stream[FIMCompletionStreamResponse], acc *StreamAccumulator) (*FIMCompletionResponse, error) {
+	if acc == nil {
+		acc = &StreamAccumulator{}
+	}
+	defer stream.Close()
+	for stream.Next() {
+		acc.AddFIM(stream.Current())
+	}
+	return acc.FIMResponse(), stream.Err()
+}
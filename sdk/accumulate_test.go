@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStreamAccumulatorMergesFragments(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"id":"c1","object":"chat.completion.chunk","created":1,"model":"m","choices":[{"index":0,"delta":{"role":"assistant","content":"Let me "}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"content":"check."}},{"index":1,"delta":{"content":"Sure"}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"id":"call-1","type":"function","index":0,"function":{"name":"get_weather","arguments":"{\"city\":"}}]}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"tool_calls":[{"id":"call-2","type":"function","index":1,"function":{"name":"get_time","arguments":"{}"}}]}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"},{"index":1,"delta":{},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":7,"total_tokens":12}}`,
+		} {
+			_, _ = io.WriteString(w, "data: "+chunk+"\n\n")
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().ChatStream("m", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var deltas []string
+	var reported []string
+	acc := &StreamAccumulator{
+		OnContent: func(choice int, delta string) { deltas = append(deltas, delta) },
+		OnToolCall: func(choice int, call ToolCall) {
+			reported = append(reported, call.Function.Name+call.Function.Arguments)
+		},
+	}
+	resp, err := AccumulateChatStream(stream, acc)
+	if err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	expected := &ChatCompletionResponse{
+		ID:      "c1",
+		Object:  "chat.completion",
+		Created: 1,
+		Model:   "m",
+		Choices: []ChatCompletionResponseChoice{
+			{Index: 0, Message: ChatMessage{Role: RoleAssistant, Content: "Let me check.", ToolCalls: []ToolCall{
+				{Id: "call-1", Type: ToolTypeFunction, Index: 0, Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+				{Id: "call-2", Type: ToolTypeFunction, Index: 1, Function: FunctionCall{Name: "get_time", Arguments: "{}"}},
+			}}, FinishReason: FinishReasonToolCalls},
+			{Index: 1, Message: ChatMessage{Role: RoleAssistant, Content: "Sure"}, FinishReason: FinishReasonStop},
+		},
+		Usage: UsageInfo{PromptTokens: 5, CompletionTokens: 7, TotalTokens: 12},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("expected %+v, got %+v", expected, resp)
+	}
+	if !reflect.DeepEqual(deltas, []string{"Let me ", "check.", "Sure"}) {
+		t.Errorf("unexpected content deltas %q", deltas)
+	}
+	if !reflect.DeepEqual(reported, []string{`get_weather{"city":"Paris"}`, "get_time{}"}) {
+		t.Errorf("expected each tool call to be reported once complete, got %q", reported)
+	}
+}
+
+func TestAccumulateFIMStream(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"f1\",\"model\":\"codestral-latest\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"return \"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"f1\",\"model\":\"codestral-latest\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a + b\"},\"finish_reason\":\"stop\"}],\"usage\":{\"prompt_tokens\":4,\"completion_tokens\":3,\"total_tokens\":7}}\n\n")
+		_, _ = io.WriteString(w, "data: {\"object\":\"error\",\"message\":\"Service overloaded\",\"type\":\"service_unavailable\",\"code\":\"503\"}\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().FIMStream(&FIMRequestParams{Model: "codestral-latest", Prompt: "def add(a, b):"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := AccumulateFIMStream(stream, nil)
+	if err == nil {
+		t.Error("expected the error event to be returned")
+	}
+	if resp.Choices[0].Message.Content != "return a + b" || resp.Choices[0].FinishReason != FinishReasonStop || resp.Usage.TotalTokens != 7 {
+		t.Errorf("expected the chunks before the error to be kept, got %+v", resp)
+	}
+}
+
+func TestStreamAccumulatorMergesContentChunks(t *testing.T) {
+	var acc StreamAccumulator
+	for _, data := range []string{
+		`{"choices":[{"index":0,"delta":{"role":"assistant","content":[{"type":"thinking","thinking":[{"type":"text","text":"Two "}]}]}}]}`,
+		`{"choices":[{"index":0,"delta":{"content":[{"type":"thinking","thinking":[{"type":"text","text":"plus two."}]}]}}]}`,
+		`{"choices":[{"index":0,"delta":{"content":"It is "}}]}`,
+		`{"choices":[{"index":0,"delta":{"content":[{"type":"text","text":"four."}]},"finish_reason":"stop"}]}`,
+	} {
+		var chunk ChatCompletionStreamResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		acc.Add(chunk)
+	}
+
+	message := acc.Response().Choices[0].Message
+	expected := []ContentPart{
+		{Type: ContentPartThinking, Thinking: []ContentPart{TextPart("Two plus two.")}},
+		TextPart("It is four."),
+	}
+	if !reflect.DeepEqual(message.ContentParts, expected) || message.Content != "It is four." || message.Thinking() != "Two plus two." {
+		t.Errorf("expected merged thinking and text chunks, got %+v", message)
+	}
+}
@@ -1,9 +1,7 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,7 +29,8 @@ type AgentCompletionRequest struct {
 
 // ResponseFormatSpec specifies the response format
 type ResponseFormatSpec struct {
-	Type ResponseFormat `json:"type"`
+	Type       ResponseFormat `json:"type"`
+	JSONSchema *JSONSchema    `json:"json_schema,omitempty"` // For ResponseFormatJsonSchema
 }
 
 // AgentComplete performs an agent completion request
@@ -43,6 +42,12 @@ type ResponseFormatSpec struct {
 //
 // Returns a ChatCompletionResponse (same structure as regular chat)
 func (c *MistralClient) AgentComplete(agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*ChatCompletionResponse, error) {
+	return c.AgentCompleteCtx(context.Background(), agentID, messages, params)
+}
+
+// AgentCompleteCtx is like AgentComplete but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AgentCompleteCtx(ctx context.Context, agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*ChatCompletionResponse, error) {
+	ctx = withOperation(ctx, "agents.completions")
 	if params == nil {
 		params = &AgentCompletionRequest{}
 	}
@@ -103,19 +108,8 @@ func (c *MistralClient) AgentComplete(agentID string, messages []ChatMessage, pa
 		reqMap["prompt_mode"] = params.PromptMode
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/agents/completions", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var chatResponse ChatCompletionResponse
-	err = mapToStruct(respData, &chatResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/agents/completions", &chatResponse); err != nil {
 		return nil, err
 	}
 
@@ -129,8 +123,15 @@ func (c *MistralClient) AgentComplete(agentID string, messages []ChatMessage, pa
 //   - messages: The conversation messages
 //   - params: Optional parameters for the completion
 //
-// Returns a channel of ChatCompletionStreamResponse
-func (c *MistralClient) AgentCompleteStream(agentID string, messages []ChatMessage, params *AgentCompletionRequest) (<-chan ChatCompletionStreamResponse, error) {
+// Returns a Stream of ChatCompletionStreamResponse; close it when done with it.
+// AgentCompleteStreamChan delivers the chunks on a channel instead.
+func (c *MistralClient) AgentCompleteStream(agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*Stream[ChatCompletionStreamResponse], error) {
+	return c.AgentCompleteStreamCtx(context.Background(), agentID, messages, params)
+}
+
+// AgentCompleteStreamCtx is like AgentCompleteStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AgentCompleteStreamCtx(ctx context.Context, agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*Stream[ChatCompletionStreamResponse], error) {
+	ctx = withOperation(ctx, "agents.completions.stream")
 	if params == nil {
 		params = &AgentCompletionRequest{}
 	}
@@ -191,60 +192,31 @@ func (c *MistralClient) AgentCompleteStream(agentID string, messages []ChatMessa
 		reqMap["prompt_mode"] = params.PromptMode
 	}
 
-	// Create response channel
-	responseChan := make(chan ChatCompletionStreamResponse)
-
-	response, err := c.request(http.MethodPost, reqMap, "v1/agents/completions", true, nil)
+	response, err := c.request(ctx, http.MethodPost, reqMap, "v1/agents/completions", true, nil)
 	if err != nil {
-		close(responseChan)
 		return nil, err
 	}
 
 	respBody, ok := response.(io.ReadCloser)
 	if !ok {
-		close(responseChan)
 		return nil, fmt.Errorf("invalid response type: %T", response)
 	}
 
-	// Start streaming in a goroutine (same pattern as ChatStream)
-	go func() {
-		defer close(responseChan)
-		defer respBody.Close()
-
-		reader := bufio.NewReader(respBody)
-
-		for {
-			line, err := reader.ReadBytes('\n')
-			if err == io.EOF {
-				break
-			} else if err != nil {
-				responseChan <- ChatCompletionStreamResponse{Error: fmt.Errorf("error reading stream response: %w", err)}
-				return
-			}
-
-			if bytes.Equal(line, []byte("\n")) {
-				continue
-			}
-
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-
-				if bytes.Equal(jsonLine, []byte("[DONE]")) {
-					break
-				}
-
-				var streamResponse ChatCompletionStreamResponse
-				if err := json.Unmarshal(jsonLine, &streamResponse); err != nil {
-					responseChan <- ChatCompletionStreamResponse{Error: fmt.Errorf("error unmarshaling stream response: %w", err)}
-					return
-				}
+	return newStream(ctx, respBody, c.streamIdleTimeout, decodeChunk[ChatCompletionStreamResponse], nil), nil
+}
 
-				responseChan <- streamResponse
-			}
-		}
-	}()
+// AgentCompleteStreamChan is like AgentCompleteStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) AgentCompleteStreamChan(agentID string, messages []ChatMessage, params *AgentCompletionRequest) (<-chan ChatCompletionStreamResponse, error) {
+	return c.AgentCompleteStreamChanCtx(context.Background(), agentID, messages, params)
+}
 
-	return responseChan, nil
+// AgentCompleteStreamChanCtx is like AgentCompleteStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AgentCompleteStreamChanCtx(ctx context.Context, agentID string, messages []ChatMessage, params *AgentCompletionRequest) (<-chan ChatCompletionStreamResponse, error) {
+	stream, err := c.AgentCompleteStreamCtx(ctx, agentID, messages, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) ChatCompletionStreamResponse { return ChatCompletionStreamResponse{Error: err} }), nil
 }
 
 // NewAgentCompletionRequest creates a new AgentCompletionRequest with default values
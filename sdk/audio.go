@@ -1,8 +1,8 @@
 package sdk
 
 import (
-	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -80,6 +80,12 @@ type TranscriptionResponse struct {
 //
 // Returns transcription text with optional timestamps
 func (c *MistralClient) Transcribe(model string, file io.Reader, filename string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	return c.TranscribeCtx(context.Background(), model, file, filename, params)
+}
+
+// TranscribeCtx is like Transcribe but uses ctx for cancellation and deadlines.
+func (c *MistralClient) TranscribeCtx(ctx context.Context, model string, file io.Reader, filename string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	ctx = withOperation(ctx, "audio.transcriptions.create")
 	if params == nil {
 		params = &TranscriptionRequest{}
 	}
@@ -147,60 +153,48 @@ func (c *MistralClient) Transcribe(model string, file io.Reader, filename string
 	}
 
 	// Create request
-	req, err := http.NewRequest(http.MethodPost, c.endpoint+"/v1/audio/transcriptions", body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/v1/audio/transcriptions", body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", UserAgent)
-
-	// Send request with retry logic
-	client := &http.Client{Timeout: c.timeout}
-	var lastErr error
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		resp, err := client.Do(req)
-		if err != nil {
-			lastErr = err
-			if attempt < c.maxRetries {
-				continue
-			}
-			return nil, NewMistralConnectionError(err.Error())
-		}
-		defer resp.Body.Close()
-
-		// Check if we should retry
-		if retryStatusCodes[resp.StatusCode] && attempt < c.maxRetries {
-			lastErr = fmt.Errorf("received retry status code: %d", resp.StatusCode)
-			continue
-		}
 
-		// Read response body
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
+	resp, err := c.doWithRetry(req, RequestKindUpload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-		// Check for errors
-		if resp.StatusCode >= 400 {
-			return nil, NewMistralAPIError(string(respBody), resp.StatusCode, resp.Header)
-		}
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
 
-		// Parse response
-		var result TranscriptionResponse
-		if err := json.Unmarshal(respBody, &result); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-		}
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp.StatusCode, resp.Header, respBody)
+	}
 
-		return &result, nil
+	// Parse response
+	var result TranscriptionResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+	return &result, nil
 }
 
 // TranscribeFromURL transcribes an audio file from a URL
 func (c *MistralClient) TranscribeFromURL(model string, fileURL string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	return c.TranscribeFromURLCtx(context.Background(), model, fileURL, params)
+}
+
+// TranscribeFromURLCtx is like TranscribeFromURL but uses ctx for cancellation and deadlines.
+func (c *MistralClient) TranscribeFromURLCtx(ctx context.Context, model string, fileURL string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	ctx = withOperation(ctx, "audio.transcriptions.create")
 	if params == nil {
 		params = &TranscriptionRequest{}
 	}
@@ -210,19 +204,8 @@ func (c *MistralClient) TranscribeFromURL(model string, fileURL string, params *
 
 	reqMap := buildTranscriptionRequestMap(params)
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/audio/transcriptions", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var transcriptionResponse TranscriptionResponse
-	err = mapToStruct(respData, &transcriptionResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/audio/transcriptions", &transcriptionResponse); err != nil {
 		return nil, err
 	}
 
@@ -230,7 +213,13 @@ func (c *MistralClient) TranscribeFromURL(model string, fileURL string, params *
 }
 
 // TranscribeStream transcribes audio with SSE streaming responses.
-func (c *MistralClient) TranscribeStream(model string, file io.Reader, filename string, params *TranscriptionRequest) (<-chan TranscriptionStreamEvent, error) {
+func (c *MistralClient) TranscribeStream(model string, file io.Reader, filename string, params *TranscriptionRequest) (*Stream[TranscriptionStreamEvent], error) {
+	return c.TranscribeStreamCtx(context.Background(), model, file, filename, params)
+}
+
+// TranscribeStreamCtx is like TranscribeStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) TranscribeStreamCtx(ctx context.Context, model string, file io.Reader, filename string, params *TranscriptionRequest) (*Stream[TranscriptionStreamEvent], error) {
+	ctx = withOperation(ctx, "audio.transcriptions.stream")
 	if params == nil {
 		params = &TranscriptionRequest{}
 	}
@@ -284,75 +273,52 @@ func (c *MistralClient) TranscribeStream(model string, file io.Reader, filename
 		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.endpoint+"/v1/audio/transcriptions", body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/v1/audio/transcriptions", body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Accept", "text/event-stream")
-	req.Header.Set("User-Agent", UserAgent)
 
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.doWithRetry(req, RequestKindStream)
 	if err != nil {
-		return nil, NewMistralConnectionError(err.Error())
+		return nil, err
 	}
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, NewMistralAPIError(string(respBody), resp.StatusCode, resp.Header)
+		return nil, newAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
-	out := make(chan TranscriptionStreamEvent)
-	go func() {
-		defer close(out)
-		defer resp.Body.Close()
-
-		reader := bufio.NewReader(resp.Body)
-		for {
-			line, readErr := reader.ReadBytes('\n')
-			if readErr == io.EOF {
-				break
-			}
-			if readErr != nil {
-				out <- TranscriptionStreamEvent{Error: fmt.Errorf("error reading stream response: %w", readErr)}
-				return
-			}
-
-			if bytes.Equal(line, []byte("\n")) {
-				continue
-			}
-			if !bytes.HasPrefix(line, []byte("data: ")) {
-				continue
-			}
-
-			jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-			if bytes.Equal(jsonLine, []byte("[DONE]")) {
-				break
-			}
-
-			var payload map[string]any
-			if err := json.Unmarshal(jsonLine, &payload); err != nil {
-				out <- TranscriptionStreamEvent{Error: fmt.Errorf("error decoding stream event: %w", err)}
-				continue
-			}
-
-			event := TranscriptionStreamEvent{}
-			if eventType, ok := payload["type"].(string); ok {
-				event.Type = eventType
-			}
+	return newStream(ctx, resp.Body, c.streamIdleTimeout, decodeTranscriptionEvent, nil), nil
+}
 
-			var data TranscriptionResponse
-			if err := mapToStruct(payload, &data); err == nil {
-				event.Data = &data
-			}
+func decodeTranscriptionEvent(event *SSEEvent) (TranscriptionStreamEvent, error) {
+	eventType, _, err := decodeEventPayload(event)
+	if err != nil {
+		return TranscriptionStreamEvent{}, err
+	}
+	result := TranscriptionStreamEvent{Type: eventType}
+	var data TranscriptionResponse
+	if json.Unmarshal(event.Data, &data) == nil {
+		result.Data = &data
+	}
+	return result, nil
+}
 
-			out <- event
-		}
-	}()
+// TranscribeStreamChan is like TranscribeStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) TranscribeStreamChan(model string, file io.Reader, filename string, params *TranscriptionRequest) (<-chan TranscriptionStreamEvent, error) {
+	return c.TranscribeStreamChanCtx(context.Background(), model, file, filename, params)
+}
 
-	return out, nil
+// TranscribeStreamChanCtx is like TranscribeStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) TranscribeStreamChanCtx(ctx context.Context, model string, file io.Reader, filename string, params *TranscriptionRequest) (<-chan TranscriptionStreamEvent, error) {
+	stream, err := c.TranscribeStreamCtx(ctx, model, file, filename, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) TranscriptionStreamEvent { return TranscriptionStreamEvent{Error: err} }), nil
 }
 
 func buildTranscriptionRequestMap(params *TranscriptionRequest) map[string]interface{} {
@@ -385,6 +351,12 @@ func buildTranscriptionRequestMap(params *TranscriptionRequest) map[string]inter
 
 // TranscribeFromFileID transcribes an audio file that was previously uploaded
 func (c *MistralClient) TranscribeFromFileID(model string, fileID string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	return c.TranscribeFromFileIDCtx(context.Background(), model, fileID, params)
+}
+
+// TranscribeFromFileIDCtx is like TranscribeFromFileID but uses ctx for cancellation and deadlines.
+func (c *MistralClient) TranscribeFromFileIDCtx(ctx context.Context, model string, fileID string, params *TranscriptionRequest) (*TranscriptionResponse, error) {
+	ctx = withOperation(ctx, "audio.transcriptions.create")
 	if params == nil {
 		params = &TranscriptionRequest{}
 	}
@@ -394,19 +366,8 @@ func (c *MistralClient) TranscribeFromFileID(model string, fileID string, params
 
 	reqMap := buildTranscriptionRequestMap(params)
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/audio/transcriptions", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var transcriptionResponse TranscriptionResponse
-	err = mapToStruct(respData, &transcriptionResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/audio/transcriptions", &transcriptionResponse); err != nil {
 		return nil, err
 	}
 
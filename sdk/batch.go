@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -108,6 +109,12 @@ type ListBatchJobsParams struct {
 
 // CreateBatchJob creates a new batch job
 func (c *MistralClient) CreateBatchJob(req *CreateBatchJobRequest) (*BatchJobOut, error) {
+	return c.CreateBatchJobCtx(context.Background(), req)
+}
+
+// CreateBatchJobCtx is like CreateBatchJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CreateBatchJobCtx(ctx context.Context, req *CreateBatchJobRequest) (*BatchJobOut, error) {
+	ctx = withOperation(ctx, "batch.jobs.create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -136,19 +143,8 @@ func (c *MistralClient) CreateBatchJob(req *CreateBatchJobRequest) (*BatchJobOut
 		payload["requests"] = req.Requests
 	}
 
-	response, err := c.request(http.MethodPost, payload, "v1/batch/jobs", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var batchJobOut BatchJobOut
-	err = mapToStruct(respData, &batchJobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, payload, "v1/batch/jobs", &batchJobOut); err != nil {
 		return nil, err
 	}
 
@@ -157,6 +153,12 @@ func (c *MistralClient) CreateBatchJob(req *CreateBatchJobRequest) (*BatchJobOut
 
 // ListBatchJobs gets a list of batch jobs
 func (c *MistralClient) ListBatchJobs(params *ListBatchJobsParams) (*BatchJobsOut, error) {
+	return c.ListBatchJobsCtx(context.Background(), params)
+}
+
+// ListBatchJobsCtx is like ListBatchJobs but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListBatchJobsCtx(ctx context.Context, params *ListBatchJobsParams) (*BatchJobsOut, error) {
+	ctx = withOperation(ctx, "batch.jobs.list")
 	if params == nil {
 		params = &ListBatchJobsParams{}
 	}
@@ -193,19 +195,8 @@ func (c *MistralClient) ListBatchJobs(params *ListBatchJobsParams) (*BatchJobsOu
 		path += "?" + queryParams.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var batchJobsOut BatchJobsOut
-	err = mapToStruct(respData, &batchJobsOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &batchJobsOut); err != nil {
 		return nil, err
 	}
 
@@ -216,24 +207,19 @@ func (c *MistralClient) ListBatchJobs(params *ListBatchJobsParams) (*BatchJobsOu
 //
 // Pass inline=true to request inline results in the response.
 func (c *MistralClient) GetBatchJob(jobID string, inline ...bool) (*BatchJobOut, error) {
+	return c.GetBatchJobCtx(context.Background(), jobID, inline...)
+}
+
+// GetBatchJobCtx is like GetBatchJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetBatchJobCtx(ctx context.Context, jobID string, inline ...bool) (*BatchJobOut, error) {
+	ctx = withOperation(ctx, "batch.jobs.get")
 	path := fmt.Sprintf("v1/batch/jobs/%s", jobID)
 	if len(inline) > 0 {
 		path = fmt.Sprintf("%s?inline=%t", path, inline[0])
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var batchJobOut BatchJobOut
-	err = mapToStruct(respData, &batchJobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &batchJobOut); err != nil {
 		return nil, err
 	}
 
@@ -242,19 +228,14 @@ func (c *MistralClient) GetBatchJob(jobID string, inline ...bool) (*BatchJobOut,
 
 // CancelBatchJob cancels a batch job
 func (c *MistralClient) CancelBatchJob(jobID string) (*BatchJobOut, error) {
-	response, err := c.request(http.MethodPost, nil, fmt.Sprintf("v1/batch/jobs/%s/cancel", jobID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.CancelBatchJobCtx(context.Background(), jobID)
+}
 
+// CancelBatchJobCtx is like CancelBatchJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CancelBatchJobCtx(ctx context.Context, jobID string) (*BatchJobOut, error) {
+	ctx = withOperation(ctx, "batch.jobs.cancel")
 	var batchJobOut BatchJobOut
-	err = mapToStruct(respData, &batchJobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, nil, fmt.Sprintf("v1/batch/jobs/%s/cancel", jobID), &batchJobOut); err != nil {
 		return nil, err
 	}
 
@@ -263,19 +244,14 @@ func (c *MistralClient) CancelBatchJob(jobID string) (*BatchJobOut, error) {
 
 // DeleteBatchJob requests deletion of a batch job.
 func (c *MistralClient) DeleteBatchJob(jobID string) (*DeleteBatchJobResponse, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/batch/jobs/%s", jobID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.DeleteBatchJobCtx(context.Background(), jobID)
+}
 
+// DeleteBatchJobCtx is like DeleteBatchJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteBatchJobCtx(ctx context.Context, jobID string) (*DeleteBatchJobResponse, error) {
+	ctx = withOperation(ctx, "batch.jobs.delete")
 	var deleteResponse DeleteBatchJobResponse
-	err = mapToStruct(respData, &deleteResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/batch/jobs/%s", jobID), &deleteResponse); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,381 @@
+// Package cassette records HTTP interactions with the Mistral API into a file and replays them
+// offline, so code built on sdk.MistralClient can be tested deterministically without network access.
+//
+//	rec, err := cassette.New("testdata/chat.json", cassette.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	client := sdk.NewClient(sdk.WithAPIKey("test"), sdk.WithTransport(rec))
+//
+// Run the test once in ModeRecord with a real API key to create the cassette. Authorization headers
+// are scrubbed before anything is written.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder talks to the network.
+type Mode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails requests that were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the network and rewrites the cassette with the interactions.
+	ModeRecord
+	// ModeReplayOrRecord replays recorded interactions and records the ones that are missing.
+	ModeReplayOrRecord
+)
+
+// ErrNoInteraction is returned in ModeReplay when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// DefaultScrubbedHeaders are never written to a cassette.
+var DefaultScrubbedHeaders = []string{"Authorization", "X-Api-Key", "Cookie", "Set-Cookie"}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"` // Used instead of Body for binary payloads
+}
+
+// Response is a recorded HTTP response. SSE streams are stored as their full body.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// Matcher reports whether a live request, with its already read body, matches a recorded one.
+type Matcher func(req *http.Request, body []byte, recorded *Request) bool
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sends recorded requests through next instead of http.DefaultTransport.
+func WithTransport(next http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.next = next
+	}
+}
+
+// WithMatcher replaces the default matcher, which compares the method, path, query and normalized body.
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// WithIgnoredFields makes the default matcher ignore JSON body fields with these names at any depth,
+// for example "random_seed" or a request timestamp that changes between runs.
+func WithIgnoredFields(fields ...string) Option {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.ignored[field] = true
+		}
+	}
+}
+
+// WithScrubbedHeaders adds headers that are removed from recorded requests and responses.
+func WithScrubbedHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.scrubbed = append(r.scrubbed, headers...)
+	}
+}
+
+// Recorder is an http.RoundTripper that records interactions into, or replays them from, a cassette file.
+// Replayed interactions are consumed in order, so repeated identical requests such as polling get
+// the responses recorded for them.
+type Recorder struct {
+	path     string
+	mode     Mode
+	next     http.RoundTripper
+	matcher  Matcher
+	ignored  map[string]bool
+	scrubbed []string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// New opens the cassette at path. In ModeReplay the file must exist; in ModeRecord it is replaced.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		next:     http.DefaultTransport,
+		ignored:  map[string]bool{},
+		scrubbed: append([]string(nil), DefaultScrubbedHeaders...),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.matcher == nil {
+		r.matcher = r.defaultMatcher
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == ModeReplayOrRecord {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// Interactions returns the interactions currently in the cassette.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Interaction, len(r.interactions))
+	for i, interaction := range r.interactions {
+		out[i] = *interaction
+	}
+	return out
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode != ModeRecord {
+		if interaction := r.match(req, body); interaction != nil {
+			return interaction.Response.toHTTP(req)
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+		}
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) match(req *http.Request, body []byte) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if !r.used[i] && r.matcher(req, body, &interaction.Request) {
+			r.used[i] = true
+			return interaction
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	live := req.Clone(req.Context())
+	live.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.next.RoundTrip(live)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request:  Request{Method: req.Method, URL: req.URL.String(), Header: r.scrub(req.Header)},
+		Response: Response{StatusCode: resp.StatusCode, Header: r.scrub(resp.Header)},
+	}
+	interaction.Request.Body, interaction.Request.BodyBase64 = encodeBody(body)
+	interaction.Response.Body, interaction.Response.BodyBase64 = encodeBody(respBody)
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.used = append(r.used, true)
+	err = r.save()
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// save writes the cassette; r.mu must be held.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) scrub(header http.Header) http.Header {
+	out := header.Clone()
+	for _, name := range r.scrubbed {
+		out.Del(name)
+	}
+	return out
+}
+
+// defaultMatcher compares method, path, query and body. JSON bodies are compared without the ignored
+// fields, and multipart bodies by their parts, so the random boundary does not matter.
+func (r *Recorder) defaultMatcher(req *http.Request, body []byte, recorded *Request) bool {
+	if req.Method != recorded.Method {
+		return false
+	}
+	recordedURL, err := req.URL.Parse(recorded.URL)
+	if err != nil || recordedURL.Path != req.URL.Path || !reflect.DeepEqual(recordedURL.Query(), req.URL.Query()) {
+		return false
+	}
+	recordedBody, err := decodeBody(recorded.Body, recorded.BodyBase64)
+	if err != nil {
+		return false
+	}
+	return r.normalize(req.Header.Get("Content-Type"), body) == r.normalize(recorded.Header.Get("Content-Type"), recordedBody)
+}
+
+func (r *Recorder) normalize(contentType string, body []byte) string {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json":
+		var payload any
+		if json.Unmarshal(body, &payload) == nil {
+			if normalized, err := json.Marshal(r.dropIgnored(payload)); err == nil {
+				return string(normalized)
+			}
+		}
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		if normalized, err := r.normalizeMultipart(body, params["boundary"]); err == nil {
+			return normalized
+		}
+	}
+	return string(body)
+}
+
+func (r *Recorder) dropIgnored(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.ignored[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = r.dropIgnored(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.dropIgnored(item)
+		}
+	}
+	return value
+}
+
+func (r *Recorder) normalizeMultipart(body []byte, boundary string) (string, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if r.ignored[part.FormName()] {
+			continue
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part.FormName()+"\x00"+part.FileName()+"\x00"+string(content))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\x01"), nil
+}
+
+func (resp *Response) toHTTP(req *http.Request) (*http.Response, error) {
+	body, err := decodeBody(resp.Body, resp.BodyBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cassette body: %w", err)
+	}
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return body, nil
+}
+
+// encodeBody stores text bodies as-is and binary bodies, which JSON cannot hold, as base64.
+func encodeBody(body []byte) (text, encoded string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeBody(text, encoded string) ([]byte, error) {
+	if encoded != "" {
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+	return []byte(text), nil
+}
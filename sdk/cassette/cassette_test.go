@@ -0,0 +1,149 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+func recordingServer(t *testing.T) *sdk.MockHTTPServer {
+	t.Helper()
+	mock := sdk.NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/files":
+			sdk.MockFileUploadResponse().Write(w)
+		case "/v1/chat/completions":
+			if strings.Contains(sdk.ReadRequestBody(r), `"stream":true`) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n")
+				_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n")
+				_, _ = io.WriteString(w, "data: [DONE]\n\n")
+				return
+			}
+			sdk.MockChatResponse().Write(w)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	t.Cleanup(mock.Close)
+	return mock
+}
+
+func exercise(t *testing.T, client *sdk.MistralClient, seed int) {
+	t.Helper()
+	params := &sdk.ChatRequestParams{RandomSeed: sdk.IntPtr(seed)}
+	resp, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, params)
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if resp.ID != "chat-123" {
+		t.Errorf("unexpected chat response %+v", resp)
+	}
+
+	stream, err := client.ChatStreamChan("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("stream")}, params)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	var text strings.Builder
+	for chunk := range stream {
+		if chunk.Error != nil {
+			t.Fatalf("stream chunk: %v", chunk.Error)
+		}
+		text.WriteString(chunk.Choices[0].Delta.Content)
+	}
+	if text.String() != "Hello" {
+		t.Errorf("expected streamed text Hello, got %q", text.String())
+	}
+
+	file, err := client.UploadFile(bytes.NewReader([]byte{0xff, 0xfe, 0x00, 0x01}), "data.bin", sdk.FilePurposeFineTune)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if file.ID != "file-123" {
+		t.Errorf("unexpected upload response %+v", file)
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "session.json")
+	mock := recordingServer(t)
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercise(t, sdk.NewClient(sdk.WithAPIKey("real-secret"), sdk.WithBaseURL(mock.Server.URL), sdk.WithTransport(rec)), 1)
+	if len(mock.Requests) != 3 || len(rec.Interactions()) != 3 {
+		t.Fatalf("expected 3 recorded interactions, got %d requests and %d interactions", len(mock.Requests), len(rec.Interactions()))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("real-secret")) {
+		t.Error("the API key must be scrubbed from the cassette")
+	}
+
+	mock.Close()
+	replay, err := New(path, ModeReplay, WithIgnoredFields("random_seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercise(t, sdk.NewClient(sdk.WithAPIKey("other"), sdk.WithBaseURL(mock.Server.URL), sdk.WithTransport(replay), sdk.WithMaxRetries(1)), 2)
+}
+
+func TestReplayRejectsUnrecordedRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	mock := recordingServer(t)
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := sdk.NewClient(sdk.WithBaseURL(mock.Server.URL), sdk.WithTransport(rec))
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = sdk.NewClient(sdk.WithBaseURL(mock.Server.URL), sdk.WithTransport(replay), sdk.WithMaxRetries(1))
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("something else")}, nil); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction for a different prompt, got %v", err)
+	}
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); err != nil {
+		t.Errorf("expected the recorded prompt to replay, got %v", err)
+	}
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected each interaction to replay once, got %v", err)
+	}
+}
+
+func TestReplayOrRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	mock := recordingServer(t)
+
+	for i := 0; i < 2; i++ {
+		rec, err := New(path, ModeReplayOrRecord)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := sdk.NewClient(sdk.WithBaseURL(mock.Server.URL), sdk.WithTransport(rec))
+		if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(mock.Requests) != 1 {
+		t.Errorf("expected the second run to replay, got %d live requests", len(mock.Requests))
+	}
+}
@@ -1,8 +1,7 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -98,6 +97,11 @@ type UsageInfo struct {
 }
 
 func (c *MistralClient) Chat(model string, messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	return c.ChatCtx(context.Background(), model, messages, params)
+}
+
+func (c *MistralClient) ChatCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	ctx = withOperation(ctx, "chat.completions")
 	if params == nil {
 		params = NewChatRequestParams()
 	}
@@ -169,33 +173,27 @@ func (c *MistralClient) Chat(model string, messages []ChatMessage, params *ChatR
 		requestData["prompt_cache_key"] = *params.PromptCacheKey
 	}
 
-	response, err := c.request(http.MethodPost, requestData, "v1/chat/completions", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var chatResponse ChatCompletionResponse
-	err = mapToStruct(respData, &chatResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, requestData, "v1/chat/completions", &chatResponse); err != nil {
 		return nil, err
 	}
 
 	return &chatResponse, nil
 }
 
-// ChatStream sends a chat message and returns a channel to receive streaming responses.
-func (c *MistralClient) ChatStream(model string, messages []ChatMessage, params *ChatRequestParams) (<-chan ChatCompletionStreamResponse, error) {
+// ChatStream sends a chat message and returns a Stream of the response chunks. Close the stream
+// when done with it. ChatStreamChan delivers the chunks on a channel instead.
+func (c *MistralClient) ChatStream(model string, messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], error) {
+	return c.ChatStreamCtx(context.Background(), model, messages, params)
+}
+
+// ChatStreamCtx is like ChatStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ChatStreamCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], error) {
+	ctx = withOperation(ctx, "chat.completions.stream")
 	if params == nil {
 		params = NewChatRequestParams()
 	}
 
-	responseChannel := make(chan ChatCompletionStreamResponse)
-
 	requestData := map[string]interface{}{
 		"model":    model,
 		"messages": messages,
@@ -264,7 +262,7 @@ func (c *MistralClient) ChatStream(model string, messages []ChatMessage, params
 		requestData["prompt_cache_key"] = *params.PromptCacheKey
 	}
 
-	response, err := c.request(http.MethodPost, requestData, "v1/chat/completions", true, nil)
+	response, err := c.request(ctx, http.MethodPost, requestData, "v1/chat/completions", true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -274,57 +272,21 @@ func (c *MistralClient) ChatStream(model string, messages []ChatMessage, params
 		return nil, fmt.Errorf("invalid response type: %T", response)
 	}
 
-	// Execute the HTTP request in a separate goroutine.
-	go func() {
-		defer close(responseChannel)
-		defer respBody.Close()
-
-		// Assuming ChatCompletionStreamResponse is already defined in your Go code.
-		// Assuming responseChannel is a channel of ChatCompletionStreamResponse.
-
-		// Create a buffered reader to read the stream line by line.
-		reader := bufio.NewReader(respBody)
-
-		for {
-			// Read a line from the buffered reader.
-			line, err := reader.ReadBytes('\n')
-			if err == io.EOF {
-				break // End of stream.
-			} else if err != nil {
-				responseChannel <- ChatCompletionStreamResponse{Error: fmt.Errorf("error reading stream response: %w", err)}
-				return
-			}
-
-			// Skip empty lines.
-			if bytes.Equal(line, []byte("\n")) {
-				continue
-			}
-
-			// Check if the line starts with "data: ".
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				// Trim the prefix and any leading or trailing whitespace.
-				jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-
-				// Check for the special "[DONE]" message.
-				if bytes.Equal(jsonLine, []byte("[DONE]")) {
-					break
-				}
-
-				// Decode the JSON object from the line.
-				var streamResponse ChatCompletionStreamResponse
-				if err := json.Unmarshal(jsonLine, &streamResponse); err != nil {
-					responseChannel <- ChatCompletionStreamResponse{Error: fmt.Errorf("error decoding stream response: %w", err)}
-					continue
-				}
-
-				// Send the decoded response to the channel.
-				responseChannel <- streamResponse
-			}
-		}
-	}()
-
-	// Return the response channel.
-	return responseChannel, nil
+	return newStream(ctx, respBody, c.streamIdleTimeout, decodeChunk[ChatCompletionStreamResponse], nil), nil
+}
+
+// ChatStreamChan is like ChatStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) ChatStreamChan(model string, messages []ChatMessage, params *ChatRequestParams) (<-chan ChatCompletionStreamResponse, error) {
+	return c.ChatStreamChanCtx(context.Background(), model, messages, params)
+}
+
+// ChatStreamChanCtx is like ChatStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ChatStreamChanCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (<-chan ChatCompletionStreamResponse, error) {
+	stream, err := c.ChatStreamCtx(ctx, model, messages, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) ChatCompletionStreamResponse { return ChatCompletionStreamResponse{Error: err} }), nil
 }
 
 // mapToStruct is a helper function to convert a map to a struct.
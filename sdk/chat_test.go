@@ -280,7 +280,7 @@ func TestChatStream(t *testing.T) {
 	params := NewChatRequestParams()
 	params.MaxTokens = IntPtr(50)
 	params.Temperature = Float64Ptr(0)
-	resChan, err := client.ChatStream(
+	resChan, err := client.ChatStreamChan(
 		"mistral-tiny-2312",
 		[]ChatMessage{
 			{
@@ -361,7 +361,7 @@ func TestChatStreamFunctionCall(t *testing.T) {
 		},
 	}
 	params.ToolChoice = ToolChoiceAuto
-	resChan, err := client.ChatStream(
+	resChan, err := client.ChatStreamChan(
 		"mistral-small-latest",
 		[]ChatMessage{
 			{
@@ -424,7 +424,7 @@ func TestChatStreamJsonMode(t *testing.T) {
 	params := NewChatRequestParams()
 	params.Temperature = Float64Ptr(0)
 	params.ResponseFormat = ResponseFormatJsonObject
-	resChan, err := client.ChatStream(
+	resChan, err := client.ChatStreamChan(
 		"open-mixtral-8x22b",
 		[]ChatMessage{
 			{
@@ -1,7 +1,7 @@
 package sdk
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -58,24 +58,19 @@ type ModerationResponse struct {
 //
 // Returns moderation results with category scores
 func (c *MistralClient) Moderate(model string, inputs []ClassificationInput) (*ModerationResponse, error) {
+	return c.ModerateCtx(context.Background(), model, inputs)
+}
+
+// ModerateCtx is like Moderate but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ModerateCtx(ctx context.Context, model string, inputs []ClassificationInput) (*ModerationResponse, error) {
+	ctx = withOperation(ctx, "classifiers.moderate")
 	reqMap := map[string]interface{}{
 		"model":  model,
 		"inputs": inputs,
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/moderations", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var moderationResponse ModerationResponse
-	err = mapToStruct(respData, &moderationResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/moderations", &moderationResponse); err != nil {
 		return nil, err
 	}
 
@@ -84,24 +79,19 @@ func (c *MistralClient) Moderate(model string, inputs []ClassificationInput) (*M
 
 // ModerateChat performs chat moderation.
 func (c *MistralClient) ModerateChat(model string, inputs []ChatMessage) (*ModerationResponse, error) {
+	return c.ModerateChatCtx(context.Background(), model, inputs)
+}
+
+// ModerateChatCtx is like ModerateChat but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ModerateChatCtx(ctx context.Context, model string, inputs []ChatMessage) (*ModerationResponse, error) {
+	ctx = withOperation(ctx, "classifiers.moderate_chat")
 	reqMap := map[string]interface{}{
 		"model":  model,
 		"inputs": inputs,
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/chat/moderations", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var moderationResponse ModerationResponse
-	err = mapToStruct(respData, &moderationResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/chat/moderations", &moderationResponse); err != nil {
 		return nil, err
 	}
 
@@ -117,24 +107,19 @@ type ClassificationResponse struct {
 
 // Classify performs text classification.
 func (c *MistralClient) Classify(model string, inputs []ClassificationInput) (*ClassificationResponse, error) {
+	return c.ClassifyCtx(context.Background(), model, inputs)
+}
+
+// ClassifyCtx is like Classify but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ClassifyCtx(ctx context.Context, model string, inputs []ClassificationInput) (*ClassificationResponse, error) {
+	ctx = withOperation(ctx, "classifiers.classify")
 	reqMap := map[string]interface{}{
 		"model":  model,
 		"inputs": inputs,
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/classifications", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var classificationResponse ClassificationResponse
-	err = mapToStruct(respData, &classificationResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/classifications", &classificationResponse); err != nil {
 		return nil, err
 	}
 
@@ -143,24 +128,19 @@ func (c *MistralClient) Classify(model string, inputs []ClassificationInput) (*C
 
 // ClassifyChat performs chat classification.
 func (c *MistralClient) ClassifyChat(model string, inputs []ChatClassificationInput) (*ClassificationResponse, error) {
+	return c.ClassifyChatCtx(context.Background(), model, inputs)
+}
+
+// ClassifyChatCtx is like ClassifyChat but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ClassifyChatCtx(ctx context.Context, model string, inputs []ChatClassificationInput) (*ClassificationResponse, error) {
+	ctx = withOperation(ctx, "classifiers.classify_chat")
 	reqMap := map[string]interface{}{
 		"model":  model,
 		"inputs": inputs,
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/chat/classifications", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var classificationResponse ClassificationResponse
-	err = mapToStruct(respData, &classificationResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/chat/classifications", &classificationResponse); err != nil {
 		return nil, err
 	}
 
@@ -175,10 +155,16 @@ func (c *MistralClient) ClassifyChat(model string, inputs []ChatClassificationIn
 //
 // Returns moderation results
 func (c *MistralClient) ModerateText(model string, texts []string) (*ModerationResponse, error) {
+	return c.ModerateTextCtx(context.Background(), model, texts)
+}
+
+// ModerateTextCtx is like ModerateText but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ModerateTextCtx(ctx context.Context, model string, texts []string) (*ModerationResponse, error) {
+	ctx = withOperation(ctx, "classifiers.moderate")
 	inputs := make([]ClassificationInput, len(texts))
 	for i, text := range texts {
 		inputs[i] = text
 	}
 
-	return c.Moderate(model, inputs)
+	return c.ModerateCtx(ctx, model, inputs)
 }
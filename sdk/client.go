@@ -2,8 +2,8 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -31,12 +31,26 @@ type MistralClient struct {
 	endpoint   string
 	maxRetries int
 	timeout    time.Duration
+
+	httpClient   *http.Client
+	transport    http.RoundTripper
+	retryPolicy  RetryPolicy
+	onRetry      RetryHook
+	middleware   []Middleware
+	rateLimiter  *RateLimiter
+	usageTracker *UsageTracker
+	logger       Logger
+	logLevels    LogLevels
+	credentials  CredentialProvider
+	observers    []Observer
+	metrics      Metrics
+	headers      http.Header
+	userAgent    string
+
+	streamIdleTimeout time.Duration
 }
 
 func NewMistralClient(apiKey string, endpoint string, maxRetries int, timeout time.Duration) *MistralClient {
-	if apiKey == "" {
-		apiKey = os.Getenv("MISTRAL_API_KEY")
-	}
 	if endpoint == "" {
 		endpoint = Endpoint
 	}
@@ -47,12 +61,12 @@ func NewMistralClient(apiKey string, endpoint string, maxRetries int, timeout ti
 		timeout = DefaultTimeout
 	}
 
-	return &MistralClient{
-		apiKey:     apiKey,
-		endpoint:   endpoint,
-		maxRetries: maxRetries,
-		timeout:    timeout,
-	}
+	return NewClient(
+		WithAPIKey(apiKey),
+		WithBaseURL(endpoint),
+		WithMaxRetries(maxRetries),
+		WithTimeout(timeout),
+	)
 }
 
 // NewMistralClientDefault creates a new Mistral API client with the default endpoint and the given API key. Defaults to using MISTRAL_API_KEY from the environment.
@@ -73,7 +87,51 @@ func NewCodestralClientDefault(apiKey string) *MistralClient {
 	return NewMistralClient(apiKey, CodestralEndpoint, DefaultMaxRetries, DefaultTimeout)
 }
 
-func (c *MistralClient) request(method string, jsonData map[string]interface{}, path string, stream bool, params map[string]string) (interface{}, error) {
+func (c *MistralClient) request(ctx context.Context, method string, jsonData map[string]interface{}, path string, stream bool, params map[string]string) (interface{}, error) {
+	resp, err := c.call(ctx, method, jsonData, path, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	if stream {
+		return resp.Body, nil
+	}
+
+	var result interface{} = map[string]interface{}{}
+	if err := decodeBody(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// requestInto sends a request and decodes the response body straight into out, which must be a
+// pointer. An empty body leaves out untouched.
+func (c *MistralClient) requestInto(ctx context.Context, method string, jsonData map[string]interface{}, path string, out interface{}) error {
+	resp, err := c.call(ctx, method, jsonData, path, false)
+	if err != nil {
+		return err
+	}
+	return decodeBody(resp.Body, out)
+}
+
+// decodeBody decodes a single JSON value from body into out and closes body. A nil out discards the
+// body.
+func decodeBody(body io.ReadCloser, out interface{}) error {
+	defer body.Close()
+	if out == nil {
+		_, err := io.Copy(io.Discard, body)
+		return err
+	}
+	err := json.NewDecoder(body).Decode(out)
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// call builds and sends an API request, returning the response when its status is below 400. The
+// caller owns the response body.
+func (c *MistralClient) call(ctx context.Context, method string, jsonData map[string]interface{}, path string, stream bool) (*http.Response, error) {
 	uri, err := url.Parse(c.endpoint)
 	if err != nil {
 		return nil, err
@@ -87,58 +145,91 @@ func (c *MistralClient) request(method string, jsonData map[string]interface{},
 		uri.Path = path
 	}
 	jsonValue, _ := json.Marshal(jsonData)
-	req, err := http.NewRequest(method, uri.String(), bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", UserAgent)
 
-	client := &http.Client{
-		Timeout: c.timeout,
+	kind := RequestKindDefault
+	if stream {
+		kind = RequestKindStream
 	}
-
-	var resp *http.Response
-	for i := 0; i < c.maxRetries; i++ {
-		resp, err = client.Do(req)
-		if err != nil {
-			if i == c.maxRetries-1 {
-				return nil, err
-			}
-			continue
-		}
-		if _, ok := retryStatusCodes[resp.StatusCode]; ok {
-			time.Sleep(time.Duration(i+1) * 500 * time.Millisecond)
-			continue
-		}
-		break
+	resp, err := c.doWithRetry(req, kind)
+	if err != nil {
+		return nil, err
 	}
 
 	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
 		responseBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("(HTTP Error %d) %s", resp.StatusCode, string(responseBytes))
+		return nil, newAPIError(resp.StatusCode, resp.Header, responseBytes)
 	}
+	return resp, nil
+}
 
-	if stream {
-		return resp.Body, nil
+// setHeaders applies the User-Agent and client-wide headers to h. Authorization is set per attempt by authorize.
+func (c *MistralClient) setHeaders(h http.Header) {
+	h.Set("User-Agent", c.agent())
+	for key, values := range c.headers {
+		h.Del(key)
+		for _, value := range values {
+			h.Add(key, value)
+		}
 	}
+}
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// do sends req through the middleware chain with the client's shared http.Client.
+func (c *MistralClient) do(req *http.Request) (*http.Response, error) {
+	return c.chain(c.send)(req)
+}
+
+func (c *MistralClient) send(req *http.Request) (*http.Response, error) {
+	return c.client().Do(req)
+}
+
+func (c *MistralClient) client() *http.Client {
+	if c.httpClient == nil {
+		return &http.Client{Timeout: c.timeout}
 	}
-	if len(bytes.TrimSpace(body)) == 0 {
-		return map[string]interface{}{}, nil
+	return c.httpClient
+}
+
+func (c *MistralClient) policy() RetryPolicy {
+	if c.retryPolicy == nil {
+		return NewDefaultRetryPolicy(c.maxRetries)
 	}
+	return c.retryPolicy
+}
 
-	var result interface{}
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return nil, err
+func (c *MistralClient) agent() string {
+	if c.userAgent == "" {
+		return UserAgent
 	}
+	return c.userAgent
+}
 
-	return result, nil
+// sleepCtx pauses for d or until ctx is done, whichever comes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendEvent delivers v on out unless ctx is done first. It reports whether v was sent,
+// so streaming goroutines can stop once the caller has gone away.
+func sendEvent[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
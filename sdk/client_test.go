@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -245,3 +246,38 @@ func TestClientRetryValues(t *testing.T) {
 		})
 	}
 }
+
+func TestResponsesKeepInt64Precision(t *testing.T) {
+	// 2^53 + 1 cannot be represented as a float64.
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockJSONResponse(200, `{"id": "file-1", "object": "file", "bytes": 9007199254740993, "created_at": 9007199254740993}`).Write(w)
+	})
+	defer mock.Close()
+
+	file, err := mock.GetClient().RetrieveFile("file-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Bytes != 9007199254740993 || file.CreatedAt != 9007199254740993 {
+		t.Errorf("expected 9007199254740993, got bytes %d and created_at %d", file.Bytes, file.CreatedAt)
+	}
+}
+
+func TestResponsesWithEmptyBody(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer mock.Close()
+	client := mock.GetClient()
+
+	deleted, err := client.DeleteLibrary("lib-1")
+	if err != nil || deleted != nil {
+		t.Errorf("expected no response and no error, got %+v, %v", deleted, err)
+	}
+	if err := client.DeleteConversation("conv-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := client.RetrieveModel("model-1"); err != nil {
+		t.Errorf("expected an empty body to decode as a zero value, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -67,6 +68,11 @@ type ToolExecutionConfiguration struct {
 }
 
 func (c *MistralClient) CreateConnector(req *ConnectorRequest) (APIResponse, error) {
+	return c.CreateConnectorCtx(context.Background(), req)
+}
+
+func (c *MistralClient) CreateConnectorCtx(ctx context.Context, req *ConnectorRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -84,22 +90,32 @@ func (c *MistralClient) CreateConnector(req *ConnectorRequest) (APIResponse, err
 		"oauth2_server_metadata_url": req.OAuth2ServerMetadataURL,
 		"system_prompt":              req.SystemPrompt,
 	})
-	return c.requestMap(http.MethodPost, body, "v1/connectors")
+	return c.requestMap(ctx, http.MethodPost, body, "v1/connectors")
 }
 
 func (c *MistralClient) ListConnectors(params *ListConnectorsParams) (APIResponse, error) {
+	return c.ListConnectorsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListConnectorsCtx(ctx context.Context, params *ListConnectorsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.list")
 	if params == nil {
 		params = &ListConnectorsParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"cursor": params.Cursor, "page_size": params.PageSize})
 	body := optionalRequestMap(map[string]any{"query_filters": params.QueryFilters})
 	if len(body) == 0 {
-		return c.requestMap(http.MethodGet, nil, appendQuery("v1/connectors", query))
+		return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/connectors", query))
 	}
-	return c.requestMap(http.MethodGet, body, appendQuery("v1/connectors", query))
+	return c.requestMap(ctx, http.MethodGet, body, appendQuery("v1/connectors", query))
 }
 
 func (c *MistralClient) GetConnectorAuthURL(connectorIDOrName string, appReturnURL *string, credentialsName *string, githubInstallationLink ...*bool) (APIResponse, error) {
+	return c.GetConnectorAuthURLCtx(context.Background(), connectorIDOrName, appReturnURL, credentialsName, githubInstallationLink...)
+}
+
+func (c *MistralClient) GetConnectorAuthURLCtx(ctx context.Context, connectorIDOrName string, appReturnURL *string, credentialsName *string, githubInstallationLink ...*bool) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.get_auth_url")
 	var githubLink *bool
 	if len(githubInstallationLink) > 0 {
 		githubLink = githubInstallationLink[0]
@@ -109,16 +125,26 @@ func (c *MistralClient) GetConnectorAuthURL(connectorIDOrName string, appReturnU
 		"credentials_name":         credentialsName,
 		"github_installation_link": githubLink,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/auth_url", connectorIDOrName), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/auth_url", connectorIDOrName), query))
 }
 
 func (c *MistralClient) CallConnectorTool(connectorIDOrName, toolName string, credentialsName *string, arguments map[string]any) (APIResponse, error) {
+	return c.CallConnectorToolCtx(context.Background(), connectorIDOrName, toolName, credentialsName, arguments)
+}
+
+func (c *MistralClient) CallConnectorToolCtx(ctx context.Context, connectorIDOrName, toolName string, credentialsName *string, arguments map[string]any) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.call_tool")
 	query := queryWithOptionalValues(map[string]any{"credentials_name": credentialsName})
 	body := optionalRequestMap(map[string]any{"arguments": arguments})
-	return c.requestMap(http.MethodPost, body, appendQuery(fmt.Sprintf("v1/connectors/%s/tools/%s/call", connectorIDOrName, toolName), query))
+	return c.requestMap(ctx, http.MethodPost, body, appendQuery(fmt.Sprintf("v1/connectors/%s/tools/%s/call", connectorIDOrName, toolName), query))
 }
 
 func (c *MistralClient) ListConnectorTools(connectorIDOrName string, params *ListConnectorToolsParams) (APIResponse, error) {
+	return c.ListConnectorToolsCtx(context.Background(), connectorIDOrName, params)
+}
+
+func (c *MistralClient) ListConnectorToolsCtx(ctx context.Context, connectorIDOrName string, params *ListConnectorToolsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.list_tools")
 	if params == nil {
 		params = &ListConnectorToolsParams{}
 	}
@@ -129,79 +155,169 @@ func (c *MistralClient) ListConnectorTools(connectorIDOrName string, params *Lis
 		"pretty":           params.Pretty,
 		"credentials_name": params.CredentialsName,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/tools", connectorIDOrName), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/tools", connectorIDOrName), query))
 }
 
 func (c *MistralClient) GetConnectorAuthenticationMethods(connectorIDOrName string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/connectors/%s/authentication_methods", connectorIDOrName))
+	return c.GetConnectorAuthenticationMethodsCtx(context.Background(), connectorIDOrName)
+}
+
+func (c *MistralClient) GetConnectorAuthenticationMethodsCtx(ctx context.Context, connectorIDOrName string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.get_authentication_methods")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/connectors/%s/authentication_methods", connectorIDOrName))
 }
 
 func (c *MistralClient) ActivateConnectorForOrganization(connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
-	return c.activateConnector(connectorID, "organization", config)
+	return c.ActivateConnectorForOrganizationCtx(context.Background(), connectorID, config)
+}
+
+func (c *MistralClient) ActivateConnectorForOrganizationCtx(ctx context.Context, connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.activate_for_organization")
+	return c.activateConnector(ctx, connectorID, "organization", config)
 }
 
 func (c *MistralClient) DeactivateConnectorForOrganization(connectorID string) (APIResponse, error) {
-	return c.deactivateConnector(connectorID, "organization")
+	return c.DeactivateConnectorForOrganizationCtx(context.Background(), connectorID)
+}
+
+func (c *MistralClient) DeactivateConnectorForOrganizationCtx(ctx context.Context, connectorID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.deactivate_for_organization")
+	return c.deactivateConnector(ctx, connectorID, "organization")
 }
 
 func (c *MistralClient) ActivateConnectorForWorkspace(connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
-	return c.activateConnector(connectorID, "workspace", config)
+	return c.ActivateConnectorForWorkspaceCtx(context.Background(), connectorID, config)
+}
+
+func (c *MistralClient) ActivateConnectorForWorkspaceCtx(ctx context.Context, connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.activate_for_workspace")
+	return c.activateConnector(ctx, connectorID, "workspace", config)
 }
 
 func (c *MistralClient) DeactivateConnectorForWorkspace(connectorID string) (APIResponse, error) {
-	return c.deactivateConnector(connectorID, "workspace")
+	return c.DeactivateConnectorForWorkspaceCtx(context.Background(), connectorID)
+}
+
+func (c *MistralClient) DeactivateConnectorForWorkspaceCtx(ctx context.Context, connectorID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.deactivate_for_workspace")
+	return c.deactivateConnector(ctx, connectorID, "workspace")
 }
 
 func (c *MistralClient) ActivateConnectorForUser(connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
-	return c.activateConnector(connectorID, "user", config)
+	return c.ActivateConnectorForUserCtx(context.Background(), connectorID, config)
+}
+
+func (c *MistralClient) ActivateConnectorForUserCtx(ctx context.Context, connectorID string, config *ToolExecutionConfiguration) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.activate_for_user")
+	return c.activateConnector(ctx, connectorID, "user", config)
 }
 
 func (c *MistralClient) DeactivateConnectorForUser(connectorID string) (APIResponse, error) {
-	return c.deactivateConnector(connectorID, "user")
+	return c.DeactivateConnectorForUserCtx(context.Background(), connectorID)
+}
+
+func (c *MistralClient) DeactivateConnectorForUserCtx(ctx context.Context, connectorID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.deactivate_for_user")
+	return c.deactivateConnector(ctx, connectorID, "user")
 }
 
 func (c *MistralClient) ListOrganizationConnectorCredentials(connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
-	return c.listConnectorCredentials(connectorIDOrName, "organization", params)
+	return c.ListOrganizationConnectorCredentialsCtx(context.Background(), connectorIDOrName, params)
+}
+
+func (c *MistralClient) ListOrganizationConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.list_organization_credentials")
+	return c.listConnectorCredentials(ctx, connectorIDOrName, "organization", params)
 }
 
 func (c *MistralClient) CreateOrUpdateOrganizationConnectorCredentials(connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
-	return c.createOrUpdateConnectorCredentials(connectorIDOrName, "organization", req)
+	return c.CreateOrUpdateOrganizationConnectorCredentialsCtx(context.Background(), connectorIDOrName, req)
+}
+
+func (c *MistralClient) CreateOrUpdateOrganizationConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.create_or_update_organization_credentials")
+	return c.createOrUpdateConnectorCredentials(ctx, connectorIDOrName, "organization", req)
 }
 
 func (c *MistralClient) ListWorkspaceConnectorCredentials(connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
-	return c.listConnectorCredentials(connectorIDOrName, "workspace", params)
+	return c.ListWorkspaceConnectorCredentialsCtx(context.Background(), connectorIDOrName, params)
+}
+
+func (c *MistralClient) ListWorkspaceConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.list_workspace_credentials")
+	return c.listConnectorCredentials(ctx, connectorIDOrName, "workspace", params)
 }
 
 func (c *MistralClient) CreateOrUpdateWorkspaceConnectorCredentials(connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
-	return c.createOrUpdateConnectorCredentials(connectorIDOrName, "workspace", req)
+	return c.CreateOrUpdateWorkspaceConnectorCredentialsCtx(context.Background(), connectorIDOrName, req)
+}
+
+func (c *MistralClient) CreateOrUpdateWorkspaceConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.create_or_update_workspace_credentials")
+	return c.createOrUpdateConnectorCredentials(ctx, connectorIDOrName, "workspace", req)
 }
 
 func (c *MistralClient) ListUserConnectorCredentials(connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
-	return c.listConnectorCredentials(connectorIDOrName, "user", params)
+	return c.ListUserConnectorCredentialsCtx(context.Background(), connectorIDOrName, params)
+}
+
+func (c *MistralClient) ListUserConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, params *ListConnectorCredentialsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.list_user_credentials")
+	return c.listConnectorCredentials(ctx, connectorIDOrName, "user", params)
 }
 
 func (c *MistralClient) CreateOrUpdateUserConnectorCredentials(connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
-	return c.createOrUpdateConnectorCredentials(connectorIDOrName, "user", req)
+	return c.CreateOrUpdateUserConnectorCredentialsCtx(context.Background(), connectorIDOrName, req)
+}
+
+func (c *MistralClient) CreateOrUpdateUserConnectorCredentialsCtx(ctx context.Context, connectorIDOrName string, req *ConnectorCredentialsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.create_or_update_user_credentials")
+	return c.createOrUpdateConnectorCredentials(ctx, connectorIDOrName, "user", req)
 }
 
 func (c *MistralClient) DeleteOrganizationConnectorCredentials(connectorIDOrName, credentialsName string) (APIResponse, error) {
-	return c.deleteConnectorCredentials(connectorIDOrName, "organization", credentialsName)
+	return c.DeleteOrganizationConnectorCredentialsCtx(context.Background(), connectorIDOrName, credentialsName)
+}
+
+func (c *MistralClient) DeleteOrganizationConnectorCredentialsCtx(ctx context.Context, connectorIDOrName, credentialsName string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.delete_organization_credentials")
+	return c.deleteConnectorCredentials(ctx, connectorIDOrName, "organization", credentialsName)
 }
 
 func (c *MistralClient) DeleteWorkspaceConnectorCredentials(connectorIDOrName, credentialsName string) (APIResponse, error) {
-	return c.deleteConnectorCredentials(connectorIDOrName, "workspace", credentialsName)
+	return c.DeleteWorkspaceConnectorCredentialsCtx(context.Background(), connectorIDOrName, credentialsName)
+}
+
+func (c *MistralClient) DeleteWorkspaceConnectorCredentialsCtx(ctx context.Context, connectorIDOrName, credentialsName string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.delete_workspace_credentials")
+	return c.deleteConnectorCredentials(ctx, connectorIDOrName, "workspace", credentialsName)
 }
 
 func (c *MistralClient) DeleteUserConnectorCredentials(connectorIDOrName, credentialsName string) (APIResponse, error) {
-	return c.deleteConnectorCredentials(connectorIDOrName, "user", credentialsName)
+	return c.DeleteUserConnectorCredentialsCtx(context.Background(), connectorIDOrName, credentialsName)
+}
+
+func (c *MistralClient) DeleteUserConnectorCredentialsCtx(ctx context.Context, connectorIDOrName, credentialsName string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.delete_user_credentials")
+	return c.deleteConnectorCredentials(ctx, connectorIDOrName, "user", credentialsName)
 }
 
 func (c *MistralClient) GetConnector(connectorIDOrName string, fetchCustomerData *bool, fetchConnectionSecrets *bool) (APIResponse, error) {
+	return c.GetConnectorCtx(context.Background(), connectorIDOrName, fetchCustomerData, fetchConnectionSecrets)
+}
+
+func (c *MistralClient) GetConnectorCtx(ctx context.Context, connectorIDOrName string, fetchCustomerData *bool, fetchConnectionSecrets *bool) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.get")
 	query := queryWithOptionalValues(map[string]any{"fetch_customer_data": fetchCustomerData, "fetch_connection_secrets": fetchConnectionSecrets})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s", connectorIDOrName), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s", connectorIDOrName), query))
 }
 
 func (c *MistralClient) UpdateConnector(connectorID string, req *UpdateConnectorRequest) (APIResponse, error) {
+	return c.UpdateConnectorCtx(context.Background(), connectorID, req)
+}
+
+func (c *MistralClient) UpdateConnectorCtx(ctx context.Context, connectorID string, req *UpdateConnectorRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -218,14 +334,19 @@ func (c *MistralClient) UpdateConnector(connectorID string, req *UpdateConnector
 		"headers":            req.Headers,
 		"auth_data":          req.AuthData,
 	})
-	return c.requestMap(http.MethodPatch, body, fmt.Sprintf("v1/connectors/%s", connectorID))
+	return c.requestMap(ctx, http.MethodPatch, body, fmt.Sprintf("v1/connectors/%s", connectorID))
 }
 
 func (c *MistralClient) DeleteConnector(connectorID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/connectors/%s", connectorID))
+	return c.DeleteConnectorCtx(context.Background(), connectorID)
+}
+
+func (c *MistralClient) DeleteConnectorCtx(ctx context.Context, connectorID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "connectors.delete")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/connectors/%s", connectorID))
 }
 
-func (c *MistralClient) activateConnector(connectorID, scope string, config *ToolExecutionConfiguration) (APIResponse, error) {
+func (c *MistralClient) activateConnector(ctx context.Context, connectorID, scope string, config *ToolExecutionConfiguration) (APIResponse, error) {
 	var body map[string]interface{}
 	if config != nil {
 		body = optionalRequestMap(map[string]any{
@@ -235,29 +356,29 @@ func (c *MistralClient) activateConnector(connectorID, scope string, config *Too
 			"exclude":               config.Exclude,
 		})
 	}
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/connectors/%s/%s/activate", connectorID, scope))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/connectors/%s/%s/activate", connectorID, scope))
 }
 
-func (c *MistralClient) deactivateConnector(connectorID, scope string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, nil, fmt.Sprintf("v1/connectors/%s/%s/deactivate", connectorID, scope))
+func (c *MistralClient) deactivateConnector(ctx context.Context, connectorID, scope string) (APIResponse, error) {
+	return c.requestMap(ctx, http.MethodPost, nil, fmt.Sprintf("v1/connectors/%s/%s/deactivate", connectorID, scope))
 }
 
-func (c *MistralClient) listConnectorCredentials(connectorIDOrName, scope string, params *ListConnectorCredentialsParams) (APIResponse, error) {
+func (c *MistralClient) listConnectorCredentials(ctx context.Context, connectorIDOrName, scope string, params *ListConnectorCredentialsParams) (APIResponse, error) {
 	if params == nil {
 		params = &ListConnectorCredentialsParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"auth_type": params.AuthType, "fetch_default": params.FetchDefault})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/%s/credentials", connectorIDOrName, scope), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/connectors/%s/%s/credentials", connectorIDOrName, scope), query))
 }
 
-func (c *MistralClient) createOrUpdateConnectorCredentials(connectorIDOrName, scope string, req *ConnectorCredentialsRequest) (APIResponse, error) {
+func (c *MistralClient) createOrUpdateConnectorCredentials(ctx context.Context, connectorIDOrName, scope string, req *ConnectorCredentialsRequest) (APIResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "is_default": req.IsDefault, "credentials": req.Credentials})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/connectors/%s/%s/credentials", connectorIDOrName, scope))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/connectors/%s/%s/credentials", connectorIDOrName, scope))
 }
 
-func (c *MistralClient) deleteConnectorCredentials(connectorIDOrName, scope, credentialsName string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/connectors/%s/%s/credentials/%s", connectorIDOrName, scope, credentialsName))
+func (c *MistralClient) deleteConnectorCredentials(ctx context.Context, connectorIDOrName, scope, credentialsName string) (APIResponse, error) {
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/connectors/%s/%s/credentials/%s", connectorIDOrName, scope, credentialsName))
 }
@@ -0,0 +1,252 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContentPartType is the type of a message content chunk.
+type ContentPartType string
+
+const (
+	ContentPartText        ContentPartType = "text"
+	ContentPartImageURL    ContentPartType = "image_url"
+	ContentPartDocumentURL ContentPartType = "document_url"
+	ContentPartInputAudio  ContentPartType = "input_audio"
+	ContentPartFile        ContentPartType = "file"
+	ContentPartThinking    ContentPartType = "thinking"
+	ContentPartReference   ContentPartType = "reference"
+)
+
+// ContentPart is one chunk of a multimodal message. Type tells which of the other fields are set.
+type ContentPart struct {
+	Type         ContentPartType `json:"type"`
+	Text         string          `json:"text,omitempty"`          // text
+	ImageURL     *ImageURL       `json:"image_url,omitempty"`     // image_url
+	DocumentURL  string          `json:"document_url,omitempty"`  // document_url
+	DocumentName string          `json:"document_name,omitempty"` // document_url, optional
+	InputAudio   string          `json:"input_audio,omitempty"`   // input_audio: base64-encoded audio or an audio URL
+	FileID       string          `json:"file_id,omitempty"`       // file
+	Thinking     []ContentPart   `json:"thinking,omitempty"`      // thinking
+	Closed       *bool           `json:"closed,omitempty"`        // thinking
+	ReferenceIDs []int           `json:"reference_ids,omitempty"` // reference
+}
+
+// ImageURL is the image of an image_url content chunk: an https URL or a base64 data URI.
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// UnmarshalJSON accepts the image URL as a plain string as well as an object.
+func (u *ImageURL) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &u.URL)
+	}
+	type plain ImageURL
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+// TextPart returns a text content chunk.
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: ContentPartText, Text: text}
+}
+
+// ImageURLPart returns an image content chunk for an https URL or a data URI.
+func ImageURLPart(url string) ContentPart {
+	return ContentPart{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: url}}
+}
+
+// ImageFromBytes returns an image content chunk embedding data as a base64 data URI. The MIME type
+// is detected from the content.
+func ImageFromBytes(data []byte) (ContentPart, error) {
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return ContentPart{}, fmt.Errorf("mistral: unsupported image type %s", mimeType)
+	}
+	return ImageURLPart(dataURI(mimeType, data)), nil
+}
+
+// ImageFromFile returns an image content chunk embedding the file at path as a base64 data URI. The
+// MIME type is detected from the content, or from the file extension when the content is not
+// recognized.
+func ImageFromFile(path string) (ContentPart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ContentPart{}, err
+	}
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType, _, _ = strings.Cut(mime.TypeByExtension(filepath.Ext(path)), ";")
+	}
+	if !strings.HasPrefix(mimeType, "image/") {
+		return ContentPart{}, fmt.Errorf("mistral: %s is not a supported image", path)
+	}
+	return ImageURLPart(dataURI(mimeType, data)), nil
+}
+
+func dataURI(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// DocumentURLPart returns a document content chunk for a PDF or other document URL.
+func DocumentURLPart(url string) ContentPart {
+	return ContentPart{Type: ContentPartDocumentURL, DocumentURL: url}
+}
+
+// InputAudioPart returns an audio content chunk for audio models such as Voxtral. audio is the
+// base64-encoded audio or an audio URL.
+func InputAudioPart(audio string) ContentPart {
+	return ContentPart{Type: ContentPartInputAudio, InputAudio: audio}
+}
+
+// AudioFromFile returns an audio content chunk embedding the file at path, base64-encoded.
+func AudioFromFile(path string) (ContentPart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ContentPart{}, err
+	}
+	return InputAudioPart(base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// FilePart returns a content chunk referencing a file uploaded with UploadFile.
+func FilePart(fileID string) ContentPart {
+	return ContentPart{Type: ContentPartFile, FileID: fileID}
+}
+
+// UserMessageParts creates a user message made of content chunks.
+func UserMessageParts(parts ...ContentPart) ChatMessage {
+	return ChatMessage{Role: RoleUser, ContentParts: parts}
+}
+
+// partsText joins the text chunks of parts, leaving out thinking and non-text chunks.
+func partsText(parts []ContentPart) string {
+	var text strings.Builder
+	for _, part := range parts {
+		if part.Type == ContentPartText {
+			text.WriteString(part.Text)
+		}
+	}
+	return text.String()
+}
+
+// partsThinking joins the text of the thinking chunks of parts.
+func partsThinking(parts []ContentPart) string {
+	var text strings.Builder
+	for _, part := range parts {
+		if part.Type == ContentPartThinking {
+			text.WriteString(partsText(part.Thinking))
+		}
+	}
+	return text.String()
+}
+
+// decodeContent decodes message content sent either as a string or as an array of chunks. For
+// chunks, the returned text is their joined text.
+func decodeContent(data json.RawMessage) (string, []ContentPart, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return "", nil, nil
+	}
+	if data[0] == '"' {
+		var text string
+		err := json.Unmarshal(data, &text)
+		return text, nil, err
+	}
+	var parts []ContentPart
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return "", nil, err
+	}
+	return partsText(parts), parts, nil
+}
+
+// Text returns the text of the message: Content, or the joined text chunks when the message is
+// made of ContentParts.
+func (m ChatMessage) Text() string {
+	if m.ContentParts != nil {
+		return partsText(m.ContentParts)
+	}
+	return m.Content
+}
+
+// Thinking returns the joined text of the thinking chunks of a reasoning model's reply.
+func (m ChatMessage) Thinking() string {
+	return partsThinking(m.ContentParts)
+}
+
+// MarshalJSON sends ContentParts as a content array when set, and Content as a string otherwise.
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	type plain ChatMessage
+	if m.ContentParts == nil {
+		return json.Marshal(plain(m))
+	}
+	return json.Marshal(struct {
+		plain
+		Content []ContentPart `json:"content"`
+	}{plain(m), m.ContentParts})
+}
+
+// UnmarshalJSON accepts content as a string or as an array of chunks. Chunks are kept in
+// ContentParts and their text is copied to Content.
+func (m *ChatMessage) UnmarshalJSON(data []byte) error {
+	type plain ChatMessage
+	var raw struct {
+		plain
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = ChatMessage(raw.plain)
+	var err error
+	m.Content, m.ContentParts, err = decodeContent(raw.Content)
+	return err
+}
+
+// Text returns the text of the delta, like ChatMessage.Text.
+func (d DeltaMessage) Text() string {
+	if d.ContentParts != nil {
+		return partsText(d.ContentParts)
+	}
+	return d.Content
+}
+
+// Thinking returns the joined text of the thinking chunks of the delta.
+func (d DeltaMessage) Thinking() string {
+	return partsThinking(d.ContentParts)
+}
+
+// MarshalJSON sends ContentParts as a content array when set, and Content as a string otherwise.
+func (d DeltaMessage) MarshalJSON() ([]byte, error) {
+	type plain DeltaMessage
+	if d.ContentParts == nil {
+		return json.Marshal(plain(d))
+	}
+	return json.Marshal(struct {
+		plain
+		Content []ContentPart `json:"content"`
+	}{plain(d), d.ContentParts})
+}
+
+// UnmarshalJSON accepts content as a string or as an array of chunks, like ChatMessage.UnmarshalJSON.
+func (d *DeltaMessage) UnmarshalJSON(data []byte) error {
+	type plain DeltaMessage
+	var raw struct {
+		plain
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = DeltaMessage(raw.plain)
+	var err error
+	d.Content, d.ContentParts, err = decodeContent(raw.Content)
+	return err
+}
@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChatMessageContentJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		message  ChatMessage
+		expected string
+	}{
+		{"string content", UserMessage("hi"), `{"role":"user","content":"hi"}`},
+		{"no content", ChatMessage{Role: RoleAssistant, ToolCalls: []ToolCall{{Id: "call-1", Type: ToolTypeFunction, Function: FunctionCall{Name: "f", Arguments: "{}"}}}}, `{"role":"assistant","tool_calls":[{"id":"call-1","type":"function","function":{"name":"f","arguments":"{}"}}]}`},
+		{"content parts", UserMessageParts(TextPart("What is this?"), ImageURLPart("https://example.com/cat.png")), `{"role":"user","content":[{"type":"text","text":"What is this?"},{"type":"image_url","image_url":{"url":"https://example.com/cat.png"}}]}`},
+		{"document and audio", UserMessageParts(DocumentURLPart("https://example.com/a.pdf"), InputAudioPart("UklGRg=="), FilePart("file-1")), `{"role":"user","content":[{"type":"document_url","document_url":"https://example.com/a.pdf"},{"type":"input_audio","input_audio":"UklGRg=="},{"type":"file","file_id":"file-1"}]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.message)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, data)
+			}
+			var decoded ChatMessage
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decoded.Text() != tc.message.Text() || !reflect.DeepEqual(decoded.ContentParts, tc.message.ContentParts) {
+				t.Errorf("expected %+v to round-trip, got %+v", tc.message, decoded)
+			}
+		})
+	}
+}
+
+func TestChatResponseWithContentChunks(t *testing.T) {
+	var requestBody map[string]any
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&requestBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"1","object":"chat.completion","model":"magistral-medium-latest","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":[
+			{"type":"thinking","thinking":[{"type":"text","text":"A cat, "},{"type":"text","text":"clearly."}]},
+			{"type":"text","text":"It is "},{"type":"text","text":"a cat."}]}}]}`)
+	})
+	defer mock.Close()
+
+	resp, err := mock.GetClient().Chat("magistral-medium-latest", []ChatMessage{UserMessageParts(TextPart("What is this?"), ImageURLPart("data:image/png;base64,AAAA"))}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	message := resp.Choices[0].Message
+	if message.Content != "It is a cat." || message.Text() != "It is a cat." || message.Thinking() != "A cat, clearly." || len(message.ContentParts) != 3 {
+		t.Errorf("expected the chunks to be flattened, got %+v", message)
+	}
+	content, _ := requestBody["messages"].([]any)[0].(map[string]any)["content"].([]any)
+	if len(content) != 2 || content[1].(map[string]any)["type"] != "image_url" {
+		t.Errorf("expected the content parts to be sent, got %v", requestBody["messages"])
+	}
+}
+
+func TestDeltaMessageContentChunks(t *testing.T) {
+	var delta DeltaMessage
+	if err := json.Unmarshal([]byte(`{"content":[{"type":"thinking","thinking":[{"type":"text","text":"hmm"}]}]}`), &delta); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delta.Content != "" || delta.Thinking() != "hmm" {
+		t.Errorf("expected a thinking delta, got %+v", delta)
+	}
+	if err := json.Unmarshal([]byte(`{"content":"plain"}`), &delta); err != nil || delta.Text() != "plain" || delta.ContentParts != nil {
+		t.Errorf("expected a string delta, got %+v, %v", delta, err)
+	}
+}
+
+func TestImageURLAcceptsString(t *testing.T) {
+	var part ContentPart
+	if err := json.Unmarshal([]byte(`{"type":"image_url","image_url":"https://example.com/cat.png"}`), &part); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if part.ImageURL == nil || part.ImageURL.URL != "https://example.com/cat.png" {
+		t.Errorf("unexpected image URL %+v", part.ImageURL)
+	}
+}
+
+func TestImageFromFile(t *testing.T) {
+	dir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pngPath := filepath.Join(dir, "cat.bin")
+	svgPath := filepath.Join(dir, "logo.svg")
+	textPath := filepath.Join(dir, "notes.txt")
+	for path, data := range map[string][]byte{pngPath: png, svgPath: []byte("<svg/>"), textPath: []byte("hello")} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	part, err := ImageFromFile(pngPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if part.ImageURL.URL != "data:image/png;base64,"+base64.StdEncoding.EncodeToString(png) {
+		t.Errorf("expected a PNG data URI detected from the content, got %s", part.ImageURL.URL)
+	}
+	if part, err := ImageFromFile(svgPath); err != nil || !strings.HasPrefix(part.ImageURL.URL, "data:image/svg+xml;base64,") {
+		t.Errorf("expected the extension to be used for SVG, got %+v, %v", part, err)
+	}
+	if _, err := ImageFromFile(textPath); err == nil {
+		t.Error("expected an error for a text file")
+	}
+	if _, err := ImageFromBytes([]byte("hello")); err == nil {
+		t.Error("expected an error for text bytes")
+	}
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChatCtxDeadline(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ReadRequestBody(r)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer mock.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := mock.GetClient().ChatCtx(ctx, "mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("request was not cancelled promptly: %v", elapsed)
+	}
+	mock.Close() // Waits for the handler, so that Requests is complete
+	if len(mock.Requests) != 1 {
+		t.Fatalf("expected no retries after cancellation, got %d requests", len(mock.Requests))
+	}
+}
+
+func TestRetrySleepHonoursCancellation(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+	})
+	defer mock.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := mock.GetClient().ListModelsCtx(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestChatStreamCtxCancelStopsGoroutine(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ReadRequestBody(r)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer mock.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := mock.GetClient().ChatStreamChanCtx(ctx, "mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := <-stream
+	if first.Error != nil || len(first.Choices) != 1 {
+		t.Fatalf("unexpected first chunk: %+v", first)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range stream {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream channel was not closed after cancellation")
+	}
+}
+
+func TestWaitForWorkflowCompletionCtxCancel(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockJSONResponse(http.StatusOK, `{"status":"RUNNING"}`).Write(w)
+	})
+	defer mock.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := mock.GetClient().WaitForWorkflowCompletionCtx(ctx, "exec", time.Hour, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
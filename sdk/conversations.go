@@ -1,9 +1,7 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -112,6 +110,12 @@ type ConversationStreamEvent struct {
 
 // StartConversation starts a new conversation
 func (c *MistralClient) StartConversation(req *ConversationStartRequest) (*ConversationResponse, error) {
+	return c.StartConversationCtx(context.Background(), req)
+}
+
+// StartConversationCtx is like StartConversation but uses ctx for cancellation and deadlines.
+func (c *MistralClient) StartConversationCtx(ctx context.Context, req *ConversationStartRequest) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.start")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -157,19 +161,8 @@ func (c *MistralClient) StartConversation(req *ConversationStartRequest) (*Conve
 		reqMap["model"] = *req.Model
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/conversations", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var convResponse ConversationResponse
-	err = mapToStruct(respData, &convResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/conversations", &convResponse); err != nil {
 		return nil, err
 	}
 
@@ -177,7 +170,13 @@ func (c *MistralClient) StartConversation(req *ConversationStartRequest) (*Conve
 }
 
 // StartConversationStream starts a conversation and returns SSE events.
-func (c *MistralClient) StartConversationStream(req *ConversationStartRequest) (<-chan ConversationStreamEvent, error) {
+func (c *MistralClient) StartConversationStream(req *ConversationStartRequest) (*Stream[ConversationStreamEvent], error) {
+	return c.StartConversationStreamCtx(context.Background(), req)
+}
+
+// StartConversationStreamCtx is like StartConversationStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) StartConversationStreamCtx(ctx context.Context, req *ConversationStartRequest) (*Stream[ConversationStreamEvent], error) {
+	ctx = withOperation(ctx, "conversations.start.stream")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -219,11 +218,31 @@ func (c *MistralClient) StartConversationStream(req *ConversationStartRequest) (
 		reqMap["model"] = *req.Model
 	}
 
-	return c.conversationStream("v1/conversations", reqMap)
+	return c.conversationStream(ctx, "v1/conversations", reqMap)
+}
+
+// StartConversationStreamChan is like StartConversationStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) StartConversationStreamChan(req *ConversationStartRequest) (<-chan ConversationStreamEvent, error) {
+	return c.StartConversationStreamChanCtx(context.Background(), req)
+}
+
+// StartConversationStreamChanCtx is like StartConversationStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) StartConversationStreamChanCtx(ctx context.Context, req *ConversationStartRequest) (<-chan ConversationStreamEvent, error) {
+	stream, err := c.StartConversationStreamCtx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) ConversationStreamEvent { return ConversationStreamEvent{Error: err} }), nil
 }
 
 // AppendToConversationStream appends to a conversation and returns SSE events.
-func (c *MistralClient) AppendToConversationStream(conversationID string, req *ConversationAppendRequest) (<-chan ConversationStreamEvent, error) {
+func (c *MistralClient) AppendToConversationStream(conversationID string, req *ConversationAppendRequest) (*Stream[ConversationStreamEvent], error) {
+	return c.AppendToConversationStreamCtx(context.Background(), conversationID, req)
+}
+
+// AppendToConversationStreamCtx is like AppendToConversationStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AppendToConversationStreamCtx(ctx context.Context, conversationID string, req *ConversationAppendRequest) (*Stream[ConversationStreamEvent], error) {
+	ctx = withOperation(ctx, "conversations.append.stream")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -238,11 +257,31 @@ func (c *MistralClient) AppendToConversationStream(conversationID string, req *C
 		reqMap["completion_args"] = req.CompletionArgs
 	}
 
-	return c.conversationStream(fmt.Sprintf("v1/conversations/%s", conversationID), reqMap)
+	return c.conversationStream(ctx, fmt.Sprintf("v1/conversations/%s", conversationID), reqMap)
+}
+
+// AppendToConversationStreamChan is like AppendToConversationStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) AppendToConversationStreamChan(conversationID string, req *ConversationAppendRequest) (<-chan ConversationStreamEvent, error) {
+	return c.AppendToConversationStreamChanCtx(context.Background(), conversationID, req)
+}
+
+// AppendToConversationStreamChanCtx is like AppendToConversationStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AppendToConversationStreamChanCtx(ctx context.Context, conversationID string, req *ConversationAppendRequest) (<-chan ConversationStreamEvent, error) {
+	stream, err := c.AppendToConversationStreamCtx(ctx, conversationID, req)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) ConversationStreamEvent { return ConversationStreamEvent{Error: err} }), nil
 }
 
 // RestartConversationStream restarts a conversation and returns SSE events.
-func (c *MistralClient) RestartConversationStream(conversationID string, req *ConversationRestartRequest) (<-chan ConversationStreamEvent, error) {
+func (c *MistralClient) RestartConversationStream(conversationID string, req *ConversationRestartRequest) (*Stream[ConversationStreamEvent], error) {
+	return c.RestartConversationStreamCtx(context.Background(), conversationID, req)
+}
+
+// RestartConversationStreamCtx is like RestartConversationStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RestartConversationStreamCtx(ctx context.Context, conversationID string, req *ConversationRestartRequest) (*Stream[ConversationStreamEvent], error) {
+	ctx = withOperation(ctx, "conversations.restart.stream")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -266,11 +305,25 @@ func (c *MistralClient) RestartConversationStream(conversationID string, req *Co
 		reqMap["agent_version"] = req.AgentVersion
 	}
 
-	return c.conversationStream(fmt.Sprintf("v1/conversations/%s/restart", conversationID), reqMap)
+	return c.conversationStream(ctx, fmt.Sprintf("v1/conversations/%s/restart", conversationID), reqMap)
+}
+
+// RestartConversationStreamChan is like RestartConversationStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) RestartConversationStreamChan(conversationID string, req *ConversationRestartRequest) (<-chan ConversationStreamEvent, error) {
+	return c.RestartConversationStreamChanCtx(context.Background(), conversationID, req)
+}
+
+// RestartConversationStreamChanCtx is like RestartConversationStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RestartConversationStreamChanCtx(ctx context.Context, conversationID string, req *ConversationRestartRequest) (<-chan ConversationStreamEvent, error) {
+	stream, err := c.RestartConversationStreamCtx(ctx, conversationID, req)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) ConversationStreamEvent { return ConversationStreamEvent{Error: err} }), nil
 }
 
-func (c *MistralClient) conversationStream(path string, reqMap map[string]interface{}) (<-chan ConversationStreamEvent, error) {
-	response, err := c.request(http.MethodPost, reqMap, path, true, nil)
+func (c *MistralClient) conversationStream(ctx context.Context, path string, reqMap map[string]interface{}) (*Stream[ConversationStreamEvent], error) {
+	response, err := c.request(ctx, http.MethodPost, reqMap, path, true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -280,58 +333,33 @@ func (c *MistralClient) conversationStream(path string, reqMap map[string]interf
 		return nil, fmt.Errorf("invalid response type: %T", response)
 	}
 
-	out := make(chan ConversationStreamEvent)
-	go func() {
-		defer close(out)
-		defer body.Close()
-
-		reader := bufio.NewReader(body)
-		for {
-			line, readErr := reader.ReadBytes('\n')
-			if readErr == io.EOF {
-				break
-			}
-			if readErr != nil {
-				out <- ConversationStreamEvent{Error: fmt.Errorf("error reading stream response: %w", readErr)}
-				return
-			}
-
-			if bytes.Equal(line, []byte("\n")) {
-				continue
-			}
-			if !bytes.HasPrefix(line, []byte("data: ")) {
-				continue
-			}
-
-			jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-			if bytes.Equal(jsonLine, []byte("[DONE]")) {
-				break
-			}
-
-			var payload map[string]interface{}
-			if err := json.Unmarshal(jsonLine, &payload); err != nil {
-				out <- ConversationStreamEvent{Error: fmt.Errorf("error decoding stream event: %w", err)}
-				continue
-			}
-
-			event := ConversationStreamEvent{Data: payload}
-			if t, ok := payload["type"].(string); ok {
-				event.Type = t
-			}
-			out <- event
-		}
-	}()
-
-	return out, nil
+	return newStream(ctx, body, c.streamIdleTimeout, decodeConversationEvent, nil), nil
+}
+
+func decodeConversationEvent(event *SSEEvent) (ConversationStreamEvent, error) {
+	eventType, payload, err := decodeEventPayload(event)
+	return ConversationStreamEvent{Type: eventType, Data: payload}, err
 }
 
 // ListConversations lists all conversations
 func (c *MistralClient) ListConversations(page int) (*ConversationListResponse, error) {
-	return c.ListConversationsWithParams(&ListConversationsParams{Page: &page})
+	return c.ListConversationsCtx(context.Background(), page)
+}
+
+// ListConversationsCtx is like ListConversations but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListConversationsCtx(ctx context.Context, page int) (*ConversationListResponse, error) {
+	ctx = withOperation(ctx, "conversations.list")
+	return c.ListConversationsWithParamsCtx(ctx, &ListConversationsParams{Page: &page})
 }
 
 // ListConversationsWithParams lists all conversations with filters.
 func (c *MistralClient) ListConversationsWithParams(params *ListConversationsParams) (*ConversationListResponse, error) {
+	return c.ListConversationsWithParamsCtx(context.Background(), params)
+}
+
+// ListConversationsWithParamsCtx is like ListConversationsWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListConversationsWithParamsCtx(ctx context.Context, params *ListConversationsParams) (*ConversationListResponse, error) {
+	ctx = withOperation(ctx, "conversations.list")
 	if params == nil {
 		params = &ListConversationsParams{}
 	}
@@ -352,19 +380,8 @@ func (c *MistralClient) ListConversationsWithParams(params *ListConversationsPar
 		path += "?" + query.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var listResponse ConversationListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -373,19 +390,14 @@ func (c *MistralClient) ListConversationsWithParams(params *ListConversationsPar
 
 // GetConversation retrieves a specific conversation
 func (c *MistralClient) GetConversation(conversationID string) (*ConversationResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s", conversationID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetConversationCtx(context.Background(), conversationID)
+}
 
+// GetConversationCtx is like GetConversation but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetConversationCtx(ctx context.Context, conversationID string) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.get")
 	var convResponse ConversationResponse
-	err = mapToStruct(respData, &convResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s", conversationID), &convResponse); err != nil {
 		return nil, err
 	}
 
@@ -394,11 +406,23 @@ func (c *MistralClient) GetConversation(conversationID string) (*ConversationRes
 
 // AppendToConversation appends inputs to an existing conversation
 func (c *MistralClient) AppendToConversation(conversationID string, inputs []ConversationInput) (*ConversationResponse, error) {
-	return c.AppendToConversationWithParams(conversationID, &ConversationAppendRequest{Inputs: inputs})
+	return c.AppendToConversationCtx(context.Background(), conversationID, inputs)
+}
+
+// AppendToConversationCtx is like AppendToConversation but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AppendToConversationCtx(ctx context.Context, conversationID string, inputs []ConversationInput) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.append")
+	return c.AppendToConversationWithParamsCtx(ctx, conversationID, &ConversationAppendRequest{Inputs: inputs})
 }
 
 // AppendToConversationWithParams appends inputs with optional params.
 func (c *MistralClient) AppendToConversationWithParams(conversationID string, req *ConversationAppendRequest) (*ConversationResponse, error) {
+	return c.AppendToConversationWithParamsCtx(context.Background(), conversationID, req)
+}
+
+// AppendToConversationWithParamsCtx is like AppendToConversationWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) AppendToConversationWithParamsCtx(ctx context.Context, conversationID string, req *ConversationAppendRequest) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.append")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -419,19 +443,8 @@ func (c *MistralClient) AppendToConversationWithParams(conversationID string, re
 		reqMap["completion_args"] = req.CompletionArgs
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, fmt.Sprintf("v1/conversations/%s", conversationID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var convResponse ConversationResponse
-	err = mapToStruct(respData, &convResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, fmt.Sprintf("v1/conversations/%s", conversationID), &convResponse); err != nil {
 		return nil, err
 	}
 
@@ -440,19 +453,14 @@ func (c *MistralClient) AppendToConversationWithParams(conversationID string, re
 
 // GetConversationHistory retrieves the history of a conversation
 func (c *MistralClient) GetConversationHistory(conversationID string) (*ConversationHistoryResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s/history", conversationID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetConversationHistoryCtx(context.Background(), conversationID)
+}
 
+// GetConversationHistoryCtx is like GetConversationHistory but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetConversationHistoryCtx(ctx context.Context, conversationID string) (*ConversationHistoryResponse, error) {
+	ctx = withOperation(ctx, "conversations.get_history")
 	var historyResponse ConversationHistoryResponse
-	err = mapToStruct(respData, &historyResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s/history", conversationID), &historyResponse); err != nil {
 		return nil, err
 	}
 
@@ -461,19 +469,14 @@ func (c *MistralClient) GetConversationHistory(conversationID string) (*Conversa
 
 // GetConversationMessages retrieves only message entries from a conversation.
 func (c *MistralClient) GetConversationMessages(conversationID string) (*ConversationMessagesResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s/messages", conversationID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetConversationMessagesCtx(context.Background(), conversationID)
+}
 
+// GetConversationMessagesCtx is like GetConversationMessages but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetConversationMessagesCtx(ctx context.Context, conversationID string) (*ConversationMessagesResponse, error) {
+	ctx = withOperation(ctx, "conversations.get_messages")
 	var messagesResponse ConversationMessagesResponse
-	err = mapToStruct(respData, &messagesResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/conversations/%s/messages", conversationID), &messagesResponse); err != nil {
 		return nil, err
 	}
 
@@ -482,11 +485,23 @@ func (c *MistralClient) GetConversationMessages(conversationID string) (*Convers
 
 // RestartConversation restarts a conversation from a specific point
 func (c *MistralClient) RestartConversation(conversationID string, inputs []ConversationInput) (*ConversationResponse, error) {
-	return c.RestartConversationWithParams(conversationID, &ConversationRestartRequest{Inputs: inputs})
+	return c.RestartConversationCtx(context.Background(), conversationID, inputs)
+}
+
+// RestartConversationCtx is like RestartConversation but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RestartConversationCtx(ctx context.Context, conversationID string, inputs []ConversationInput) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.restart")
+	return c.RestartConversationWithParamsCtx(ctx, conversationID, &ConversationRestartRequest{Inputs: inputs})
 }
 
 // RestartConversationWithParams restarts a conversation with optional params.
 func (c *MistralClient) RestartConversationWithParams(conversationID string, req *ConversationRestartRequest) (*ConversationResponse, error) {
+	return c.RestartConversationWithParamsCtx(context.Background(), conversationID, req)
+}
+
+// RestartConversationWithParamsCtx is like RestartConversationWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RestartConversationWithParamsCtx(ctx context.Context, conversationID string, req *ConversationRestartRequest) (*ConversationResponse, error) {
+	ctx = withOperation(ctx, "conversations.restart")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -516,19 +531,8 @@ func (c *MistralClient) RestartConversationWithParams(conversationID string, req
 		reqMap["agent_version"] = req.AgentVersion
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, fmt.Sprintf("v1/conversations/%s/restart", conversationID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var convResponse ConversationResponse
-	err = mapToStruct(respData, &convResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, fmt.Sprintf("v1/conversations/%s/restart", conversationID), &convResponse); err != nil {
 		return nil, err
 	}
 
@@ -542,6 +546,11 @@ func (c *MistralClient) RestartConversationWithParams(conversationID string, req
 //
 // Returns an error if the deletion fails
 func (c *MistralClient) DeleteConversation(conversationID string) error {
-	_, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/conversations/%s", conversationID), false, nil)
-	return err
+	return c.DeleteConversationCtx(context.Background(), conversationID)
+}
+
+// DeleteConversationCtx is like DeleteConversation but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteConversationCtx(ctx context.Context, conversationID string) error {
+	ctx = withOperation(ctx, "conversations.delete")
+	return c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/conversations/%s", conversationID), nil)
 }
@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoCredentials is returned by credential providers that have no API key to offer.
+var ErrNoCredentials = errors.New("mistral: no API key available")
+
+// CredentialProvider supplies the API key sent with each request. Token is called before every HTTP
+// attempt, so a provider can rotate keys without restarting the client.
+type CredentialProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// CredentialRefresher is implemented by providers that cache keys. When the API answers 401, the client
+// calls Refresh and retries the request once if the provider then returns a different key.
+type CredentialRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// WithCredentials makes the client ask provider for the API key of every request. It takes precedence over WithAPIKey.
+func WithCredentials(provider CredentialProvider) Option {
+	return func(c *MistralClient) {
+		c.credentials = provider
+	}
+}
+
+// StaticCredentials always returns the same API key.
+type StaticCredentials string
+
+// Token returns the key.
+func (s StaticCredentials) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvCredentials reads the API key from the first non-empty environment variable in the list on every request.
+type EnvCredentials []string
+
+// Token returns the value of the first set variable, or ErrNoCredentials.
+func (e EnvCredentials) Token(ctx context.Context) (string, error) {
+	for _, name := range e {
+		if value := os.Getenv(name); value != "" {
+			return value, nil
+		}
+	}
+	return "", ErrNoCredentials
+}
+
+// FileCredentials reads the API key from a file and re-reads it whenever the file changes, for
+// example when Kubernetes updates a mounted secret. Surrounding whitespace is trimmed.
+type FileCredentials struct {
+	path    string
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials creates a FileCredentials for the key stored at path.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+// Token returns the key in the file, reading it again if its modification time or size changed.
+func (f *FileCredentials) Token(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", ErrNoCredentials
+	}
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// Refresh forgets the cached key so the next Token call reads the file again.
+func (f *FileCredentials) Refresh(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.token = ""
+	return nil
+}
+
+// CredentialFunc fetches an API key, for example from a secrets manager. A zero expiry means the
+// key is valid until the API rejects it.
+type CredentialFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// CachedCredentials caches the key returned by a CredentialFunc until it expires or is refreshed.
+// Concurrent callers share a single fetch.
+type CachedCredentials struct {
+	fetch CredentialFunc
+	// Skew fetches a new key this long before the cached one expires.
+	Skew time.Duration
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+// NewCachedCredentials wraps fetch with a cache. Keys are renewed 30 seconds before they expire.
+func NewCachedCredentials(fetch CredentialFunc) *CachedCredentials {
+	return &CachedCredentials{fetch: fetch, Skew: 30 * time.Second}
+}
+
+// Token returns the cached key, fetching a new one when there is none or it is about to expire.
+func (c *CachedCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	if c.token != "" && (c.expiry.IsZero() || now().Add(c.Skew).Before(c.expiry)) {
+		return c.token, nil
+	}
+	token, expiry, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", ErrNoCredentials
+	}
+	c.token, c.expiry = token, expiry
+	return token, nil
+}
+
+// Refresh drops the cached key so the next Token call fetches a new one.
+func (c *CachedCredentials) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+	return nil
+}
+
+// authorize sets the Authorization header of h from the client's credential provider and returns the key used.
+func (c *MistralClient) authorize(ctx context.Context, h http.Header) (string, error) {
+	provider := c.credentials
+	if provider == nil {
+		provider = StaticCredentials(c.apiKey)
+	}
+	token, err := provider.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get API key: %w", err)
+	}
+	h.Set("Authorization", "Bearer "+token)
+	return token, nil
+}
+
+// refreshCredentials is called after a 401. It reports whether the provider now offers a key other than used.
+func (c *MistralClient) refreshCredentials(ctx context.Context, used string) bool {
+	if c.credentials == nil {
+		return false
+	}
+	if refresher, ok := c.credentials.(CredentialRefresher); ok {
+		if err := refresher.Refresh(ctx); err != nil {
+			return false
+		}
+	}
+	token, err := c.credentials.Token(ctx)
+	return err == nil && token != used
+}
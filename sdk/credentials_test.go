@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCredentialsRefreshedOnUnauthorized(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key-2" {
+			MockErrorResponse(http.StatusUnauthorized, "key revoked").Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	fetches := 0
+	provider := NewCachedCredentials(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		if fetches == 1 {
+			return "key-1", time.Time{}, nil
+		}
+		return "key-2", time.Time{}, nil
+	})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1), WithCredentials(provider))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("expected the rotated key to be used, got %v", err)
+	}
+	if len(mock.Requests) != 2 || fetches != 2 {
+		t.Errorf("expected one retry after refreshing, got %d requests and %d fetches", len(mock.Requests), fetches)
+	}
+
+	// The refreshed key is cached for later calls.
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Requests) != 3 || fetches != 2 {
+		t.Errorf("expected the cached key to be reused, got %d requests and %d fetches", len(mock.Requests), fetches)
+	}
+}
+
+func TestUnauthorizedWithoutNewKeyIsNotRetried(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusUnauthorized, "bad key").Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(3), WithCredentials(StaticCredentials("static")))
+	_, err := client.ListModels()
+	if !IsAuthError(err) {
+		t.Fatalf("expected an auth error, got %v", err)
+	}
+	if len(mock.Requests) != 1 {
+		t.Errorf("expected a single request, got %d", len(mock.Requests))
+	}
+	if got := mock.Requests[0].Header.Get("Authorization"); got != "Bearer static" {
+		t.Errorf("unexpected Authorization header %q", got)
+	}
+}
+
+func TestCredentialProviderErrors(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:1"), WithCredentials(EnvCredentials{"MISTRAL_TEST_UNSET_KEY"}))
+	_, err := client.ListModels()
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("MISTRAL_TEST_PRIMARY_KEY", "")
+	t.Setenv("MISTRAL_TEST_FALLBACK_KEY", "fallback")
+	token, err := EnvCredentials{"MISTRAL_TEST_PRIMARY_KEY", "MISTRAL_TEST_FALLBACK_KEY"}.Token(context.Background())
+	if err != nil || token != "fallback" {
+		t.Errorf("expected the fallback key, got %q, %v", token, err)
+	}
+}
+
+func TestFileCredentialsReloadOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := NewFileCredentials(path)
+	ctx := context.Background()
+
+	if token, err := provider.Token(ctx); err != nil || token != "first" {
+		t.Fatalf("expected the trimmed key, got %q, %v", token, err)
+	}
+	if err := os.WriteFile(path, []byte("second-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := provider.Token(ctx); err != nil || token != "second-key" {
+		t.Errorf("expected the rotated key, got %q, %v", token, err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Token(ctx); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestCachedCredentialsExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fetches := 0
+	provider := NewCachedCredentials(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return "key", now.Add(time.Minute), nil
+	})
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = provider.Token(ctx)
+	_, _ = provider.Token(ctx)
+	if fetches != 1 {
+		t.Errorf("expected the key to be cached, got %d fetches", fetches)
+	}
+	now = now.Add(45 * time.Second) // Within Skew of the expiry.
+	_, _ = provider.Token(ctx)
+	if fetches != 2 {
+		t.Errorf("expected a fetch before expiry, got %d fetches", fetches)
+	}
+	_ = provider.Refresh(ctx)
+	_, _ = provider.Token(ctx)
+	if fetches != 3 {
+		t.Errorf("expected a fetch after Refresh, got %d fetches", fetches)
+	}
+}
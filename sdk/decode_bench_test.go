@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// benchmarkServer serves body for every request.
+func benchmarkServer(b *testing.B, body []byte) *MistralClient {
+	b.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	b.Cleanup(server.Close)
+	return NewMistralClient("test-api-key", server.URL, 0, DefaultTimeout)
+}
+
+// benchmarkDecode compares decoding body straight into a T with the previous path, which unmarshalled
+// into interface{} and converted the map with mapToStruct.
+func benchmarkDecode[T any](b *testing.B, body []byte) {
+	b.Run("direct", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			var out T
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(&out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			var raw interface{}
+			if err := json.Unmarshal(body, &raw); err != nil {
+				b.Fatal(err)
+			}
+			var out T
+			if err := mapToStruct(raw.(map[string]interface{}), &out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkChat(b *testing.B) {
+	body := []byte(MockChatResponse().Body)
+	benchmarkDecode[ChatCompletionResponse](b, body)
+
+	client := benchmarkServer(b, body)
+	messages := []ChatMessage{UserMessage("Hello")}
+	b.Run("client", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := client.Chat("mistral-small-latest", messages, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkEmbeddings(b *testing.B) {
+	resp := EmbeddingResponse{ID: "embd-1", Object: "list", Model: "mistral-embed"}
+	for i := 0; i < 1000; i++ {
+		vector := make([]float64, 1024)
+		for j := range vector {
+			vector[j] = float64((i*1024+j)%997)/997 - 0.5
+		}
+		resp.Data = append(resp.Data, EmbeddingObject{Object: "embedding", Embedding: vector, Index: i})
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkDecode[EmbeddingResponse](b, body)
+
+	client := benchmarkServer(b, body)
+	b.Run("client", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			if _, err := client.Embeddings("mistral-embed", []string{"text"}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkListFiles(b *testing.B) {
+	total := 100
+	list := ListFilesOut{Object: "list", Total: &total}
+	for i := 0; i < total; i++ {
+		list.Data = append(list.Data, FileSchema{
+			ID:        fmt.Sprintf("file-%d", i),
+			Object:    "file",
+			Bytes:     int64(1024 * i),
+			CreatedAt: 1700000000 + int64(i),
+			Filename:  fmt.Sprintf("train-%d.jsonl", i),
+			Purpose:   FilePurposeFineTune,
+		})
+	}
+	body, err := json.Marshal(list)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkDecode[ListFilesOut](b, body)
+
+	client := benchmarkServer(b, body)
+	b.Run("client", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := client.ListFiles(nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -92,11 +93,23 @@ type ListDocumentsParams struct {
 
 // ListDocuments lists all documents in a library
 func (c *MistralClient) ListDocuments(libraryID string, page int) (*DocumentListResponse, error) {
-	return c.ListDocumentsWithParams(libraryID, &ListDocumentsParams{Page: &page})
+	return c.ListDocumentsCtx(context.Background(), libraryID, page)
+}
+
+// ListDocumentsCtx is like ListDocuments but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListDocumentsCtx(ctx context.Context, libraryID string, page int) (*DocumentListResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.list")
+	return c.ListDocumentsWithParamsCtx(ctx, libraryID, &ListDocumentsParams{Page: &page})
 }
 
 // ListDocumentsWithParams lists documents in a library with filters.
 func (c *MistralClient) ListDocumentsWithParams(libraryID string, params *ListDocumentsParams) (*DocumentListResponse, error) {
+	return c.ListDocumentsWithParamsCtx(context.Background(), libraryID, params)
+}
+
+// ListDocumentsWithParamsCtx is like ListDocumentsWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListDocumentsWithParamsCtx(ctx context.Context, libraryID string, params *ListDocumentsParams) (*DocumentListResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.list")
 	if params == nil {
 		params = &ListDocumentsParams{}
 	}
@@ -126,19 +139,8 @@ func (c *MistralClient) ListDocumentsWithParams(libraryID string, params *ListDo
 		path += "?" + query.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var listResponse DocumentListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -147,6 +149,12 @@ func (c *MistralClient) ListDocumentsWithParams(libraryID string, params *ListDo
 
 // UploadDocument uploads a document to a library
 func (c *MistralClient) UploadDocument(libraryID string, file io.Reader, filename string) (*DocumentUploadResponse, error) {
+	return c.UploadDocumentCtx(context.Background(), libraryID, file, filename)
+}
+
+// UploadDocumentCtx is like UploadDocument but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UploadDocumentCtx(ctx context.Context, libraryID string, file io.Reader, filename string) (*DocumentUploadResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.upload")
 	// Create multipart form
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -166,19 +174,18 @@ func (c *MistralClient) UploadDocument(libraryID string, file io.Reader, filenam
 	}
 
 	// Create request
-	req, err := http.NewRequest(http.MethodPost, c.endpoint+fmt.Sprintf("/v1/libraries/%s/documents", libraryID), body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+fmt.Sprintf("/v1/libraries/%s/documents", libraryID), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	// Send request
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.doWithRetry(req, RequestKindUpload)
 	if err != nil {
-		return nil, NewMistralConnectionError(err.Error())
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -190,7 +197,7 @@ func (c *MistralClient) UploadDocument(libraryID string, file io.Reader, filenam
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
-		return nil, NewMistralAPIError(string(respBody), resp.StatusCode, resp.Header)
+		return nil, newAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
 	// Parse response
@@ -204,19 +211,14 @@ func (c *MistralClient) UploadDocument(libraryID string, file io.Reader, filenam
 
 // GetDocument retrieves a specific document
 func (c *MistralClient) GetDocument(libraryID, documentID string) (*Document, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetDocumentCtx(context.Background(), libraryID, documentID)
+}
 
+// GetDocumentCtx is like GetDocument but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetDocumentCtx(ctx context.Context, libraryID, documentID string) (*Document, error) {
+	ctx = withOperation(ctx, "libraries.documents.get")
 	var document Document
-	err = mapToStruct(respData, &document)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), &document); err != nil {
 		return nil, err
 	}
 
@@ -225,6 +227,12 @@ func (c *MistralClient) GetDocument(libraryID, documentID string) (*Document, er
 
 // UpdateDocument updates a document
 func (c *MistralClient) UpdateDocument(libraryID, documentID string, req *UpdateDocumentRequest) (*Document, error) {
+	return c.UpdateDocumentCtx(context.Background(), libraryID, documentID, req)
+}
+
+// UpdateDocumentCtx is like UpdateDocument but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateDocumentCtx(ctx context.Context, libraryID, documentID string, req *UpdateDocumentRequest) (*Document, error) {
+	ctx = withOperation(ctx, "libraries.documents.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -241,19 +249,8 @@ func (c *MistralClient) UpdateDocument(libraryID, documentID string, req *Update
 		reqMap["attributes"] = req.Attributes
 	}
 
-	response, err := c.request(http.MethodPatch, reqMap, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var document Document
-	err = mapToStruct(respData, &document)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPatch, reqMap, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), &document); err != nil {
 		return nil, err
 	}
 
@@ -262,19 +259,14 @@ func (c *MistralClient) UpdateDocument(libraryID, documentID string, req *Update
 
 // DeleteDocument deletes a document
 func (c *MistralClient) DeleteDocument(libraryID, documentID string) (*DeleteDocumentResponse, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.DeleteDocumentCtx(context.Background(), libraryID, documentID)
+}
 
+// DeleteDocumentCtx is like DeleteDocument but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteDocumentCtx(ctx context.Context, libraryID, documentID string) (*DeleteDocumentResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.delete")
 	var deleteResponse DeleteDocumentResponse
-	err = mapToStruct(respData, &deleteResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/libraries/%s/documents/%s", libraryID, documentID), &deleteResponse); err != nil {
 		return nil, err
 	}
 
@@ -283,19 +275,14 @@ func (c *MistralClient) DeleteDocument(libraryID, documentID string) (*DeleteDoc
 
 // GetDocumentStatus retrieves the processing status of a document
 func (c *MistralClient) GetDocumentStatus(libraryID, documentID string) (*DocumentStatusResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/status", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetDocumentStatusCtx(context.Background(), libraryID, documentID)
+}
 
+// GetDocumentStatusCtx is like GetDocumentStatus but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetDocumentStatusCtx(ctx context.Context, libraryID, documentID string) (*DocumentStatusResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.get_status")
 	var statusResponse DocumentStatusResponse
-	err = mapToStruct(respData, &statusResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/status", libraryID, documentID), &statusResponse); err != nil {
 		return nil, err
 	}
 
@@ -304,19 +291,14 @@ func (c *MistralClient) GetDocumentStatus(libraryID, documentID string) (*Docume
 
 // GetDocumentTextContent retrieves extracted text content for a document.
 func (c *MistralClient) GetDocumentTextContent(libraryID, documentID string) (*DocumentTextContent, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/text_content", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetDocumentTextContentCtx(context.Background(), libraryID, documentID)
+}
 
+// GetDocumentTextContentCtx is like GetDocumentTextContent but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetDocumentTextContentCtx(ctx context.Context, libraryID, documentID string) (*DocumentTextContent, error) {
+	ctx = withOperation(ctx, "libraries.documents.get_text_content")
 	var textContent DocumentTextContent
-	err = mapToStruct(respData, &textContent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/text_content", libraryID, documentID), &textContent); err != nil {
 		return nil, err
 	}
 
@@ -325,19 +307,14 @@ func (c *MistralClient) GetDocumentTextContent(libraryID, documentID string) (*D
 
 // GetDocumentSignedURL retrieves a signed URL for the document binary.
 func (c *MistralClient) GetDocumentSignedURL(libraryID, documentID string) (*DocumentSignedURLResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/signed-url", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetDocumentSignedURLCtx(context.Background(), libraryID, documentID)
+}
 
+// GetDocumentSignedURLCtx is like GetDocumentSignedURL but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetDocumentSignedURLCtx(ctx context.Context, libraryID, documentID string) (*DocumentSignedURLResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.get_signed_url")
 	var signedURL DocumentSignedURLResponse
-	err = mapToStruct(respData, &signedURL)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/signed-url", libraryID, documentID), &signedURL); err != nil {
 		return nil, err
 	}
 
@@ -346,19 +323,14 @@ func (c *MistralClient) GetDocumentSignedURL(libraryID, documentID string) (*Doc
 
 // GetDocumentExtractedTextSignedURL retrieves a signed URL for OCR extracted text.
 func (c *MistralClient) GetDocumentExtractedTextSignedURL(libraryID, documentID string) (*DocumentSignedURLResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/extracted-text-signed-url", libraryID, documentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetDocumentExtractedTextSignedURLCtx(context.Background(), libraryID, documentID)
+}
 
+// GetDocumentExtractedTextSignedURLCtx is like GetDocumentExtractedTextSignedURL but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetDocumentExtractedTextSignedURLCtx(ctx context.Context, libraryID, documentID string) (*DocumentSignedURLResponse, error) {
+	ctx = withOperation(ctx, "libraries.documents.get_extracted_text_signed_url")
 	var signedURL DocumentSignedURLResponse
-	err = mapToStruct(respData, &signedURL)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s/documents/%s/extracted-text-signed-url", libraryID, documentID), &signedURL); err != nil {
 		return nil, err
 	}
 
@@ -367,6 +339,11 @@ func (c *MistralClient) GetDocumentExtractedTextSignedURL(libraryID, documentID
 
 // ReprocessDocument requests document reprocessing.
 func (c *MistralClient) ReprocessDocument(libraryID, documentID string) error {
-	_, err := c.request(http.MethodPost, map[string]interface{}{}, fmt.Sprintf("v1/libraries/%s/documents/%s/reprocess", libraryID, documentID), false, nil)
-	return err
+	return c.ReprocessDocumentCtx(context.Background(), libraryID, documentID)
+}
+
+// ReprocessDocumentCtx is like ReprocessDocument but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ReprocessDocumentCtx(ctx context.Context, libraryID, documentID string) error {
+	ctx = withOperation(ctx, "libraries.documents.reprocess")
+	return c.requestInto(ctx, http.MethodPost, map[string]interface{}{}, fmt.Sprintf("v1/libraries/%s/documents/%s/reprocess", libraryID, documentID), nil)
 }
@@ -1,7 +1,7 @@
 package sdk
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -32,7 +32,13 @@ type EmbeddingRequest struct {
 
 // Embeddings creates embeddings for the given input texts (simple version)
 func (c *MistralClient) Embeddings(model string, input []string) (*EmbeddingResponse, error) {
-	return c.EmbeddingsWithParams(model, input, nil)
+	return c.EmbeddingsCtx(context.Background(), model, input)
+}
+
+// EmbeddingsCtx is like Embeddings but uses ctx for cancellation and deadlines.
+func (c *MistralClient) EmbeddingsCtx(ctx context.Context, model string, input []string) (*EmbeddingResponse, error) {
+	ctx = withOperation(ctx, "embeddings.create")
+	return c.EmbeddingsWithParamsCtx(ctx, model, input, nil)
 }
 
 // EmbeddingsWithParams creates embeddings with additional parameters
@@ -44,6 +50,12 @@ func (c *MistralClient) Embeddings(model string, input []string) (*EmbeddingResp
 //
 // Returns embeddings for the input texts
 func (c *MistralClient) EmbeddingsWithParams(model string, input []string, params *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return c.EmbeddingsWithParamsCtx(context.Background(), model, input, params)
+}
+
+// EmbeddingsWithParamsCtx is like EmbeddingsWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) EmbeddingsWithParamsCtx(ctx context.Context, model string, input []string, params *EmbeddingRequest) (*EmbeddingResponse, error) {
+	ctx = withOperation(ctx, "embeddings.create")
 	if params == nil {
 		params = &EmbeddingRequest{}
 	}
@@ -67,19 +79,8 @@ func (c *MistralClient) EmbeddingsWithParams(model string, input []string, param
 		requestData["output_dtype"] = *params.OutputDtype
 	}
 
-	response, err := c.request(http.MethodPost, requestData, "v1/embeddings", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var embeddingResponse EmbeddingResponse
-	err = mapToStruct(respData, &embeddingResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, requestData, "v1/embeddings", &embeddingResponse); err != nil {
 		return nil, err
 	}
 
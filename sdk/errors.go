@@ -1,7 +1,12 @@
 package sdk
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 )
 
 // MistralError is the base error type for all Mistral errors.
@@ -13,32 +18,219 @@ func (e *MistralError) Error() string {
 	return e.Message
 }
 
+// ValidationErrorDetail is one entry of the validation `detail` list returned for 422 responses.
+type ValidationErrorDetail struct {
+	Type  string `json:"type,omitempty"`
+	Loc   []any  `json:"loc,omitempty"`
+	Msg   string `json:"msg,omitempty"`
+	Input any    `json:"input,omitempty"`
+}
+
 // MistralAPIError is returned when the API responds with an error message.
 type MistralAPIError struct {
 	MistralError
 	HTTPStatus int
 	Headers    map[string][]string
+	RequestID  string                  // Value of the x-request-id header, or request_id from the body
+	Type       string                  // Error type reported by the API, e.g. "invalid_request_error"
+	Code       string                  // Error code reported by the API, if any
+	Param      string                  // Offending request parameter, if any
+	Detail     []ValidationErrorDetail // Validation errors for 422 responses
+	Body       []byte                  // Raw response body
 }
 
 func NewMistralAPIError(message string, httpStatus int, headers map[string][]string) *MistralAPIError {
 	return &MistralAPIError{
 		MistralError: MistralError{Message: message},
-		HTTPStatus:       httpStatus,
-		Headers:          headers,
+		HTTPStatus:   httpStatus,
+		Headers:      headers,
+		RequestID:    http.Header(headers).Get("X-Request-Id"),
 	}
 }
 
 func (e *MistralAPIError) Error() string {
+	if e.HTTPStatus == 0 {
+		return e.Message
+	}
 	return fmt.Sprintf("%s (HTTP status: %d)", e.Message, e.HTTPStatus)
 }
 
+// IsRateLimited reports whether the request was rejected because of rate limits.
+func (e *MistralAPIError) IsRateLimited() bool {
+	return e.HTTPStatus == http.StatusTooManyRequests || strings.Contains(strings.ToLower(e.Type), "rate_limit")
+}
+
+// IsAuthError reports whether the request was rejected because of a missing, invalid or unauthorized API key.
+func (e *MistralAPIError) IsAuthError() bool {
+	return e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden
+}
+
+// IsContextLengthExceeded reports whether the prompt did not fit in the model's context window.
+func (e *MistralAPIError) IsContextLengthExceeded() bool {
+	if e.HTTPStatus != http.StatusBadRequest && e.HTTPStatus != http.StatusUnprocessableEntity && e.HTTPStatus != 0 {
+		return false
+	}
+	message := strings.ToLower(e.Message)
+	return strings.Contains(message, "context length") ||
+		strings.Contains(message, "context_length") ||
+		strings.Contains(message, "too large for model")
+}
+
+// IsServerError reports whether the API failed with a 5xx status.
+func (e *MistralAPIError) IsServerError() bool {
+	return e.HTTPStatus >= 500
+}
+
 // MistralConnectionError is returned when the SDK cannot reach the API server for any reason.
 type MistralConnectionError struct {
 	MistralError
+	Err error
 }
 
 func NewMistralConnectionError(message string) *MistralConnectionError {
 	return &MistralConnectionError{
 		MistralError: MistralError{Message: message},
 	}
-}
\ No newline at end of file
+}
+
+// Unwrap returns the underlying transport error, so errors.Is works with context.Canceled and friends.
+func (e *MistralConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// IsRateLimited reports whether err is a MistralAPIError caused by rate limiting.
+func IsRateLimited(err error) bool {
+	var apiErr *MistralAPIError
+	return errors.As(err, &apiErr) && apiErr.IsRateLimited()
+}
+
+// IsAuthError reports whether err is a MistralAPIError caused by authentication or authorization.
+func IsAuthError(err error) bool {
+	var apiErr *MistralAPIError
+	return errors.As(err, &apiErr) && apiErr.IsAuthError()
+}
+
+// IsContextLengthExceeded reports whether err is a MistralAPIError caused by an oversized prompt.
+func IsContextLengthExceeded(err error) bool {
+	var apiErr *MistralAPIError
+	return errors.As(err, &apiErr) && apiErr.IsContextLengthExceeded()
+}
+
+func newConnectionError(err error) *MistralConnectionError {
+	return &MistralConnectionError{
+		MistralError: MistralError{Message: err.Error()},
+		Err:          err,
+	}
+}
+
+// newAPIError builds a MistralAPIError from an HTTP error response, parsing the Mistral error body when possible.
+func newAPIError(httpStatus int, headers http.Header, body []byte) *MistralAPIError {
+	apiErr := NewMistralAPIError(strings.TrimSpace(string(body)), httpStatus, headers)
+	apiErr.Body = body
+	parseAPIErrorBody(apiErr, body)
+	return apiErr
+}
+
+// parseAPIErrorBody fills apiErr from a JSON error body; bodies that are not JSON objects are left as the message.
+func parseAPIErrorBody(apiErr *MistralAPIError, body []byte) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return
+	}
+	// Some gateways nest the error object under "error".
+	if nested, ok := payload["error"]; ok {
+		var inner map[string]json.RawMessage
+		if json.Unmarshal(nested, &inner) == nil {
+			payload = inner
+		} else {
+			payload["message"] = nested
+		}
+	}
+
+	if raw, ok := payload["message"]; ok {
+		var message string
+		if json.Unmarshal(raw, &message) == nil {
+			apiErr.Message = message
+		} else {
+			var nested struct {
+				Detail json.RawMessage `json:"detail"`
+			}
+			if json.Unmarshal(raw, &nested) == nil && nested.Detail != nil {
+				payload["detail"] = nested.Detail
+			}
+		}
+	}
+	if raw, ok := payload["detail"]; ok {
+		var detail string
+		if json.Unmarshal(raw, &detail) == nil {
+			apiErr.Message = detail
+		} else if json.Unmarshal(raw, &apiErr.Detail) == nil && len(apiErr.Detail) > 0 {
+			messages := make([]string, 0, len(apiErr.Detail))
+			for _, d := range apiErr.Detail {
+				messages = append(messages, formatValidationDetail(d))
+			}
+			apiErr.Message = strings.Join(messages, "; ")
+		}
+	}
+	apiErr.Type = rawString(payload["type"])
+	apiErr.Code = rawString(payload["code"])
+	apiErr.Param = rawString(payload["param"])
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = rawString(payload["request_id"])
+	}
+}
+
+// streamAPIError returns a MistralAPIError when an SSE data payload is an error event, or nil otherwise.
+func streamAPIError(data []byte) *MistralAPIError {
+	var probe struct {
+		Object string          `json:"object"`
+		Type   string          `json:"type"`
+		Error  json.RawMessage `json:"error"`
+		Status json.RawMessage `json:"status"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil
+	}
+	// Typed events, such as conversation events, may carry an error field of their own, like a
+	// failed tool execution; only untyped payloads are errors because of it.
+	hasError := len(probe.Error) > 0 && string(probe.Error) != "null"
+	isError := probe.Object == "error" || probe.Type == "error" || strings.HasSuffix(probe.Type, ".error") || (hasError && probe.Type == "")
+	if !isError {
+		return nil
+	}
+	apiErr := &MistralAPIError{MistralError: MistralError{Message: string(data)}, Body: data}
+	parseAPIErrorBody(apiErr, data)
+	if status, err := strconv.Atoi(rawString(probe.Status)); err == nil {
+		apiErr.HTTPStatus = status
+	} else if code, err := strconv.Atoi(apiErr.Code); err == nil && code >= 400 && code < 600 {
+		apiErr.HTTPStatus = code
+	}
+	return apiErr
+}
+
+func formatValidationDetail(d ValidationErrorDetail) string {
+	if len(d.Loc) == 0 {
+		return d.Msg
+	}
+	loc := make([]string, 0, len(d.Loc))
+	for _, part := range d.Loc {
+		loc = append(loc, fmt.Sprint(part))
+	}
+	return strings.Join(loc, ".") + ": " + d.Msg
+}
+
+// rawString decodes a JSON string or number as a string; null and other values yield "".
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
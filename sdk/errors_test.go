@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestNewAPIErrorParsesMistralBody(t *testing.T) {
+	headers := http.Header{"X-Request-Id": []string{"req-123"}}
+	body := []byte(`{"object":"error","message":"Invalid model: foo","type":"invalid_model","param":"model","code":"1500"}`)
+
+	apiErr := newAPIError(http.StatusBadRequest, headers, body)
+	if apiErr.Message != "Invalid model: foo" {
+		t.Errorf("unexpected message %q", apiErr.Message)
+	}
+	if apiErr.Type != "invalid_model" || apiErr.Param != "model" || apiErr.Code != "1500" {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("expected request ID from header, got %q", apiErr.RequestID)
+	}
+	if apiErr.Error() != "Invalid model: foo (HTTP status: 400)" {
+		t.Errorf("unexpected Error() %q", apiErr.Error())
+	}
+}
+
+func TestNewAPIErrorParsesValidationDetail(t *testing.T) {
+	body := []byte(`{"object":"error","message":{"detail":[{"type":"missing","loc":["body","model"],"msg":"Field required"}]},"type":"invalid_request_message_error","param":null,"code":null}`)
+
+	apiErr := newAPIError(http.StatusUnprocessableEntity, nil, body)
+	if len(apiErr.Detail) != 1 || apiErr.Detail[0].Msg != "Field required" {
+		t.Fatalf("unexpected detail: %+v", apiErr.Detail)
+	}
+	if apiErr.Message != "body.model: Field required" {
+		t.Errorf("unexpected message %q", apiErr.Message)
+	}
+	if apiErr.Code != "" || apiErr.Param != "" {
+		t.Errorf("expected null code and param to be empty, got %q %q", apiErr.Code, apiErr.Param)
+	}
+}
+
+func TestNewAPIErrorNonJSONBody(t *testing.T) {
+	apiErr := newAPIError(http.StatusBadGateway, nil, []byte("<html>bad gateway</html>"))
+	if apiErr.Message != "<html>bad gateway</html>" {
+		t.Errorf("expected raw body as message, got %q", apiErr.Message)
+	}
+	if !apiErr.IsServerError() {
+		t.Error("expected server error")
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		rateLimited   bool
+		auth          bool
+		contextLength bool
+	}{
+		{"rate limited", http.StatusTooManyRequests, `{"message":"Requests rate limit exceeded"}`, true, false, false},
+		{"unauthorized", http.StatusUnauthorized, `{"message":"Unauthorized","request_id":"abc"}`, false, true, false},
+		{"forbidden", http.StatusForbidden, `{"detail":"Forbidden"}`, false, true, false},
+		{"context length", http.StatusBadRequest, `{"object":"error","message":"Prompt contains 40000 tokens, too large for model with 32768 maximum context length","type":"invalid_request_error"}`, false, false, true},
+		{"bad request", http.StatusBadRequest, `{"message":"bad"}`, false, false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error = newAPIError(tc.status, nil, []byte(tc.body))
+			if IsRateLimited(err) != tc.rateLimited {
+				t.Errorf("IsRateLimited = %v, want %v", !tc.rateLimited, tc.rateLimited)
+			}
+			if IsAuthError(err) != tc.auth {
+				t.Errorf("IsAuthError = %v, want %v", !tc.auth, tc.auth)
+			}
+			if IsContextLengthExceeded(err) != tc.contextLength {
+				t.Errorf("IsContextLengthExceeded = %v, want %v", !tc.contextLength, tc.contextLength)
+			}
+		})
+	}
+
+	if IsRateLimited(errors.New("plain")) {
+		t.Error("plain errors are never rate limited")
+	}
+}
+
+func TestRequestReturnsTypedAPIError(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-429")
+		MockJSONResponse(http.StatusTooManyRequests, `{"object":"error","message":"Rate limit exceeded","type":"rate_limited"}`).Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1))
+	_, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+
+	var apiErr *MistralAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *MistralAPIError, got %T: %v", err, err)
+	}
+	if apiErr.HTTPStatus != http.StatusTooManyRequests || apiErr.RequestID != "req-429" {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+	if !IsRateLimited(err) {
+		t.Error("expected rate limited error")
+	}
+}
+
+func TestConnectionErrorUnwraps(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:1"), WithMaxRetries(1))
+	_, err := client.ListModels()
+
+	var connErr *MistralConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected *MistralConnectionError, got %T: %v", err, err)
+	}
+	if connErr.Unwrap() == nil {
+		t.Error("expected the transport error to be wrapped")
+	}
+}
+
+func TestChatStreamSurfacesMidStreamAPIError(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"object\":\"error\",\"message\":\"Service overloaded\",\"type\":\"service_unavailable\",\"code\":\"503\"}\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().ChatStreamChan("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var streamErr error
+	chunks := 0
+	for chunk := range stream {
+		if chunk.Error != nil {
+			streamErr = chunk.Error
+			continue
+		}
+		chunks++
+	}
+	if chunks != 1 {
+		t.Errorf("expected 1 content chunk, got %d", chunks)
+	}
+	var apiErr *MistralAPIError
+	if !errors.As(streamErr, &apiErr) {
+		t.Fatalf("expected *MistralAPIError, got %T: %v", streamErr, streamErr)
+	}
+	if apiErr.Message != "Service overloaded" || apiErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Errorf("unexpected stream error: %+v", apiErr)
+	}
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -120,6 +121,12 @@ type ListFilesParams struct {
 //   - filename: The name of the file
 //   - purpose: The intended purpose of the file (fine-tune, batch, etc.)
 func (c *MistralClient) UploadFile(file io.Reader, filename string, purpose FilePurpose) (*UploadFileOut, error) {
+	return c.UploadFileCtx(context.Background(), file, filename, purpose)
+}
+
+// UploadFileCtx is like UploadFile but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UploadFileCtx(ctx context.Context, file io.Reader, filename string, purpose FilePurpose) (*UploadFileOut, error) {
+	ctx = withOperation(ctx, "files.upload")
 	// Create multipart form
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -146,60 +153,48 @@ func (c *MistralClient) UploadFile(file io.Reader, filename string, purpose File
 	}
 
 	// Create request
-	req, err := http.NewRequest(http.MethodPost, c.endpoint+"/v1/files", body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/v1/files", body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", UserAgent)
-
-	// Send request with retry logic
-	client := &http.Client{Timeout: c.timeout}
-	var lastErr error
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		resp, err := client.Do(req)
-		if err != nil {
-			lastErr = err
-			if attempt < c.maxRetries {
-				continue
-			}
-			return nil, NewMistralConnectionError(err.Error())
-		}
-		defer resp.Body.Close()
 
-		// Check if we should retry
-		if retryStatusCodes[resp.StatusCode] && attempt < c.maxRetries {
-			lastErr = fmt.Errorf("received retry status code: %d", resp.StatusCode)
-			continue
-		}
-
-		// Read response body
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
+	resp, err := c.doWithRetry(req, RequestKindUpload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-		// Check for errors
-		if resp.StatusCode >= 400 {
-			return nil, NewMistralAPIError(string(respBody), resp.StatusCode, resp.Header)
-		}
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
 
-		// Parse response
-		var result UploadFileOut
-		if err := json.Unmarshal(respBody, &result); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-		}
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp.StatusCode, resp.Header, respBody)
+	}
 
-		return &result, nil
+	// Parse response
+	var result UploadFileOut
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+	return &result, nil
 }
 
 // ListFiles returns a list of files that belong to the user's organization.
 func (c *MistralClient) ListFiles(params *ListFilesParams) (*ListFilesOut, error) {
+	return c.ListFilesCtx(context.Background(), params)
+}
+
+// ListFilesCtx is like ListFiles but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListFilesCtx(ctx context.Context, params *ListFilesParams) (*ListFilesOut, error) {
+	ctx = withOperation(ctx, "files.list")
 	if params == nil {
 		params = &ListFilesParams{}
 	}
@@ -236,19 +231,8 @@ func (c *MistralClient) ListFiles(params *ListFilesParams) (*ListFilesOut, error
 		path += "?" + queryParams.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var listFilesOut ListFilesOut
-	err = mapToStruct(respData, &listFilesOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &listFilesOut); err != nil {
 		return nil, err
 	}
 
@@ -257,19 +241,14 @@ func (c *MistralClient) ListFiles(params *ListFilesParams) (*ListFilesOut, error
 
 // RetrieveFile retrieves information about a specific file.
 func (c *MistralClient) RetrieveFile(fileID string) (*RetrieveFileOut, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/files/%s", fileID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.RetrieveFileCtx(context.Background(), fileID)
+}
 
+// RetrieveFileCtx is like RetrieveFile but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RetrieveFileCtx(ctx context.Context, fileID string) (*RetrieveFileOut, error) {
+	ctx = withOperation(ctx, "files.retrieve")
 	var retrieveFileOut RetrieveFileOut
-	err = mapToStruct(respData, &retrieveFileOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/files/%s", fileID), &retrieveFileOut); err != nil {
 		return nil, err
 	}
 
@@ -278,19 +257,14 @@ func (c *MistralClient) RetrieveFile(fileID string) (*RetrieveFileOut, error) {
 
 // DeleteFile deletes a file.
 func (c *MistralClient) DeleteFile(fileID string) (*DeleteFileOut, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/files/%s", fileID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.DeleteFileCtx(context.Background(), fileID)
+}
 
+// DeleteFileCtx is like DeleteFile but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteFileCtx(ctx context.Context, fileID string) (*DeleteFileOut, error) {
+	ctx = withOperation(ctx, "files.delete")
 	var deleteFileOut DeleteFileOut
-	err = mapToStruct(respData, &deleteFileOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/files/%s", fileID), &deleteFileOut); err != nil {
 		return nil, err
 	}
 
@@ -300,25 +274,29 @@ func (c *MistralClient) DeleteFile(fileID string) (*DeleteFileOut, error) {
 // DownloadFile downloads the content of a file.
 // Returns the file content as a byte slice.
 func (c *MistralClient) DownloadFile(fileID string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, c.endpoint+fmt.Sprintf("/v1/files/%s/content", fileID), nil)
+	return c.DownloadFileCtx(context.Background(), fileID)
+}
+
+// DownloadFileCtx is like DownloadFile but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DownloadFileCtx(ctx context.Context, fileID string) ([]byte, error) {
+	ctx = withOperation(ctx, "files.download")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+fmt.Sprintf("/v1/files/%s/content", fileID), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	req.Header.Set("Accept", "application/octet-stream")
-	req.Header.Set("User-Agent", UserAgent)
 
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.doWithRetry(req, RequestKindDefault)
 	if err != nil {
-		return nil, NewMistralConnectionError(err.Error())
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, NewMistralAPIError(string(body), resp.StatusCode, resp.Header)
+		return nil, newAPIError(resp.StatusCode, resp.Header, body)
 	}
 
 	content, err := io.ReadAll(resp.Body)
@@ -332,6 +310,12 @@ func (c *MistralClient) DownloadFile(fileID string) ([]byte, error) {
 // GetSignedURL retrieves a signed URL for accessing a file.
 // The URL will be valid for the specified number of hours (default: 24h).
 func (c *MistralClient) GetSignedURL(fileID string, expiryHours *int) (*FileSignedURL, error) {
+	return c.GetSignedURLCtx(context.Background(), fileID, expiryHours)
+}
+
+// GetSignedURLCtx is like GetSignedURL but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetSignedURLCtx(ctx context.Context, fileID string, expiryHours *int) (*FileSignedURL, error) {
+	ctx = withOperation(ctx, "files.get_signed_url")
 	queryParams := url.Values{}
 	if expiryHours != nil {
 		queryParams.Add("expiry", fmt.Sprintf("%d", *expiryHours))
@@ -342,19 +326,8 @@ func (c *MistralClient) GetSignedURL(fileID string, expiryHours *int) (*FileSign
 		path += "?" + queryParams.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var fileSignedURL FileSignedURL
-	err = mapToStruct(respData, &fileSignedURL)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &fileSignedURL); err != nil {
 		return nil, err
 	}
 
@@ -1,9 +1,7 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -48,6 +46,7 @@ type FIMCompletionStreamResponse struct {
 	Created int                                 `json:"created"`
 	Model   string                              `json:"model"`
 	Choices []FIMCompletionResponseChoiceStream `json:"choices"`
+	Usage   UsageInfo                           `json:"usage,omitempty"`
 	Error   error                               `json:"-"`
 }
 
@@ -60,6 +59,12 @@ type FIMCompletionResponseChoiceStream struct {
 
 // FIM sends a FIM request and returns the completion response (non-streaming).
 func (c *MistralClient) FIM(params *FIMRequestParams) (*FIMCompletionResponse, error) {
+	return c.FIMCtx(context.Background(), params)
+}
+
+// FIMCtx is like FIM but uses ctx for cancellation and deadlines.
+func (c *MistralClient) FIMCtx(ctx context.Context, params *FIMRequestParams) (*FIMCompletionResponse, error) {
+	ctx = withOperation(ctx, "fim.completions")
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -97,32 +102,27 @@ func (c *MistralClient) FIM(params *FIMRequestParams) (*FIMCompletionResponse, e
 		requestData["stop"] = params.Stop
 	}
 
-	response, err := c.request(http.MethodPost, requestData, "v1/fim/completions", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var fimResponse FIMCompletionResponse
-	err = mapToStruct(respData, &fimResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, requestData, "v1/fim/completions", &fimResponse); err != nil {
 		return nil, err
 	}
 
 	return &fimResponse, nil
 }
 
-// FIMStream sends a streaming FIM request and returns a channel of responses
+// FIMStream sends a streaming FIM request and returns a Stream of the completion chunks
 //
 // Parameters:
 //   - params: FIM request parameters (stream will be set to true automatically)
 //
-// Returns a channel that streams FIM completion chunks
-func (c *MistralClient) FIMStream(params *FIMRequestParams) (<-chan FIMCompletionStreamResponse, error) {
+// Close the stream when done with it. FIMStreamChan delivers the chunks on a channel instead.
+func (c *MistralClient) FIMStream(params *FIMRequestParams) (*Stream[FIMCompletionStreamResponse], error) {
+	return c.FIMStreamCtx(context.Background(), params)
+}
+
+// FIMStreamCtx is like FIMStream but uses ctx for cancellation and deadlines.
+func (c *MistralClient) FIMStreamCtx(ctx context.Context, params *FIMRequestParams) (*Stream[FIMCompletionStreamResponse], error) {
+	ctx = withOperation(ctx, "fim.completions.stream")
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -160,58 +160,29 @@ func (c *MistralClient) FIMStream(params *FIMRequestParams) (<-chan FIMCompletio
 		requestData["stop"] = params.Stop
 	}
 
-	// Create response channel
-	responseChan := make(chan FIMCompletionStreamResponse)
-
-	response, err := c.request(http.MethodPost, requestData, "v1/fim/completions", true, nil)
+	response, err := c.request(ctx, http.MethodPost, requestData, "v1/fim/completions", true, nil)
 	if err != nil {
-		close(responseChan)
 		return nil, err
 	}
 
 	respBody, ok := response.(io.ReadCloser)
 	if !ok {
-		close(responseChan)
 		return nil, fmt.Errorf("invalid response type: %T", response)
 	}
 
-	// Start streaming in a goroutine
-	go func() {
-		defer close(responseChan)
-		defer respBody.Close()
-
-		reader := bufio.NewReader(respBody)
-
-		for {
-			line, err := reader.ReadBytes('\n')
-			if err == io.EOF {
-				break
-			} else if err != nil {
-				responseChan <- FIMCompletionStreamResponse{Error: fmt.Errorf("error reading stream response: %w", err)}
-				return
-			}
-
-			if bytes.Equal(line, []byte("\n")) {
-				continue
-			}
-
-			if bytes.HasPrefix(line, []byte("data: ")) {
-				jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-
-				if bytes.Equal(jsonLine, []byte("[DONE]")) {
-					break
-				}
-
-				var streamResponse FIMCompletionStreamResponse
-				if err := json.Unmarshal(jsonLine, &streamResponse); err != nil {
-					responseChan <- FIMCompletionStreamResponse{Error: fmt.Errorf("error unmarshaling stream response: %w", err)}
-					return
-				}
+	return newStream(ctx, respBody, c.streamIdleTimeout, decodeChunk[FIMCompletionStreamResponse], nil), nil
+}
 
-				responseChan <- streamResponse
-			}
-		}
-	}()
+// FIMStreamChan is like FIMStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) FIMStreamChan(params *FIMRequestParams) (<-chan FIMCompletionStreamResponse, error) {
+	return c.FIMStreamChanCtx(context.Background(), params)
+}
 
-	return responseChan, nil
+// FIMStreamChanCtx is like FIMStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) FIMStreamChanCtx(ctx context.Context, params *FIMRequestParams) (<-chan FIMCompletionStreamResponse, error) {
+	stream, err := c.FIMStreamCtx(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) FIMCompletionStreamResponse { return FIMCompletionStreamResponse{Error: err} }), nil
 }
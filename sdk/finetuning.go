@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -119,7 +120,14 @@ type ListFineTuningJobsParams struct {
 
 // CreateFineTuningJob creates a new fine-tuning job
 func (c *MistralClient) CreateFineTuningJob(req *CreateFineTuningJobRequest) (*JobOut, error) {
-	response, err := c.request(http.MethodPost, map[string]interface{}{
+	return c.CreateFineTuningJobCtx(context.Background(), req)
+}
+
+// CreateFineTuningJobCtx is like CreateFineTuningJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CreateFineTuningJobCtx(ctx context.Context, req *CreateFineTuningJobRequest) (*JobOut, error) {
+	ctx = withOperation(ctx, "fine_tuning.jobs.create")
+	var jobOut JobOut
+	err := c.requestInto(ctx, http.MethodPost, map[string]interface{}{
 		"model":                          req.Model,
 		"training_files":                 req.TrainingFiles,
 		"validation_files":               req.ValidationFiles,
@@ -129,18 +137,7 @@ func (c *MistralClient) CreateFineTuningJob(req *CreateFineTuningJobRequest) (*J
 		"auto_start":                     req.AutoStart,
 		"invalid_sample_skip_percentage": req.InvalidSampleSkipPercentage,
 		"job_type":                       req.JobType,
-	}, "v1/fine_tuning/jobs", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
-	var jobOut JobOut
-	err = mapToStruct(respData, &jobOut)
+	}, "v1/fine_tuning/jobs", &jobOut)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +147,12 @@ func (c *MistralClient) CreateFineTuningJob(req *CreateFineTuningJobRequest) (*J
 
 // ListFineTuningJobs gets a list of fine-tuning jobs
 func (c *MistralClient) ListFineTuningJobs(params *ListFineTuningJobsParams) (*JobsOut, error) {
+	return c.ListFineTuningJobsCtx(context.Background(), params)
+}
+
+// ListFineTuningJobsCtx is like ListFineTuningJobs but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListFineTuningJobsCtx(ctx context.Context, params *ListFineTuningJobsParams) (*JobsOut, error) {
+	ctx = withOperation(ctx, "fine_tuning.jobs.list")
 	if params == nil {
 		params = &ListFineTuningJobsParams{}
 	}
@@ -192,19 +195,8 @@ func (c *MistralClient) ListFineTuningJobs(params *ListFineTuningJobsParams) (*J
 		path += "?" + queryParams.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var jobsOut JobsOut
-	err = mapToStruct(respData, &jobsOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &jobsOut); err != nil {
 		return nil, err
 	}
 
@@ -213,19 +205,14 @@ func (c *MistralClient) ListFineTuningJobs(params *ListFineTuningJobsParams) (*J
 
 // GetFineTuningJob gets details of a specific fine-tuning job
 func (c *MistralClient) GetFineTuningJob(jobID string) (*JobOut, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s", jobID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetFineTuningJobCtx(context.Background(), jobID)
+}
 
+// GetFineTuningJobCtx is like GetFineTuningJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetFineTuningJobCtx(ctx context.Context, jobID string) (*JobOut, error) {
+	ctx = withOperation(ctx, "fine_tuning.jobs.get")
 	var jobOut JobOut
-	err = mapToStruct(respData, &jobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s", jobID), &jobOut); err != nil {
 		return nil, err
 	}
 
@@ -234,19 +221,14 @@ func (c *MistralClient) GetFineTuningJob(jobID string) (*JobOut, error) {
 
 // CancelFineTuningJob cancels a fine-tuning job
 func (c *MistralClient) CancelFineTuningJob(jobID string) (*JobOut, error) {
-	response, err := c.request(http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s/cancel", jobID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.CancelFineTuningJobCtx(context.Background(), jobID)
+}
 
+// CancelFineTuningJobCtx is like CancelFineTuningJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CancelFineTuningJobCtx(ctx context.Context, jobID string) (*JobOut, error) {
+	ctx = withOperation(ctx, "fine_tuning.jobs.cancel")
 	var jobOut JobOut
-	err = mapToStruct(respData, &jobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s/cancel", jobID), &jobOut); err != nil {
 		return nil, err
 	}
 
@@ -255,19 +237,14 @@ func (c *MistralClient) CancelFineTuningJob(jobID string) (*JobOut, error) {
 
 // StartFineTuningJob starts a fine-tuning job
 func (c *MistralClient) StartFineTuningJob(jobID string) (*JobOut, error) {
-	response, err := c.request(http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s/start", jobID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.StartFineTuningJobCtx(context.Background(), jobID)
+}
 
+// StartFineTuningJobCtx is like StartFineTuningJob but uses ctx for cancellation and deadlines.
+func (c *MistralClient) StartFineTuningJobCtx(ctx context.Context, jobID string) (*JobOut, error) {
+	ctx = withOperation(ctx, "fine_tuning.jobs.start")
 	var jobOut JobOut
-	err = mapToStruct(respData, &jobOut)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/jobs/%s/start", jobID), &jobOut); err != nil {
 		return nil, err
 	}
 
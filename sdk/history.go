@@ -0,0 +1,437 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrHistoryOverBudget is returned when a history is still over its token budget after its
+// strategy ran, for example because the pinned turns alone exceed it.
+var ErrHistoryOverBudget = errors.New("mistral: chat history does not fit its token budget")
+
+// MessageCounter counts the prompt tokens of a chat request with messages and tools. The method
+// CountMessages of a tokenizer.Tokenizer is one.
+type MessageCounter func(messages []ChatMessage, tools []Tool) int
+
+// HistoryTurn is a user message with the assistant and tool messages that follow it, so that tool
+// calls and their results are always kept or dropped together.
+type HistoryTurn struct {
+	Messages []ChatMessage `json:"messages"`
+	Pinned   bool          `json:"pinned,omitempty"` // Pinned turns are never dropped or summarized
+}
+
+// HistoryState is the part of a history a HistoryStrategy may shorten: the summary of the turns
+// dropped so far and the turns, oldest first. The system prompt is always kept.
+type HistoryState struct {
+	Summary string
+	Turns   []HistoryTurn
+}
+
+// HistoryStrategy shortens a history that is over its token budget.
+type HistoryStrategy interface {
+	// Fit returns a shorter state. fits reports whether a state fits the budget. The last turn,
+	// which holds the message being answered, and pinned turns must be kept.
+	Fit(ctx context.Context, state HistoryState, fits func(HistoryState) bool) (HistoryState, error)
+}
+
+// ChatHistory owns the messages of a chat session and keeps them within a token budget before each
+// model call. The system prompt is always kept. Older turns are dropped or summarized by the
+// history's strategy, SlidingWindow by default, except the ones that are pinned.
+//
+//	history := sdk.NewChatHistory("You are a helpful assistant.",
+//		sdk.WithHistoryBudget(24000),
+//		sdk.WithHistoryStrategy(sdk.SummarizeOldest(client, "mistral-small-latest")),
+//	)
+//	history.Add(sdk.ChatMessage{Role: sdk.RoleUser, Content: question})
+//	resp, err := history.Chat(client, "mistral-large-latest", nil)
+//
+// A ChatHistory encodes to JSON, without its options, so sessions can be saved and restored. It is
+// not safe for concurrent use.
+type ChatHistory struct {
+	system   []ChatMessage
+	summary  string
+	turns    []HistoryTurn
+	budget   int
+	counter  MessageCounter
+	strategy HistoryStrategy
+}
+
+// HistoryOption configures a ChatHistory.
+type HistoryOption func(*ChatHistory)
+
+// WithHistoryBudget sets the maximum prompt tokens of the history, tools included. Keep room for
+// the completion: the budget is usually the model's context length minus max_tokens. Zero, the
+// default, sets no limit.
+func WithHistoryBudget(tokens int) HistoryOption {
+	return func(h *ChatHistory) {
+		h.budget = tokens
+	}
+}
+
+// WithHistoryCounter sets how prompt tokens are counted. By default they are estimated at four
+// characters per token; a tokenizer gives exact counts.
+func WithHistoryCounter(counter MessageCounter) HistoryOption {
+	return func(h *ChatHistory) {
+		h.counter = counter
+	}
+}
+
+// WithHistoryStrategy sets how the history is shortened, SlidingWindow by default.
+func WithHistoryStrategy(strategy HistoryStrategy) HistoryOption {
+	return func(h *ChatHistory) {
+		h.strategy = strategy
+	}
+}
+
+// NewChatHistory returns a history with systemPrompt, which may be empty.
+func NewChatHistory(systemPrompt string, opts ...HistoryOption) *ChatHistory {
+	h := &ChatHistory{counter: estimateMessageTokens, strategy: SlidingWindow()}
+	if systemPrompt != "" {
+		h.system = []ChatMessage{{Role: RoleSystem, Content: systemPrompt}}
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Add appends messages. System messages are added to the system prompt; a user message starts a
+// new turn, and other messages join the current one.
+func (h *ChatHistory) Add(messages ...ChatMessage) {
+	h.add(false, messages)
+}
+
+// AddPinned is like Add, but pins the turns the messages belong to.
+func (h *ChatHistory) AddPinned(messages ...ChatMessage) {
+	h.add(true, messages)
+}
+
+func (h *ChatHistory) add(pinned bool, messages []ChatMessage) {
+	for _, message := range messages {
+		if message.Role == RoleSystem {
+			h.system = append(h.system, message)
+			continue
+		}
+		if message.Role == RoleUser || len(h.turns) == 0 {
+			h.turns = append(h.turns, HistoryTurn{})
+		}
+		turn := &h.turns[len(h.turns)-1]
+		turn.Messages = append(turn.Messages, message)
+		turn.Pinned = turn.Pinned || pinned
+	}
+}
+
+// Pin pins the turn holding the message at index in Messages, so that it is never dropped or
+// summarized. System messages and the summary are always kept.
+func (h *ChatHistory) Pin(index int) error {
+	offset := index - len(h.prefix())
+	if index >= 0 && offset < 0 {
+		return nil
+	}
+	for i := range h.turns {
+		if offset >= 0 && offset < len(h.turns[i].Messages) {
+			h.turns[i].Pinned = true
+			return nil
+		}
+		offset -= len(h.turns[i].Messages)
+	}
+	return fmt.Errorf("mistral: message index %d out of range", index)
+}
+
+// Summary returns the summary of the turns dropped by SummarizeOldest, if any.
+func (h *ChatHistory) Summary() string {
+	return h.summary
+}
+
+// Turns returns the turns of the history, oldest first.
+func (h *ChatHistory) Turns() []HistoryTurn {
+	return append([]HistoryTurn(nil), h.turns...)
+}
+
+// Messages returns the messages to send: the system prompt, the summary as a system message, and
+// the turns.
+func (h *ChatHistory) Messages() []ChatMessage {
+	return h.render(HistoryState{Summary: h.summary, Turns: h.turns})
+}
+
+// Tokens returns the prompt tokens of Messages with tools.
+func (h *ChatHistory) Tokens(tools []Tool) int {
+	return h.counter(h.Messages(), tools)
+}
+
+func (h *ChatHistory) prefix() []ChatMessage {
+	return h.render(HistoryState{Summary: h.summary})
+}
+
+func (h *ChatHistory) render(state HistoryState) []ChatMessage {
+	messages := append([]ChatMessage(nil), h.system...)
+	if state.Summary != "" {
+		messages = append(messages, ChatMessage{Role: RoleSystem, Content: "Summary of the earlier conversation:\n" + state.Summary})
+	}
+	for _, turn := range state.Turns {
+		messages = append(messages, turn.Messages...)
+	}
+	return messages
+}
+
+// Fit shortens the history with its strategy when it is over budget with tools. It returns
+// ErrHistoryOverBudget, and leaves the history unchanged, if the shortened history still does not
+// fit.
+func (h *ChatHistory) Fit(ctx context.Context, tools []Tool) error {
+	fits := func(state HistoryState) bool {
+		return h.budget <= 0 || h.counter(h.render(state), tools) <= h.budget
+	}
+	state := HistoryState{Summary: h.summary, Turns: h.turns}
+	if fits(state) {
+		return nil
+	}
+	state, err := h.strategy.Fit(ctx, HistoryState{Summary: h.summary, Turns: append([]HistoryTurn(nil), h.turns...)}, fits)
+	if err != nil {
+		return err
+	}
+	if !fits(state) {
+		return ErrHistoryOverBudget
+	}
+	h.summary, h.turns = state.Summary, state.Turns
+	return nil
+}
+
+// Chat fits the history to its budget, sends it with Chat, and adds the first choice of the reply
+// to the history. params may be nil.
+func (h *ChatHistory) Chat(client *MistralClient, model string, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	return h.ChatCtx(context.Background(), client, model, params)
+}
+
+// ChatCtx is like Chat but uses ctx for cancellation and deadlines.
+func (h *ChatHistory) ChatCtx(ctx context.Context, client *MistralClient, model string, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	if err := h.Fit(ctx, paramsTools(params)); err != nil {
+		return nil, err
+	}
+	resp, err := client.ChatCtx(ctx, model, h.Messages(), params)
+	if err != nil {
+		return nil, err
+	}
+	h.addReply(resp)
+	return resp, nil
+}
+
+// ChatStream is like Chat but streams the reply with ChatStream, passing content deltas to
+// onContent, which may be nil, as they arrive. The reply is added to the history once the stream
+// completes.
+func (h *ChatHistory) ChatStream(client *MistralClient, model string, params *ChatRequestParams, onContent func(delta string)) (*ChatCompletionResponse, error) {
+	return h.ChatStreamCtx(context.Background(), client, model, params, onContent)
+}
+
+// ChatStreamCtx is like ChatStream but uses ctx for cancellation and deadlines.
+func (h *ChatHistory) ChatStreamCtx(ctx context.Context, client *MistralClient, model string, params *ChatRequestParams, onContent func(delta string)) (*ChatCompletionResponse, error) {
+	if err := h.Fit(ctx, paramsTools(params)); err != nil {
+		return nil, err
+	}
+	stream, err := client.ChatStreamCtx(ctx, model, h.Messages(), params)
+	if err != nil {
+		return nil, err
+	}
+	acc := &StreamAccumulator{}
+	if onContent != nil {
+		acc.OnContent = func(choice int, delta string) {
+			if choice == 0 {
+				onContent(delta)
+			}
+		}
+	}
+	resp, err := AccumulateChatStream(stream, acc)
+	if err != nil {
+		return resp, err
+	}
+	h.addReply(resp)
+	return resp, nil
+}
+
+func (h *ChatHistory) addReply(resp *ChatCompletionResponse) {
+	if len(resp.Choices) > 0 {
+		h.Add(resp.Choices[0].Message)
+	}
+}
+
+type chatHistoryJSON struct {
+	System  []ChatMessage `json:"system,omitempty"`
+	Summary string        `json:"summary,omitempty"`
+	Turns   []HistoryTurn `json:"turns"`
+}
+
+// MarshalJSON encodes the system prompt, the summary, and the turns.
+func (h *ChatHistory) MarshalJSON() ([]byte, error) {
+	turns := h.turns
+	if turns == nil {
+		turns = []HistoryTurn{}
+	}
+	return json.Marshal(chatHistoryJSON{System: h.system, Summary: h.summary, Turns: turns})
+}
+
+// UnmarshalJSON restores a history encoded with MarshalJSON. The options of h are kept.
+func (h *ChatHistory) UnmarshalJSON(data []byte) error {
+	var decoded chatHistoryJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	h.system, h.summary, h.turns = decoded.System, decoded.Summary, decoded.Turns
+	if h.counter == nil {
+		h.counter = estimateMessageTokens
+	}
+	if h.strategy == nil {
+		h.strategy = SlidingWindow()
+	}
+	return nil
+}
+
+type slidingWindow struct{}
+
+// SlidingWindow returns the default strategy, which drops the oldest unpinned turns until the
+// history fits.
+func SlidingWindow() HistoryStrategy {
+	return slidingWindow{}
+}
+
+func (slidingWindow) Fit(ctx context.Context, state HistoryState, fits func(HistoryState) bool) (HistoryState, error) {
+	for !fits(state) {
+		i := oldestUnpinned(state.Turns)
+		if i < 0 {
+			break
+		}
+		state.Turns = append(state.Turns[:i], state.Turns[i+1:]...)
+	}
+	return state, nil
+}
+
+// oldestUnpinned returns the index of the oldest turn that may be dropped, or -1.
+func oldestUnpinned(turns []HistoryTurn) int {
+	for i := 0; i < len(turns)-1; i++ {
+		if !turns[i].Pinned {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultSummaryPrompt is the system prompt SummarizeStrategy uses by default.
+const DefaultSummaryPrompt = "Summarize the conversation below for the assistant that continues it. " +
+	"Keep facts, decisions, names, numbers, and open questions. Reply with the summary only."
+
+// SummarizeStrategy replaces the oldest unpinned turns with a summary written by a model, extending
+// the previous summary. Create one with SummarizeOldest.
+type SummarizeStrategy struct {
+	Client *MistralClient
+	Model  string
+	// Prompt is the system prompt of the summary request, DefaultSummaryPrompt by default.
+	Prompt string
+	// Params, when set, are the parameters of the summary request, such as MaxTokens.
+	Params *ChatRequestParams
+}
+
+// SummarizeOldest returns a strategy summarizing dropped turns with model.
+func SummarizeOldest(client *MistralClient, model string) *SummarizeStrategy {
+	return &SummarizeStrategy{Client: client, Model: model, Prompt: DefaultSummaryPrompt}
+}
+
+// Fit drops the oldest unpinned turns until the history fits with the previous summary, and
+// summarizes them. While the new summary does not fit, one more turn is folded into it.
+func (s *SummarizeStrategy) Fit(ctx context.Context, state HistoryState, fits func(HistoryState) bool) (HistoryState, error) {
+	var dropped []HistoryTurn
+	drop := func() bool {
+		i := oldestUnpinned(state.Turns)
+		if i < 0 {
+			return false
+		}
+		dropped = append(dropped, state.Turns[i])
+		state.Turns = append(state.Turns[:i], state.Turns[i+1:]...)
+		return true
+	}
+	for !fits(state) {
+		if !drop() {
+			break
+		}
+	}
+	if len(dropped) == 0 {
+		return state, nil
+	}
+
+	previous := state.Summary
+	for {
+		summary, err := s.summarize(ctx, previous, dropped)
+		if err != nil {
+			return state, err
+		}
+		state.Summary = summary
+		if fits(state) || !drop() {
+			return state, nil
+		}
+	}
+}
+
+func (s *SummarizeStrategy) summarize(ctx context.Context, previous string, turns []HistoryTurn) (string, error) {
+	var transcript strings.Builder
+	if previous != "" {
+		transcript.WriteString("Summary so far:\n" + previous + "\n\n")
+	}
+	for _, turn := range turns {
+		for _, message := range turn.Messages {
+			transcript.WriteString(message.Role + ": " + message.Text())
+			for _, call := range message.ToolCalls {
+				transcript.WriteString(" [called " + call.Function.Name + "(" + call.Function.Arguments + ")]")
+			}
+			transcript.WriteString("\n")
+		}
+	}
+
+	prompt := s.Prompt
+	if prompt == "" {
+		prompt = DefaultSummaryPrompt
+	}
+	resp, err := s.Client.ChatCtx(ctx, s.Model, []ChatMessage{
+		{Role: RoleSystem, Content: prompt},
+		{Role: RoleUser, Content: transcript.String()},
+	}, s.Params)
+	if err != nil {
+		return "", fmt.Errorf("mistral: summarizing chat history: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("mistral: summarizing chat history: empty response")
+	}
+	return strings.TrimSpace(resp.Choices[0].Message.Text()), nil
+}
+
+// paramsTools returns the tools of params for token counting.
+func paramsTools(params *ChatRequestParams) []Tool {
+	if params == nil || params.Tools == nil {
+		return nil
+	}
+	if tools, ok := params.Tools.([]Tool); ok {
+		return tools
+	}
+	var tools []Tool
+	if data, err := json.Marshal(params.Tools); err == nil {
+		_ = json.Unmarshal(data, &tools)
+	}
+	return tools
+}
+
+// estimateMessageTokens is the default MessageCounter, with the estimate of the rate limiter.
+func estimateMessageTokens(messages []ChatMessage, tools []Tool) int {
+	var payload map[string]any
+	if data, err := json.Marshal(map[string]any{"messages": messages}); err == nil {
+		_ = json.Unmarshal(data, &payload)
+	}
+	tokens := estimatePromptTokens("", payload)
+	if len(tools) > 0 {
+		var decoded any
+		if data, err := json.Marshal(tools); err == nil {
+			_ = json.Unmarshal(data, &decoded)
+		}
+		tokens += int(math.Ceil(float64(textLength(decoded)) / 4))
+	}
+	return tokens
+}
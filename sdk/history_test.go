@@ -0,0 +1,200 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// countMessages counts one token per message, so budgets in tests are message counts.
+func countMessages(messages []ChatMessage, tools []Tool) int {
+	return len(messages) + len(tools)
+}
+
+func roles(messages []ChatMessage) []string {
+	var out []string
+	for _, message := range messages {
+		out = append(out, message.Role+":"+message.Text())
+	}
+	return out
+}
+
+func TestChatHistoryGroupsToolCallsIntoTurns(t *testing.T) {
+	history := NewChatHistory("sys")
+	history.Add(
+		ChatMessage{Role: RoleUser, Content: "weather?"},
+		ChatMessage{Role: RoleAssistant, ToolCalls: []ToolCall{toolCall("call-1", "get_weather", `{}`)}},
+		ChatMessage{Role: RoleTool, Content: "sunny", ToolCallID: "call-1"},
+		ChatMessage{Role: RoleAssistant, Content: "It is sunny."},
+		ChatMessage{Role: RoleSystem, Content: "be brief"},
+		ChatMessage{Role: RoleUser, Content: "thanks"},
+	)
+
+	turns := history.Turns()
+	if len(turns) != 2 || len(turns[0].Messages) != 4 || len(turns[1].Messages) != 1 {
+		t.Fatalf("turns = %+v", turns)
+	}
+	want := []string{"system:sys", "system:be brief", "user:weather?", "assistant:", "tool:sunny", "assistant:It is sunny.", "user:thanks"}
+	if got := roles(history.Messages()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages = %q, want %q", got, want)
+	}
+
+	if err := history.Pin(4); err != nil || !history.Turns()[0].Pinned {
+		t.Errorf("Pin(4) = %v, turn pinned = %v", err, history.Turns()[0].Pinned)
+	}
+	if err := history.Pin(7); err == nil {
+		t.Error("Pin(7) succeeded, want out of range")
+	}
+}
+
+func TestChatHistorySlidingWindowKeepsPinnedAndLatest(t *testing.T) {
+	history := NewChatHistory("sys", WithHistoryBudget(6), WithHistoryCounter(countMessages))
+	history.AddPinned(ChatMessage{Role: RoleUser, Content: "my name is Ada"}, ChatMessage{Role: RoleAssistant, Content: "hi Ada"})
+	for i := 1; i <= 3; i++ {
+		history.Add(ChatMessage{Role: RoleUser, Content: fmt.Sprint("q", i)}, ChatMessage{Role: RoleAssistant, Content: fmt.Sprint("a", i)})
+	}
+	history.Add(ChatMessage{Role: RoleUser, Content: "q4"})
+
+	if err := history.Fit(context.Background(), nil); err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	want := []string{"system:sys", "user:my name is Ada", "assistant:hi Ada", "user:q3", "assistant:a3", "user:q4"}
+	if got := roles(history.Messages()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages = %q, want %q", got, want)
+	}
+
+	// Tools count towards the budget; the pinned and last turns alone do not fit, and a failed
+	// Fit drops nothing.
+	tools := []Tool{{Type: ToolTypeFunction, Function: Function{Name: "f"}}, {Type: ToolTypeFunction, Function: Function{Name: "g"}}, {Type: ToolTypeFunction, Function: Function{Name: "h"}}}
+	if err := history.Fit(context.Background(), tools); !errors.Is(err, ErrHistoryOverBudget) {
+		t.Fatalf("Fit with tools = %v, want ErrHistoryOverBudget", err)
+	}
+	if got := roles(history.Messages()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages after a failed Fit = %q, want %q", got, want)
+	}
+}
+
+func TestChatHistorySummarizesOldestTurns(t *testing.T) {
+	var requests [][]ChatMessage
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []ChatMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req.Messages)
+		reply := "answer"
+		if req.Messages[0].Content == DefaultSummaryPrompt {
+			reply = fmt.Sprintf("summary %d", len(requests))
+		}
+		data, _ := json.Marshal(ChatCompletionResponse{Choices: []ChatCompletionResponseChoice{{Message: ChatMessage{Role: RoleAssistant, Content: reply}}}})
+		MockJSONResponse(http.StatusOK, string(data)).Write(w)
+	})
+	defer mock.Close()
+	client := mock.GetClient()
+
+	history := NewChatHistory("sys", WithHistoryBudget(5), WithHistoryCounter(countMessages), WithHistoryStrategy(SummarizeOldest(client, "mistral-small-latest")))
+	history.Add(
+		ChatMessage{Role: RoleUser, Content: "q1"}, ChatMessage{Role: RoleAssistant, Content: "a1"},
+		ChatMessage{Role: RoleUser, Content: "q2"}, ChatMessage{Role: RoleAssistant, Content: "a2"},
+		ChatMessage{Role: RoleUser, Content: "q3"},
+	)
+	if _, err := history.Chat(client, "mistral-large-latest", nil); err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want a summary and a chat", len(requests))
+	}
+	if got, want := requests[0][1].Content, "user: q1\nassistant: a1\n"; got != want {
+		t.Errorf("summary transcript = %q, want %q", got, want)
+	}
+	want := []string{"system:sys", "system:Summary of the earlier conversation:\nsummary 1", "user:q2", "assistant:a2", "user:q3"}
+	if got := roles(requests[1]); !reflect.DeepEqual(got, want) {
+		t.Errorf("chat messages = %q, want %q", got, want)
+	}
+	if got := roles(history.Messages()); !reflect.DeepEqual(got, append(want, "assistant:answer")) {
+		t.Errorf("history = %q", got)
+	}
+
+	// The next summary extends the previous one.
+	history.Add(ChatMessage{Role: RoleUser, Content: "q4"})
+	if _, err := history.Chat(client, "mistral-large-latest", nil); err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	if got, want := requests[2][1].Content, "Summary so far:\nsummary 1\n\nuser: q2\nassistant: a2\n"; got != want {
+		t.Errorf("second summary transcript = %q, want %q", got, want)
+	}
+	if history.Summary() != "summary 3" {
+		t.Errorf("Summary = %q", history.Summary())
+	}
+}
+
+func TestChatHistoryStreamAddsReply(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+			`{"id":"c1","model":"m","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+		} {
+			_, _ = io.WriteString(w, "data: "+chunk+"\n\n")
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	history := NewChatHistory("")
+	history.Add(ChatMessage{Role: RoleUser, Content: "hi"})
+	var streamed string
+	if _, err := history.ChatStream(mock.GetClient(), "m", nil, func(delta string) { streamed += delta }); err != nil {
+		t.Fatalf("ChatStream: %v", err)
+	}
+	if want := []string{"user:hi", "assistant:Hello"}; !reflect.DeepEqual(roles(history.Messages()), want) || streamed != "Hello" {
+		t.Errorf("Messages = %q, streamed %q", roles(history.Messages()), streamed)
+	}
+}
+
+func TestChatHistoryJSONRoundTrip(t *testing.T) {
+	history := NewChatHistory("sys")
+	history.AddPinned(ChatMessage{Role: RoleUser, Content: "remember this"})
+	history.Add(
+		ChatMessage{Role: RoleAssistant, ToolCalls: []ToolCall{toolCall("call-1", "note", `{"text":"x"}`)}},
+		ChatMessage{Role: RoleTool, Content: "saved", ToolCallID: "call-1"},
+	)
+	history.summary = "earlier"
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewChatHistory("", WithHistoryBudget(100))
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.Messages(), history.Messages()) || !reflect.DeepEqual(restored.Turns(), history.Turns()) {
+		t.Errorf("restored %+v, want %+v", restored.Turns(), history.Turns())
+	}
+	if restored.budget != 100 {
+		t.Errorf("options not kept: budget %d", restored.budget)
+	}
+}
+
+func TestEstimateMessageTokensCountsTools(t *testing.T) {
+	messages := []ChatMessage{{Role: RoleUser, Content: "12345678"}}
+	base := estimateMessageTokens(messages, nil)
+	if base < 3 {
+		t.Fatalf("estimate = %d", base)
+	}
+	tools := []Tool{{Type: ToolTypeFunction, Function: Function{Name: "lookup", Description: "Look something up"}}}
+	if estimateMessageTokens(messages, tools) <= base {
+		t.Error("tools did not add to the estimate")
+	}
+	params := &ChatRequestParams{Tools: []map[string]any{{"type": "function", "function": map[string]any{"name": "lookup"}}}}
+	if got := paramsTools(params); len(got) != 1 || got[0].Function.Name != "lookup" {
+		t.Errorf("paramsTools = %+v", got)
+	}
+}
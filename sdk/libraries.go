@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -54,19 +55,14 @@ type DeleteLibraryResponse struct {
 
 // ListLibraries lists all libraries
 func (c *MistralClient) ListLibraries() (*LibraryListResponse, error) {
-	response, err := c.request(http.MethodGet, nil, "v1/libraries", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.ListLibrariesCtx(context.Background())
+}
 
+// ListLibrariesCtx is like ListLibraries but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListLibrariesCtx(ctx context.Context) (*LibraryListResponse, error) {
+	ctx = withOperation(ctx, "libraries.list")
 	var listResponse LibraryListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, "v1/libraries", &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -75,6 +71,12 @@ func (c *MistralClient) ListLibraries() (*LibraryListResponse, error) {
 
 // CreateLibrary creates a new library
 func (c *MistralClient) CreateLibrary(req *CreateLibraryRequest) (*Library, error) {
+	return c.CreateLibraryCtx(context.Background(), req)
+}
+
+// CreateLibraryCtx is like CreateLibrary but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CreateLibraryCtx(ctx context.Context, req *CreateLibraryRequest) (*Library, error) {
+	ctx = withOperation(ctx, "libraries.create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -90,19 +92,8 @@ func (c *MistralClient) CreateLibrary(req *CreateLibraryRequest) (*Library, erro
 		reqMap["chunk_size"] = *req.ChunkSize
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/libraries", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var library Library
-	err = mapToStruct(respData, &library)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/libraries", &library); err != nil {
 		return nil, err
 	}
 
@@ -111,19 +102,14 @@ func (c *MistralClient) CreateLibrary(req *CreateLibraryRequest) (*Library, erro
 
 // GetLibrary retrieves a specific library
 func (c *MistralClient) GetLibrary(libraryID string) (*Library, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s", libraryID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetLibraryCtx(context.Background(), libraryID)
+}
 
+// GetLibraryCtx is like GetLibrary but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetLibraryCtx(ctx context.Context, libraryID string) (*Library, error) {
+	ctx = withOperation(ctx, "libraries.get")
 	var library Library
-	err = mapToStruct(respData, &library)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/libraries/%s", libraryID), &library); err != nil {
 		return nil, err
 	}
 
@@ -132,6 +118,12 @@ func (c *MistralClient) GetLibrary(libraryID string) (*Library, error) {
 
 // UpdateLibrary updates a library
 func (c *MistralClient) UpdateLibrary(libraryID string, req *UpdateLibraryRequest) (*Library, error) {
+	return c.UpdateLibraryCtx(context.Background(), libraryID, req)
+}
+
+// UpdateLibraryCtx is like UpdateLibrary but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateLibraryCtx(ctx context.Context, libraryID string, req *UpdateLibraryRequest) (*Library, error) {
+	ctx = withOperation(ctx, "libraries.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -145,19 +137,8 @@ func (c *MistralClient) UpdateLibrary(libraryID string, req *UpdateLibraryReques
 		reqMap["description"] = *req.Description
 	}
 
-	response, err := c.request(http.MethodPut, reqMap, fmt.Sprintf("v1/libraries/%s", libraryID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var library Library
-	err = mapToStruct(respData, &library)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPut, reqMap, fmt.Sprintf("v1/libraries/%s", libraryID), &library); err != nil {
 		return nil, err
 	}
 
@@ -166,24 +147,17 @@ func (c *MistralClient) UpdateLibrary(libraryID string, req *UpdateLibraryReques
 
 // DeleteLibrary deletes a library
 func (c *MistralClient) DeleteLibrary(libraryID string) (*DeleteLibraryResponse, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/libraries/%s", libraryID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	if len(respData) == 0 {
-		return nil, nil
-	}
+	return c.DeleteLibraryCtx(context.Background(), libraryID)
+}
 
-	var deleteResponse DeleteLibraryResponse
-	err = mapToStruct(respData, &deleteResponse)
-	if err != nil {
+// DeleteLibraryCtx is like DeleteLibrary but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteLibraryCtx(ctx context.Context, libraryID string) (*DeleteLibraryResponse, error) {
+	ctx = withOperation(ctx, "libraries.delete")
+	// The API may answer with an empty body, which leaves deleteResponse nil.
+	var deleteResponse *DeleteLibraryResponse
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/libraries/%s", libraryID), &deleteResponse); err != nil {
 		return nil, err
 	}
 
-	return &deleteResponse, nil
+	return deleteResponse, nil
 }
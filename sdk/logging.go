@@ -0,0 +1,277 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLevel mirrors the log/slog levels so a slog.Level converts directly.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+// Logger receives structured SDK log records. attrs are alternating keys and values, as in log/slog.
+// On Go 1.21 and newer, WithSlogLogger adapts a *slog.Logger.
+type Logger interface {
+	Enabled(ctx context.Context, level LogLevel) bool
+	Log(ctx context.Context, level LogLevel, msg string, attrs ...any)
+}
+
+// LogLevels sets the level of each kind of record. Request and response bodies are always logged at LogLevelDebug.
+type LogLevels struct {
+	Request LogLevel // Completed requests with status below 400; defaults to LogLevelInfo
+	Retry   LogLevel // Retry attempts; defaults to LogLevelWarn
+	Error   LogLevel // Failed requests and transport errors; defaults to LogLevelError
+}
+
+// DefaultLogLevels is used by WithLogger.
+var DefaultLogLevels = LogLevels{Request: LogLevelInfo, Retry: LogLevelWarn, Error: LogLevelError}
+
+// maxLoggedBody caps logged bodies; longer bodies are truncated.
+const maxLoggedBody = 4096
+
+// maxBufferedBody caps the JSON response bodies read to log their usage; larger bodies are passed
+// through unread.
+const maxBufferedBody = 1 << 20
+
+// redactedFields are JSON keys whose values are never logged.
+var redactedFields = map[string]bool{
+	"api_key":            true,
+	"apikey":             true,
+	"authorization":      true,
+	"auth_data":          true,
+	"credentials":        true,
+	"password":           true,
+	"secret":             true,
+	"client_secret":      true,
+	"access_token":       true,
+	"refresh_token":      true,
+	"connection_secrets": true,
+}
+
+// WithLogger records method, path, status, latency, retries, request ID and token usage for every call.
+// levels overrides DefaultLogLevels when given; its zero fields keep their default.
+func WithLogger(logger Logger, levels ...LogLevels) Option {
+	return func(c *MistralClient) {
+		c.logger = logger
+		c.logLevels = DefaultLogLevels
+		if len(levels) > 0 {
+			c.logLevels = levels[0].withDefaults()
+		}
+	}
+}
+
+// withDefaults fills the zero fields of l from DefaultLogLevels. LogLevelInfo is zero, so it can
+// only be chosen for Request, which defaults to it.
+func (l LogLevels) withDefaults() LogLevels {
+	if l.Retry == 0 {
+		l.Retry = DefaultLogLevels.Retry
+	}
+	if l.Error == 0 {
+		l.Error = DefaultLogLevels.Error
+	}
+	return l
+}
+
+// logMiddleware logs each HTTP attempt. It runs innermost, so latency excludes rate limiter waits.
+func (c *MistralClient) logMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		attrs := []any{"operation", OperationName(ctx), "method", req.Method, "path", req.URL.Path}
+		debug := c.logger.Enabled(ctx, LogLevelDebug)
+		if debug {
+			c.logger.Log(ctx, LogLevelDebug, "mistral request", append(attrs,
+				"headers", redactHeaders(req.Header),
+				"body", requestBodyForLog(req),
+			)...)
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		attrs = append(attrs, "latency", time.Since(start))
+		if err != nil {
+			c.logger.Log(ctx, c.logLevels.Error, "mistral request failed", append(attrs, "error", err)...)
+			return nil, err
+		}
+
+		attrs = append(attrs, "status", resp.StatusCode)
+		if requestID := resp.Header.Get("X-Request-Id"); requestID != "" {
+			attrs = append(attrs, "request_id", requestID)
+		}
+		level := c.logLevels.Request
+		if resp.StatusCode >= 400 {
+			level = c.logLevels.Error
+		}
+
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			// Usage arrives at the end of a stream, so the record is written when the body is closed.
+			resp.Body = &usageReader{ReadCloser: resp.Body, done: func(used int, ok bool) {
+				if ok {
+					attrs = append(attrs, "total_tokens", used)
+				}
+				c.logger.Log(ctx, level, "mistral stream finished", attrs...)
+			}}
+			return resp, nil
+		}
+
+		if !debug && !c.logger.Enabled(ctx, level) {
+			return resp, nil
+		}
+		contentType := resp.Header.Get("Content-Type")
+		if !strings.HasPrefix(contentType, "application/json") || resp.ContentLength > maxBufferedBody {
+			// Downloads and audio are not read, so logging does not hold them in memory.
+			c.logResponse(ctx, level, attrs, func() string { return "[" + contentType + " body omitted]" })
+			return resp, nil
+		}
+		body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxBufferedBody+1))
+		if readErr != nil {
+			resp.Body.Close()
+			c.logger.Log(ctx, c.logLevels.Error, "mistral response read failed", append(attrs, "error", readErr)...)
+			return nil, readErr
+		}
+		if len(body) > maxBufferedBody {
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			c.logResponse(ctx, level, attrs, func() string { return "[body over " + strconv.Itoa(maxBufferedBody) + " bytes omitted]" })
+			return resp, nil
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if used, ok := usageTokens(body); ok {
+			attrs = append(attrs, "total_tokens", used)
+		}
+		c.logResponse(ctx, level, attrs, func() string { return redactBody(body) })
+		return resp, nil
+	}
+}
+
+// logResponse writes the response record, and the body at debug level.
+func (c *MistralClient) logResponse(ctx context.Context, level LogLevel, attrs []any, body func() string) {
+	c.logger.Log(ctx, level, "mistral response", attrs...)
+	if c.logger.Enabled(ctx, LogLevelDebug) {
+		c.logger.Log(ctx, LogLevelDebug, "mistral response body", "operation", OperationName(ctx), "body", body())
+	}
+}
+
+// logRetry records a retry decision made by doWithRetry.
+func (c *MistralClient) logRetry(attempt *RetryAttempt, delay time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	ctx := attempt.Request.Context()
+	attrs := []any{
+		"operation", OperationName(ctx),
+		"method", attempt.Request.Method,
+		"path", attempt.Request.URL.Path,
+		"attempt", attempt.Attempt + 1,
+		"delay", delay,
+	}
+	if attempt.Response != nil {
+		attrs = append(attrs, "status", attempt.Response.StatusCode)
+	}
+	if attempt.Err != nil {
+		attrs = append(attrs, "error", attempt.Err)
+	}
+	c.logger.Log(ctx, c.logLevels.Retry, "mistral retrying request", attrs...)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if redactedFields[strings.ToLower(key)] || strings.EqualFold(key, "X-Api-Key") {
+			value = "[REDACTED]"
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func requestBodyForLog(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "[" + req.Header.Get("Content-Type") + " body omitted]"
+	}
+	body, err := RequestBody(req)
+	if err != nil {
+		return "[unreadable body]"
+	}
+	return redactBody(body)
+}
+
+// redactBody removes secrets and shortens base64 payloads in a JSON body, then truncates it for logging.
+func redactBody(body []byte) string {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if redacted, err := json.Marshal(redactValue("", payload)); err == nil {
+			body = redacted
+		}
+	}
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...[truncated]"
+	}
+	return string(body)
+}
+
+func redactValue(key string, value any) any {
+	if redactedFields[strings.ToLower(key)] && value != nil {
+		return "[REDACTED]"
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = redactValue(k, item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue(key, item)
+		}
+		return out
+	case string:
+		return shortenBinary(v)
+	}
+	return value
+}
+
+// shortenBinary replaces data URLs and long base64 strings with a size marker.
+func shortenBinary(s string) string {
+	if strings.HasPrefix(s, "data:") {
+		if comma := strings.Index(s, ","); comma >= 0 && comma < 128 {
+			return s[:comma+1] + "[" + strconv.Itoa(len(s)-comma-1) + " bytes]"
+		}
+	}
+	if len(s) >= 256 && looksBase64(s) {
+		return "[base64 " + strconv.Itoa(len(s)) + " bytes]"
+	}
+	return s
+}
+
+func looksBase64(s string) bool {
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		isBase64 := ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' ||
+			ch == '+' || ch == '/' || ch == '=' || ch == '-' || ch == '_' || ch == '\n' || ch == '\r'
+		if !isBase64 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+//go:build go1.21
+
+package sdk
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithSlogLogger logs through logger. See WithLogger for the records written and the levels used.
+func WithSlogLogger(logger *slog.Logger, levels ...LogLevels) Option {
+	return WithLogger(slogLogger{logger: logger}, levels...)
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return l.logger.Enabled(ctx, slog.Level(level))
+}
+
+func (l slogLogger) Log(ctx context.Context, level LogLevel, msg string, attrs ...any) {
+	l.logger.Log(ctx, slog.Level(level), msg, attrs...)
+}
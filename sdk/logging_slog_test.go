@@ -0,0 +1,35 @@
+//go:build go1.21
+
+package sdk
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockListModelsResponse().Write(w)
+	})
+	defer mock.Close()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client := NewClient(WithBaseURL(mock.Server.URL), WithSlogLogger(logger))
+	if _, err := client.ListModels(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logged := out.String()
+	for _, want := range []string{`"msg":"mistral response"`, `"operation":"models.list"`, `"status":200`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("expected %s in %s", want, logged)
+		}
+	}
+	if strings.Contains(logged, "mistral request") {
+		t.Errorf("expected no debug records at info level, got %s", logged)
+	}
+}
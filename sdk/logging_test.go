@@ -0,0 +1,268 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type logRecord struct {
+	level LogLevel
+	msg   string
+	attrs map[string]any
+}
+
+type captureLogger struct {
+	mu      sync.Mutex
+	min     LogLevel
+	records []logRecord
+}
+
+func (l *captureLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return level >= l.min
+}
+
+func (l *captureLogger) Log(ctx context.Context, level LogLevel, msg string, attrs ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record := logRecord{level: level, msg: msg, attrs: map[string]any{}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		record.attrs[fmt.Sprint(attrs[i])] = attrs[i+1]
+	}
+	l.records = append(l.records, record)
+}
+
+func (l *captureLogger) find(msg string) *logRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range l.records {
+		if l.records[i].msg == msg {
+			return &l.records[i]
+		}
+	}
+	return nil
+}
+
+func TestLoggerRecordsRequestsAndRetries(t *testing.T) {
+	attempts := 0
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Request-Id", "req-log")
+		if attempts == 1 {
+			MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	logger := &captureLogger{min: LogLevelInfo}
+	policy := NewDefaultRetryPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(policy), WithLogger(logger))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	retry := logger.find("mistral retrying request")
+	if retry == nil || retry.level != LogLevelWarn || retry.attrs["status"] != http.StatusServiceUnavailable {
+		t.Fatalf("expected a warn-level retry record, got %+v", retry)
+	}
+	var response *logRecord
+	for i := range logger.records {
+		if logger.records[i].msg == "mistral response" && logger.records[i].attrs["status"] == http.StatusOK {
+			response = &logger.records[i]
+		}
+	}
+	if response == nil {
+		t.Fatalf("expected a response record, got %+v", logger.records)
+	}
+	if response.level != LogLevelInfo || response.attrs["operation"] != "chat.completions" || response.attrs["path"] != "/v1/chat/completions" ||
+		response.attrs["request_id"] != "req-log" || response.attrs["total_tokens"] != 30 {
+		t.Errorf("unexpected response record %+v", response.attrs)
+	}
+	if _, ok := response.attrs["latency"].(time.Duration); !ok {
+		t.Errorf("expected latency to be recorded, got %+v", response.attrs)
+	}
+	if logger.find("mistral request") != nil {
+		t.Error("bodies must only be logged at debug level")
+	}
+}
+
+func TestLoggerKeepsDefaultLevelsForZeroFields(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusBadRequest, "bad").Write(w)
+	})
+	defer mock.Close()
+
+	logger := &captureLogger{min: LogLevelDebug}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithLogger(logger, LogLevels{Retry: LogLevelDebug}))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if response := logger.find("mistral response"); response == nil || response.level != LogLevelError {
+		t.Errorf("expected the failure at error level, got %+v", response)
+	}
+}
+
+func TestLoggerRedactsDebugBodies(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockFineTuningJobResponse().Write(w)
+	})
+	defer mock.Close()
+
+	logger := &captureLogger{min: LogLevelDebug}
+	client := NewClient(WithAPIKey("secret-key"), WithBaseURL(mock.Server.URL), WithLogger(logger))
+	apiKey := "wandb-secret"
+	_, err := client.CreateFineTuningJob(&CreateFineTuningJobRequest{
+		Model:         "open-mistral-7b",
+		TrainingFiles: []TrainingFile{{FileID: "file-1"}},
+		Integrations:  []interface{}{WandbIntegration{Type: "wandb", Project: "p", APIKey: &apiKey}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := logger.find("mistral request")
+	if request == nil {
+		t.Fatal("expected a debug request record")
+	}
+	body := fmt.Sprint(request.attrs["body"])
+	if strings.Contains(body, "wandb-secret") || !strings.Contains(body, "[REDACTED]") {
+		t.Errorf("expected api_key to be redacted, got %s", body)
+	}
+	headers := fmt.Sprint(request.attrs["headers"])
+	if strings.Contains(headers, "secret-key") {
+		t.Errorf("expected Authorization to be redacted, got %s", headers)
+	}
+	if logger.find("mistral response body") == nil {
+		t.Error("expected the response body at debug level")
+	}
+}
+
+func TestLoggerRedactsConnectorSecrets(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockJSONResponse(http.StatusOK, `{"id":"conn-1"}`).Write(w)
+	})
+	defer mock.Close()
+
+	logger := &captureLogger{min: LogLevelDebug}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithLogger(logger))
+	if _, err := client.CreateConnector(&ConnectorRequest{
+		Name:     "crm",
+		Headers:  map[string]any{"Authorization": "Bearer header-secret"},
+		AuthData: map[string]any{"client_secret": "auth-secret"},
+	}); err != nil {
+		t.Fatalf("CreateConnector: %v", err)
+	}
+	if _, err := client.UpdateConnector("conn-1", &UpdateConnectorRequest{
+		ConnectionConfig:  map[string]any{"region": "eu"},
+		ConnectionSecrets: map[string]any{"api_token": "connection-secret"},
+	}); err != nil {
+		t.Fatalf("UpdateConnector: %v", err)
+	}
+
+	var bodies []string
+	logger.mu.Lock()
+	for _, record := range logger.records {
+		if record.msg == "mistral request" {
+			bodies = append(bodies, fmt.Sprint(record.attrs["body"]))
+		}
+	}
+	logger.mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 request bodies, got %d", len(bodies))
+	}
+	logged := strings.Join(bodies, "\n")
+	for _, secret := range []string{"header-secret", "auth-secret", "connection-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be redacted from %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, `"region":"eu"`) {
+		t.Errorf("expected the connection config to be logged, got %s", logged)
+	}
+}
+
+func TestLoggerDoesNotBufferLargeOrBinaryBodies(t *testing.T) {
+	logger := &captureLogger{min: LogLevelDebug}
+	client := NewClient(WithLogger(logger))
+	large := `{"data":"` + strings.Repeat("a", maxBufferedBody) + `"}`
+	bodies := map[string]io.ReadCloser{
+		"audio/mpeg":       io.NopCloser(strings.NewReader("ID3 audio")),
+		"application/json": io.NopCloser(strings.NewReader(large)),
+	}
+	for contentType, body := range bodies {
+		handler := client.logMiddleware(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {contentType}}, Body: body, ContentLength: -1}, nil
+		})
+		req, _ := http.NewRequest(http.MethodPost, "https://api.mistral.ai/v1/audio/speech", nil)
+		resp, err := handler(req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", contentType, err)
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if contentType == "audio/mpeg" && (resp.Body != body || string(data) != "ID3 audio") {
+			t.Errorf("expected the audio body to be passed through unread, got %q", data)
+		}
+		if contentType == "application/json" && !bytes.Equal(data, []byte(large)) {
+			t.Errorf("expected the large body to be passed through whole, got %d bytes", len(data))
+		}
+	}
+
+	var logged []string
+	logger.mu.Lock()
+	for _, record := range logger.records {
+		if record.msg == "mistral response body" {
+			logged = append(logged, fmt.Sprint(record.attrs["body"]))
+		}
+	}
+	logger.mu.Unlock()
+	if len(logged) != 2 || !strings.Contains(strings.Join(logged, " "), "[audio/mpeg body omitted]") || !strings.Contains(strings.Join(logged, " "), "bytes omitted]") {
+		t.Errorf("expected both bodies to be omitted from the log, got %q", logged)
+	}
+}
+
+func TestLoggerReturnsBodyReadErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "1000")
+		_, _ = io.WriteString(w, `{"id":"cmpl`)
+	})
+	defer mock.Close()
+
+	logger := &captureLogger{min: LogLevelInfo}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithLogger(logger), WithMaxRetries(1))
+	var connErr *MistralConnectionError
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); !errors.As(err, &connErr) {
+		t.Errorf("expected the truncated body to fail the call as a connection error, got %v", err)
+	}
+	if record := logger.find("mistral response read failed"); record == nil || record.level != LogLevelError {
+		t.Errorf("expected an error-level read failure record, got %+v", record)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	image := "data:image/png;base64," + strings.Repeat("QUJD", 100)
+	audio := strings.Repeat("QUJD", 100)
+	body := []byte(`{"auth_data":{"token":"x"},"credentials":{"client_id":"a"},"messages":[{"content":[{"type":"image_url","image_url":"` + image + `"}]}],"input_audio":"` + audio + `","model":"m"}`)
+
+	got := redactBody(body)
+	for _, secret := range []string{`"token":"x"`, `"client_id"`, audio} {
+		if strings.Contains(got, secret) {
+			t.Errorf("expected %q to be redacted from %s", secret, got)
+		}
+	}
+	for _, kept := range []string{`"model":"m"`, `data:image/png;base64,[400 bytes]`, `[base64 400 bytes]`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("expected %q in %s", kept, got)
+		}
+	}
+}
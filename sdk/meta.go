@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response behind an SDK call. Pass one to a call with WithResponseMeta.
+type ResponseMeta struct {
+	StatusCode      int
+	Header          http.Header
+	RequestID       string        // x-request-id, quoted in support tickets
+	ModelVersion    string        // Model version header, when the API sends one
+	ServerTiming    string        // Raw Server-Timing header
+	UpstreamLatency time.Duration // Time the API gateway reports spending upstream; 0 when not reported
+	RateLimit       RateLimitInfo
+	Attempts        int           // HTTP attempts made, including retries
+	Latency         time.Duration // Time from the first attempt until the final response headers arrived
+}
+
+// RateLimitInfo holds the rate-limit headers of a response. Fields are nil when the header was not sent.
+type RateLimitInfo struct {
+	LimitRequests     *int
+	RemainingRequests *int
+	LimitTokens       *int
+	RemainingTokens   *int
+	RetryAfter        time.Duration // Delay requested by Retry-After or rate-limit reset headers
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that makes the call it is passed to fill meta once response
+// headers arrive. For streams, meta is filled before the stream is returned. If the context is reused
+// for several calls, meta describes the last one. meta must not be shared by concurrent calls.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponseMeta fills the ResponseMeta requested through ctx, if any. resp is nil when no response was received.
+func recordResponseMeta(ctx context.Context, resp *http.Response, attempts int, latency time.Duration) {
+	meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+	*meta = ResponseMeta{Attempts: attempts, Latency: latency}
+	if resp == nil {
+		return
+	}
+
+	header := resp.Header
+	meta.StatusCode = resp.StatusCode
+	meta.Header = header.Clone()
+	meta.RequestID = header.Get("X-Request-Id")
+	meta.ModelVersion = firstHeader(header, "X-Model-Version", "Mistral-Model-Version")
+	meta.ServerTiming = header.Get("Server-Timing")
+	if ms, ok := headerInt(header, "X-Kong-Upstream-Latency", "X-Envoy-Upstream-Service-Time"); ok {
+		meta.UpstreamLatency = time.Duration(ms) * time.Millisecond
+	}
+	meta.RateLimit = RateLimitInfo{
+		LimitRequests:     headerIntPtr(header, "X-Ratelimit-Limit-Requests", "X-Ratelimit-Limit-Req-Minute"),
+		RemainingRequests: headerIntPtr(header, "X-Ratelimit-Remaining-Requests", "X-Ratelimit-Remaining-Req-Minute"),
+		LimitTokens:       headerIntPtr(header, "X-Ratelimit-Limit-Tokens", "X-Ratelimit-Limit-Tokens-Minute", "X-Ratelimitbysize-Limit-Minute"),
+		RemainingTokens:   headerIntPtr(header, "X-Ratelimit-Remaining-Tokens", "X-Ratelimit-Remaining-Tokens-Minute", "X-Ratelimitbysize-Remaining-Minute"),
+	}
+	meta.RateLimit.RetryAfter, _ = retryAfter(header)
+}
+
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func headerIntPtr(header http.Header, names ...string) *int {
+	if value, ok := headerInt(header, names...); ok {
+		return &value
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseMetaOnChat(t *testing.T) {
+	attempts := 0
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-meta")
+		w.Header().Set("X-Ratelimit-Remaining-Requests", "0")
+		w.Header().Set("X-Ratelimitbysize-Limit-Minute", "500000")
+		w.Header().Set("X-Ratelimit-Reset", "2")
+		w.Header().Set("X-Kong-Upstream-Latency", "120")
+		w.Header().Set("Server-Timing", "inference;dur=118")
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	policy := NewDefaultRetryPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(policy))
+
+	var meta ResponseMeta
+	ctx := WithResponseMeta(context.Background(), &meta)
+	if _, err := client.ChatCtx(ctx, "mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-meta" || meta.Attempts != 2 || meta.Latency <= 0 {
+		t.Errorf("unexpected meta %+v", meta)
+	}
+	if meta.UpstreamLatency != 120*time.Millisecond || meta.ServerTiming != "inference;dur=118" || meta.Header.Get("X-Request-Id") != "req-meta" {
+		t.Errorf("unexpected timing meta %+v", meta)
+	}
+	limits := meta.RateLimit
+	if limits.RemainingRequests == nil || *limits.RemainingRequests != 0 || limits.LimitTokens == nil || *limits.LimitTokens != 500000 ||
+		limits.RemainingTokens != nil || limits.RetryAfter != 2*time.Second {
+		t.Errorf("unexpected rate limits %+v", limits)
+	}
+}
+
+func TestResponseMetaOnStreamsAndUploads(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-"+r.URL.Path)
+		if r.URL.Path == "/v1/files" {
+			MockFileUploadResponse().Write(w)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1))
+
+	var streamMeta ResponseMeta
+	stream, err := client.ChatStreamChanCtx(WithResponseMeta(context.Background(), &streamMeta), "mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if streamMeta.RequestID != "req-/v1/chat/completions" || streamMeta.Attempts != 1 {
+		t.Errorf("expected stream meta before the stream is read, got %+v", streamMeta)
+	}
+	for range stream {
+	}
+
+	var uploadMeta ResponseMeta
+	_, err = client.UploadFileCtx(WithResponseMeta(context.Background(), &uploadMeta), bytes.NewReader([]byte("{}")), "data.jsonl", FilePurposeFineTune)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uploadMeta.RequestID != "req-/v1/files" || uploadMeta.StatusCode != http.StatusOK {
+		t.Errorf("unexpected upload meta %+v", uploadMeta)
+	}
+}
+
+func TestResponseMetaOnErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-denied")
+		MockErrorResponse(http.StatusUnauthorized, "bad key").Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1))
+	var meta ResponseMeta
+	if _, err := client.ListModelsCtx(WithResponseMeta(context.Background(), &meta)); err == nil {
+		t.Fatal("expected error")
+	}
+	if meta.StatusCode != http.StatusUnauthorized || meta.RequestID != "req-denied" {
+		t.Errorf("expected meta for failed calls, got %+v", meta)
+	}
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// MetricLabels identifies the call a measurement belongs to.
+type MetricLabels struct {
+	Operation string // SDK operation, e.g. "chat.completions"
+	Model     string // Requested model; empty for calls without one
+}
+
+// Metrics receives the measurements of every API call. NoopMetrics is the default; register an
+// implementation with WithMetrics.
+type Metrics interface {
+	// RequestDone records a finished call. status is the final HTTP status, or 0 when no response was received.
+	RequestDone(labels MetricLabels, status int, err error, latency time.Duration)
+	// Retry records a retried attempt. status is the status that caused it, or 0 for transport errors.
+	Retry(labels MetricLabels, status int)
+	// TimeToFirstToken records the delay between the start of a streaming call and its first event.
+	TimeToFirstToken(labels MetricLabels, d time.Duration)
+	// InterTokenLatency records the gap between two consecutive events of a stream.
+	InterTokenLatency(labels MetricLabels, d time.Duration)
+	// Tokens records the prompt and completion tokens reported in UsageInfo.
+	Tokens(labels MetricLabels, prompt, completion int)
+}
+
+// NoopMetrics discards all measurements.
+type NoopMetrics struct{}
+
+func (NoopMetrics) RequestDone(MetricLabels, int, error, time.Duration) {}
+func (NoopMetrics) Retry(MetricLabels, int)                             {}
+func (NoopMetrics) TimeToFirstToken(MetricLabels, time.Duration)        {}
+func (NoopMetrics) InterTokenLatency(MetricLabels, time.Duration)       {}
+func (NoopMetrics) Tokens(MetricLabels, int, int)                       {}
+
+// WithMetrics reports request counts, latency, stream timings, token usage and retries to metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *MistralClient) {
+		c.metrics = metrics
+	}
+}
+
+// Metrics returns the client's metrics collector.
+func (c *MistralClient) Metrics() Metrics {
+	return c.metrics
+}
+
+// metricsObserver feeds Observer callbacks into a Metrics collector.
+type metricsObserver struct {
+	metrics Metrics
+}
+
+type streamClockKey struct{}
+
+// streamClock remembers when the previous event of a stream arrived.
+type streamClock struct {
+	last time.Time
+}
+
+func labelsOf(call *Call) MetricLabels {
+	return MetricLabels{Operation: call.Operation, Model: call.Model}
+}
+
+func (o metricsObserver) CallStart(ctx context.Context, call *Call) context.Context {
+	if !call.Stream {
+		return ctx
+	}
+	return context.WithValue(ctx, streamClockKey{}, &streamClock{})
+}
+
+func (o metricsObserver) CallRetry(ctx context.Context, call *Call, attempt *RetryAttempt, delay time.Duration) {
+	status := 0
+	if attempt.Response != nil {
+		status = attempt.Response.StatusCode
+	}
+	o.metrics.Retry(labelsOf(call), status)
+}
+
+func (o metricsObserver) CallChunk(ctx context.Context, call *Call, at time.Time) {
+	clock, ok := ctx.Value(streamClockKey{}).(*streamClock)
+	if !ok {
+		return
+	}
+	if clock.last.IsZero() {
+		o.metrics.TimeToFirstToken(labelsOf(call), at.Sub(call.Start))
+	} else {
+		o.metrics.InterTokenLatency(labelsOf(call), at.Sub(clock.last))
+	}
+	clock.last = at
+}
+
+func (o metricsObserver) CallEnd(ctx context.Context, call *Call, result *CallResult) {
+	labels := labelsOf(call)
+	o.metrics.RequestDone(labels, result.StatusCode, result.Err, result.Duration)
+	if result.Usage != nil {
+		o.metrics.Tokens(labels, result.Usage.PromptTokens, result.Usage.CompletionTokens)
+	}
+}
@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu         sync.Mutex
+	done       []int
+	retries    []int
+	ttft       int
+	interToken int
+	prompt     int
+	completion int
+	labels     []MetricLabels
+}
+
+func (m *recordingMetrics) RequestDone(labels MetricLabels, status int, err error, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.done = append(m.done, status)
+	m.labels = append(m.labels, labels)
+}
+
+func (m *recordingMetrics) Retry(labels MetricLabels, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, status)
+}
+
+func (m *recordingMetrics) TimeToFirstToken(labels MetricLabels, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ttft++
+}
+
+func (m *recordingMetrics) InterTokenLatency(labels MetricLabels, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.interToken++
+}
+
+func (m *recordingMetrics) Tokens(labels MetricLabels, prompt, completion int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prompt += prompt
+	m.completion += completion
+}
+
+func TestMetricsDefaultToNoop(t *testing.T) {
+	client := NewClient()
+	if _, ok := client.Metrics().(NoopMetrics); !ok {
+		t.Errorf("expected NoopMetrics by default, got %T", client.Metrics())
+	}
+	if len(client.observers) != 0 {
+		t.Errorf("expected no observers without metrics, got %d", len(client.observers))
+	}
+}
+
+func TestMetricsRecordRequestsRetriesAndTokens(t *testing.T) {
+	attempts := 0
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After-Ms", "1")
+			MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	metrics := &recordingMetrics{}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(3), WithMetrics(metrics))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(metrics.done) != 1 || metrics.done[0] != http.StatusOK {
+		t.Errorf("expected one successful request, got %v", metrics.done)
+	}
+	if len(metrics.retries) != 1 || metrics.retries[0] != http.StatusTooManyRequests {
+		t.Errorf("expected one retry after a 429, got %v", metrics.retries)
+	}
+	if metrics.prompt != 10 || metrics.completion != 20 {
+		t.Errorf("expected 10 prompt and 20 completion tokens, got %d and %d", metrics.prompt, metrics.completion)
+	}
+	if metrics.labels[0] != (MetricLabels{Operation: "chat.completions", Model: "mistral-small-latest"}) {
+		t.Errorf("unexpected labels %+v", metrics.labels[0])
+	}
+}
+
+func TestMetricsRecordStreamTimings(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, content := range []string{"a", "b", "c"} {
+			_, _ = io.WriteString(w, `data: {"id":"s-1","choices":[{"index":0,"delta":{"content":"`+content+`"}}]}`+"\n\n")
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	metrics := &recordingMetrics{}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1), WithMetrics(metrics))
+	stream, err := client.FIMStreamChan(&FIMRequestParams{Model: "codestral-latest", Prompt: "def"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range stream {
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.ttft != 1 || metrics.interToken != 2 {
+		t.Errorf("expected 1 time-to-first-token and 2 inter-token samples, got %d and %d", metrics.ttft, metrics.interToken)
+	}
+	if len(metrics.labels) != 1 || metrics.labels[0].Operation != "fim.completions.stream" {
+		t.Errorf("unexpected labels %+v", metrics.labels)
+	}
+}
@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// Handler sends a single HTTP request to the API.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to inspect or modify outgoing requests and incoming responses.
+//
+// Middleware runs once per HTTP attempt, inside the retry loop, for JSON calls, streams,
+// binary downloads, multipart uploads and the realtime websocket handshake.
+// Use OperationName(req.Context()) to find out which SDK call issued the request.
+type Middleware func(next Handler) Handler
+
+type operationKey struct{}
+
+// OperationName returns the SDK operation, such as "chat.completions" or "files.upload", carried by ctx.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
+
+// withOperation tags ctx with the operation name reported to middleware.
+func withOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// WithMiddleware appends middleware to the client's chain. The first middleware is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *MistralClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// RequestBody returns a copy of req's body without consuming it.
+func RequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	SetRequestBody(req, data)
+	return data, nil
+}
+
+// SetRequestBody replaces req's body with data, keeping ContentLength and GetBody consistent so retries still work.
+func SetRequestBody(req *http.Request, data []byte) {
+	req.ContentLength = int64(len(data))
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
+
+// chain wraps final with the client's middleware.
+func (c *MistralClient) chain(final Handler) Handler {
+	handler := final
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	return handler
+}
+
+// middlewareTransport runs the client's middleware around a RoundTripper, for requests
+// sent by libraries that take an *http.Client, such as the websocket dialer.
+type middlewareTransport struct {
+	client *MistralClient
+	next   http.RoundTripper
+}
+
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.client.chain(t.next.RoundTrip)(req)
+}
@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+func TestMiddlewareSeesOperationRequestAndResponse(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("expected tenant header from middleware, got %q", got)
+		}
+		if strings.HasPrefix(r.URL.Path, "/v1/files") {
+			MockFileUploadResponse().Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	var operations []string
+	var statuses []int
+	audit := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Tenant", "acme")
+			resp, err := next(req)
+			operations = append(operations, OperationName(req.Context()))
+			if resp != nil {
+				statuses = append(statuses, resp.StatusCode)
+			}
+			return resp, err
+		}
+	}
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMiddleware(audit))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.UploadFile(strings.NewReader("{}"), "data.jsonl", FilePurposeBatch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(operations, ",") != "chat.completions,files.upload" {
+		t.Errorf("unexpected operations %v", operations)
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusOK || statuses[1] != http.StatusOK {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+}
+
+func TestMiddlewareOrderAndPayloadMutation(t *testing.T) {
+	var bodies []map[string]any
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.Unmarshal([]byte(ReadRequestBody(r)), &body)
+		bodies = append(bodies, body)
+		if len(bodies) == 1 {
+			MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+	safePrompt := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			data, err := RequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			var payload map[string]any
+			if json.Unmarshal(data, &payload) == nil {
+				payload["safe_prompt"] = true
+				data, _ = json.Marshal(payload)
+				SetRequestBody(req, data)
+			}
+			return next(req)
+		}
+	}
+
+	policy := NewDefaultRetryPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	client := NewClient(
+		WithBaseURL(mock.Server.URL),
+		WithRetryPolicy(policy),
+		WithMiddleware(trace("outer"), safePrompt),
+		WithMiddleware(trace("inner")),
+	)
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(order, ",") != "outer,inner,outer,inner" {
+		t.Errorf("expected middleware to run in order once per attempt, got %v", order)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body["safe_prompt"] != true || body["model"] != "mistral-small-latest" {
+			t.Errorf("attempt %d: expected mutated payload, got %v", i, body)
+		}
+	}
+}
+
+func TestMiddlewareWrapsRealtimeDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Signature"); got != "signed" {
+			t.Errorf("expected signature header from middleware, got %q", got)
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept websocket: %v", err)
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		_ = conn.Write(r.Context(), websocket.MessageText, []byte(`{"type":"session.created","session":{"request_id":"req-1","model":"voxtral"}}`))
+	}))
+	defer server.Close()
+
+	var operation string
+	sign := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			operation = OperationName(req.Context())
+			req.Header.Set("X-Signature", "signed")
+			return next(req)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := NewClient(WithAPIKey("key"), WithBaseURL(server.URL), WithMiddleware(sign))
+	conn, err := client.RealtimeTranscriptionConnect(ctx, "voxtral", nil)
+	if err != nil {
+		t.Fatalf("RealtimeTranscriptionConnect failed: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	if operation != "audio.realtime.transcriptions" {
+		t.Errorf("unexpected operation %q", operation)
+	}
+}
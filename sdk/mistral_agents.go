@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -74,6 +75,12 @@ type AgentAliasResponse struct {
 
 // CreateMistralAgent creates a new Mistral agent
 func (c *MistralClient) CreateMistralAgent(req *CreateMistralAgentRequest) (*MistralAgent, error) {
+	return c.CreateMistralAgentCtx(context.Background(), req)
+}
+
+// CreateMistralAgentCtx is like CreateMistralAgent but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CreateMistralAgentCtx(ctx context.Context, req *CreateMistralAgentRequest) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -107,19 +114,8 @@ func (c *MistralClient) CreateMistralAgent(req *CreateMistralAgentRequest) (*Mis
 		reqMap["version_message"] = *req.VersionMessage
 	}
 
-	response, err := c.request(http.MethodPost, reqMap, "v1/agents", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var agent MistralAgent
-	err = mapToStruct(respData, &agent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/agents", &agent); err != nil {
 		return nil, err
 	}
 
@@ -128,11 +124,23 @@ func (c *MistralClient) CreateMistralAgent(req *CreateMistralAgentRequest) (*Mis
 
 // ListMistralAgents lists all Mistral agents
 func (c *MistralClient) ListMistralAgents(page int) (*MistralAgentListResponse, error) {
-	return c.ListMistralAgentsWithParams(&ListMistralAgentsParams{Page: &page})
+	return c.ListMistralAgentsCtx(context.Background(), page)
+}
+
+// ListMistralAgentsCtx is like ListMistralAgents but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListMistralAgentsCtx(ctx context.Context, page int) (*MistralAgentListResponse, error) {
+	ctx = withOperation(ctx, "beta.agents.list")
+	return c.ListMistralAgentsWithParamsCtx(ctx, &ListMistralAgentsParams{Page: &page})
 }
 
 // ListMistralAgentsWithParams lists all Mistral agents with filters.
 func (c *MistralClient) ListMistralAgentsWithParams(params *ListMistralAgentsParams) (*MistralAgentListResponse, error) {
+	return c.ListMistralAgentsWithParamsCtx(context.Background(), params)
+}
+
+// ListMistralAgentsWithParamsCtx is like ListMistralAgentsWithParams but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListMistralAgentsWithParamsCtx(ctx context.Context, params *ListMistralAgentsParams) (*MistralAgentListResponse, error) {
+	ctx = withOperation(ctx, "beta.agents.list")
 	if params == nil {
 		params = &ListMistralAgentsParams{}
 	}
@@ -165,19 +173,8 @@ func (c *MistralClient) ListMistralAgentsWithParams(params *ListMistralAgentsPar
 		path += "?" + query.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var listResponse MistralAgentListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -186,29 +183,30 @@ func (c *MistralClient) ListMistralAgentsWithParams(params *ListMistralAgentsPar
 
 // GetMistralAgent retrieves a specific Mistral agent
 func (c *MistralClient) GetMistralAgent(agentID string) (*MistralAgent, error) {
-	return c.GetMistralAgentWithVersion(agentID, nil)
+	return c.GetMistralAgentCtx(context.Background(), agentID)
+}
+
+// GetMistralAgentCtx is like GetMistralAgent but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetMistralAgentCtx(ctx context.Context, agentID string) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.get")
+	return c.GetMistralAgentWithVersionCtx(ctx, agentID, nil)
 }
 
 // GetMistralAgentWithVersion retrieves a specific Mistral agent and optional version/alias.
 func (c *MistralClient) GetMistralAgentWithVersion(agentID string, agentVersion *string) (*MistralAgent, error) {
+	return c.GetMistralAgentWithVersionCtx(context.Background(), agentID, agentVersion)
+}
+
+// GetMistralAgentWithVersionCtx is like GetMistralAgentWithVersion but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetMistralAgentWithVersionCtx(ctx context.Context, agentID string, agentVersion *string) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.get")
 	path := fmt.Sprintf("v1/agents/%s", agentID)
 	if agentVersion != nil {
 		path += "?" + url.Values{"agent_version": []string{*agentVersion}}.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var agent MistralAgent
-	err = mapToStruct(respData, &agent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &agent); err != nil {
 		return nil, err
 	}
 
@@ -217,6 +215,12 @@ func (c *MistralClient) GetMistralAgentWithVersion(agentID string, agentVersion
 
 // UpdateMistralAgent updates a Mistral agent
 func (c *MistralClient) UpdateMistralAgent(agentID string, req *UpdateMistralAgentRequest) (*MistralAgent, error) {
+	return c.UpdateMistralAgentCtx(context.Background(), agentID, req)
+}
+
+// UpdateMistralAgentCtx is like UpdateMistralAgent but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateMistralAgentCtx(ctx context.Context, agentID string, req *UpdateMistralAgentRequest) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -254,19 +258,8 @@ func (c *MistralClient) UpdateMistralAgent(agentID string, req *UpdateMistralAge
 		reqMap["version_message"] = *req.VersionMessage
 	}
 
-	response, err := c.request(http.MethodPatch, reqMap, fmt.Sprintf("v1/agents/%s", agentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var agent MistralAgent
-	err = mapToStruct(respData, &agent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPatch, reqMap, fmt.Sprintf("v1/agents/%s", agentID), &agent); err != nil {
 		return nil, err
 	}
 
@@ -280,25 +273,25 @@ func (c *MistralClient) UpdateMistralAgent(agentID string, req *UpdateMistralAge
 //
 // Returns an error if the deletion fails
 func (c *MistralClient) DeleteMistralAgent(agentID string) error {
-	_, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/agents/%s", agentID), false, nil)
-	return err
+	return c.DeleteMistralAgentCtx(context.Background(), agentID)
+}
+
+// DeleteMistralAgentCtx is like DeleteMistralAgent but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteMistralAgentCtx(ctx context.Context, agentID string) error {
+	ctx = withOperation(ctx, "beta.agents.delete")
+	return c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/agents/%s", agentID), nil)
 }
 
 // UpdateMistralAgentVersion switches the active version for an agent.
 func (c *MistralClient) UpdateMistralAgentVersion(agentID string, version int) (*MistralAgent, error) {
-	response, err := c.request(http.MethodPatch, map[string]interface{}{"version": version}, fmt.Sprintf("v1/agents/%s/version", agentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.UpdateMistralAgentVersionCtx(context.Background(), agentID, version)
+}
 
+// UpdateMistralAgentVersionCtx is like UpdateMistralAgentVersion but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateMistralAgentVersionCtx(ctx context.Context, agentID string, version int) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.update_version")
 	var agent MistralAgent
-	err = mapToStruct(respData, &agent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPatch, map[string]interface{}{"version": version}, fmt.Sprintf("v1/agents/%s/version", agentID), &agent); err != nil {
 		return nil, err
 	}
 
@@ -307,6 +300,12 @@ func (c *MistralClient) UpdateMistralAgentVersion(agentID string, version int) (
 
 // ListMistralAgentVersions lists available versions for an agent.
 func (c *MistralClient) ListMistralAgentVersions(agentID string, page, pageSize *int) (*MistralAgentListResponse, error) {
+	return c.ListMistralAgentVersionsCtx(context.Background(), agentID, page, pageSize)
+}
+
+// ListMistralAgentVersionsCtx is like ListMistralAgentVersions but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListMistralAgentVersionsCtx(ctx context.Context, agentID string, page, pageSize *int) (*MistralAgentListResponse, error) {
+	ctx = withOperation(ctx, "beta.agents.list_versions")
 	query := url.Values{}
 	if page != nil {
 		query.Add("page", fmt.Sprintf("%d", *page))
@@ -320,19 +319,8 @@ func (c *MistralClient) ListMistralAgentVersions(agentID string, page, pageSize
 		path += "?" + query.Encode()
 	}
 
-	response, err := c.request(http.MethodGet, nil, path, false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var listResponse MistralAgentListResponse
-	err = mapToStruct(respData, &listResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, path, &listResponse); err != nil {
 		return nil, err
 	}
 
@@ -341,19 +329,14 @@ func (c *MistralClient) ListMistralAgentVersions(agentID string, page, pageSize
 
 // GetMistralAgentVersion retrieves a specific version for an agent.
 func (c *MistralClient) GetMistralAgentVersion(agentID, version string) (*MistralAgent, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/agents/%s/versions/%s", agentID, version), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.GetMistralAgentVersionCtx(context.Background(), agentID, version)
+}
 
+// GetMistralAgentVersionCtx is like GetMistralAgentVersion but uses ctx for cancellation and deadlines.
+func (c *MistralClient) GetMistralAgentVersionCtx(ctx context.Context, agentID, version string) (*MistralAgent, error) {
+	ctx = withOperation(ctx, "beta.agents.get_version")
 	var agent MistralAgent
-	err = mapToStruct(respData, &agent)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/agents/%s/versions/%s", agentID, version), &agent); err != nil {
 		return nil, err
 	}
 
@@ -362,19 +345,14 @@ func (c *MistralClient) GetMistralAgentVersion(agentID, version string) (*Mistra
 
 // CreateOrUpdateMistralAgentAlias creates/updates a version alias.
 func (c *MistralClient) CreateOrUpdateMistralAgentAlias(agentID, alias string, version int) (*AgentAliasResponse, error) {
-	response, err := c.request(http.MethodPut, map[string]interface{}{"alias": alias, "version": version}, fmt.Sprintf("v1/agents/%s/aliases", agentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.CreateOrUpdateMistralAgentAliasCtx(context.Background(), agentID, alias, version)
+}
 
+// CreateOrUpdateMistralAgentAliasCtx is like CreateOrUpdateMistralAgentAlias but uses ctx for cancellation and deadlines.
+func (c *MistralClient) CreateOrUpdateMistralAgentAliasCtx(ctx context.Context, agentID, alias string, version int) (*AgentAliasResponse, error) {
+	ctx = withOperation(ctx, "beta.agents.create_or_update_alias")
 	var aliasResp AgentAliasResponse
-	err = mapToStruct(respData, &aliasResp)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPut, map[string]interface{}{"alias": alias, "version": version}, fmt.Sprintf("v1/agents/%s/aliases", agentID), &aliasResp); err != nil {
 		return nil, err
 	}
 
@@ -383,39 +361,33 @@ func (c *MistralClient) CreateOrUpdateMistralAgentAlias(agentID, alias string, v
 
 // ListMistralAgentAliases lists aliases for an agent.
 func (c *MistralClient) ListMistralAgentAliases(agentID string) ([]AgentAliasResponse, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/agents/%s/aliases", agentID), false, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.ListMistralAgentAliasesCtx(context.Background(), agentID)
+}
 
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
+// ListMistralAgentAliasesCtx is like ListMistralAgentAliases but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ListMistralAgentAliasesCtx(ctx context.Context, agentID string) ([]AgentAliasResponse, error) {
+	ctx = withOperation(ctx, "beta.agents.list_aliases")
+	var aliasList struct {
+		Data *[]AgentAliasResponse `json:"data"`
 	}
-
-	rawData, ok := respData["data"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid alias list response format")
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/agents/%s/aliases", agentID), &aliasList); err != nil {
+		return nil, err
 	}
-
-	aliases := make([]AgentAliasResponse, 0, len(rawData))
-	for _, item := range rawData {
-		itemMap, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		var aliasResp AgentAliasResponse
-		if err := mapToStruct(itemMap, &aliasResp); err == nil {
-			aliases = append(aliases, aliasResp)
-		}
+	if aliasList.Data == nil {
+		return nil, fmt.Errorf("invalid alias list response format")
 	}
 
-	return aliases, nil
+	return *aliasList.Data, nil
 }
 
 // DeleteMistralAgentAlias deletes an alias for an agent.
 func (c *MistralClient) DeleteMistralAgentAlias(agentID, alias string) error {
+	return c.DeleteMistralAgentAliasCtx(context.Background(), agentID, alias)
+}
+
+// DeleteMistralAgentAliasCtx is like DeleteMistralAgentAlias but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteMistralAgentAliasCtx(ctx context.Context, agentID, alias string) error {
+	ctx = withOperation(ctx, "beta.agents.delete_alias")
 	path := fmt.Sprintf("v1/agents/%s/aliases?%s", agentID, url.Values{"alias": []string{alias}}.Encode())
-	_, err := c.request(http.MethodDelete, nil, path, false, nil)
-	return err
+	return c.requestInto(ctx, http.MethodDelete, nil, path, nil)
 }
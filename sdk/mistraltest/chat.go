@@ -0,0 +1,317 @@
+package mistraltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+// ChatRequest is a completion request as seen by a Responder. Chat, FIM and agent completions are
+// decoded into it; conversation requests are converted, with the conversation so far as Messages.
+type ChatRequest struct {
+	Model          string            `json:"model"`
+	AgentID        string            `json:"agent_id,omitempty"`
+	Messages       []sdk.ChatMessage `json:"messages"`
+	Tools          []sdk.Tool        `json:"tools,omitempty"`
+	ToolChoice     any               `json:"tool_choice,omitempty"`
+	ResponseFormat map[string]any    `json:"response_format,omitempty"`
+	Stream         bool              `json:"stream,omitempty"`
+	Prompt         string            `json:"prompt,omitempty"` // FIM only
+	Suffix         string            `json:"suffix,omitempty"` // FIM only
+}
+
+// Reply is the assistant message produced for a completion request.
+type Reply struct {
+	Content      string
+	ToolCalls    []sdk.ToolCall
+	FinishReason sdk.FinishReason // Defaults to tool_calls when ToolCalls is set and stop otherwise
+}
+
+// Responder produces the reply to a completion request. It is called without the server lock held.
+type Responder func(req *ChatRequest) Reply
+
+// DefaultResponder is the Responder used unless WithResponder is given:
+//   - FIM requests get the prompt back;
+//   - a user message with tools available, and tool_choice other than "none", gets a call to the
+//     requested tool, or the first one, with "{}" as arguments;
+//   - a tool result gets "Tool result: " followed by the result;
+//   - in JSON mode the last message is returned as {"echo": "..."};
+//   - anything else gets the last message back.
+func DefaultResponder(req *ChatRequest) Reply {
+	if req.Prompt != "" {
+		return Reply{Content: req.Prompt}
+	}
+	if len(req.Messages) == 0 {
+		return Reply{}
+	}
+	last := req.Messages[len(req.Messages)-1]
+	if last.Role == sdk.RoleTool {
+		return Reply{Content: "Tool result: " + last.Content}
+	}
+	if last.Role == sdk.RoleUser {
+		if name := chosenTool(req); name != "" {
+			return Reply{ToolCalls: []sdk.ToolCall{{
+				Id:       fmt.Sprintf("call_%d", len(req.Messages)),
+				Type:     sdk.ToolTypeFunction,
+				Function: sdk.FunctionCall{Name: name, Arguments: "{}"},
+			}}}
+		}
+	}
+	if format, _ := req.ResponseFormat["type"].(string); format == "json_object" || format == "json_schema" {
+		content, _ := json.Marshal(map[string]string{"echo": last.Content})
+		return Reply{Content: string(content)}
+	}
+	return Reply{Content: last.Content}
+}
+
+// chosenTool returns the function the model should call for req, or "" when it should answer.
+func chosenTool(req *ChatRequest) string {
+	switch choice := req.ToolChoice.(type) {
+	case string:
+		if choice == sdk.ToolChoiceNone {
+			return ""
+		}
+	case map[string]any:
+		if function, ok := choice["function"].(map[string]any); ok {
+			if name, _ := function["name"].(string); name != "" {
+				return name
+			}
+		}
+	}
+	for _, tool := range req.Tools {
+		if tool.Type == sdk.ToolTypeFunction && tool.Function.Name != "" {
+			return tool.Function.Name
+		}
+	}
+	return ""
+}
+
+// EnqueueReply scripts the replies to the next completion requests, in order, ahead of the Responder.
+// Chat, FIM, agent and conversation requests all consume them.
+func (s *Server) EnqueueReply(replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies = append(s.replies, replies...)
+}
+
+func (s *Server) reply(req *ChatRequest) Reply {
+	s.mu.Lock()
+	var reply Reply
+	if len(s.replies) > 0 {
+		reply = s.replies[0]
+		s.replies = s.replies[1:]
+		s.mu.Unlock()
+	} else {
+		responder := s.responder
+		s.mu.Unlock()
+		reply = responder(req)
+	}
+	if reply.FinishReason == "" {
+		reply.FinishReason = sdk.FinishReasonStop
+		if len(reply.ToolCalls) > 0 {
+			reply.FinishReason = sdk.FinishReasonToolCalls
+		}
+	}
+	return reply
+}
+
+// usage counts whitespace separated words as tokens.
+func usage(req *ChatRequest, reply Reply) sdk.UsageInfo {
+	prompt := countTokens(req.Prompt) + countTokens(req.Suffix)
+	for _, msg := range req.Messages {
+		prompt += countTokens(msg.Content)
+	}
+	if prompt == 0 {
+		prompt = 1
+	}
+	completion := countTokens(reply.Content) + len(reply.ToolCalls)
+	return sdk.UsageInfo{PromptTokens: prompt, CompletionTokens: completion, TotalTokens: prompt + completion}
+}
+
+func countTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+// words splits text into stream deltas that concatenate back to text.
+func words(text string) []string {
+	var out []string
+	for _, word := range strings.SplitAfter(text, " ") {
+		if word != "" {
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+func (s *Server) handleChat(x *exchange) {
+	var req ChatRequest
+	if !x.decode(&req) {
+		return
+	}
+	if len(req.Messages) == 0 {
+		x.error(http.StatusBadRequest, "messages must not be empty")
+		return
+	}
+	if !s.checkModel(x, req.Model) {
+		return
+	}
+	s.complete(x, &req)
+}
+
+func (s *Server) handleFIM(x *exchange) {
+	var req ChatRequest
+	if !x.decode(&req) {
+		return
+	}
+	if req.Prompt == "" {
+		x.error(http.StatusBadRequest, "prompt is required")
+		return
+	}
+	if !s.checkModel(x, req.Model) {
+		return
+	}
+	s.complete(x, &req)
+}
+
+func (s *Server) checkModel(x *exchange, model string) bool {
+	s.mu.Lock()
+	_, ok := s.models.get(model)
+	s.mu.Unlock()
+	if !ok {
+		x.error(http.StatusBadRequest, fmt.Sprintf("invalid model: %s", model))
+	}
+	return ok
+}
+
+type streamChunk struct {
+	ID      string         `json:"id"`
+	Object  string         `json:"object"`
+	Created int64          `json:"created"`
+	Model   string         `json:"model"`
+	Choices []streamChoice `json:"choices"`
+	Usage   *sdk.UsageInfo `json:"usage,omitempty"`
+}
+
+type streamChoice struct {
+	Index        int               `json:"index"`
+	Delta        streamDelta       `json:"delta"`
+	FinishReason *sdk.FinishReason `json:"finish_reason"`
+}
+
+type streamDelta struct {
+	Role      string         `json:"role,omitempty"`
+	Content   string         `json:"content"`
+	ToolCalls []sdk.ToolCall `json:"tool_calls,omitempty"`
+}
+
+// complete answers a chat, FIM or agent completion request.
+func (s *Server) complete(x *exchange, req *ChatRequest) {
+	reply := s.reply(req)
+	used := usage(req, reply)
+	s.mu.Lock()
+	id := s.nextID("cmpl")
+	s.mu.Unlock()
+
+	if !req.Stream {
+		x.json(http.StatusOK, sdk.ChatCompletionResponse{
+			ID:      id,
+			Object:  "chat.completion",
+			Created: int(now()),
+			Model:   req.Model,
+			Choices: []sdk.ChatCompletionResponseChoice{{
+				Message:      sdk.ChatMessage{Role: sdk.RoleAssistant, Content: reply.Content, ToolCalls: reply.ToolCalls},
+				FinishReason: reply.FinishReason,
+			}},
+			Usage: used,
+		})
+		return
+	}
+
+	created := now()
+	chunk := func(delta streamDelta) streamChunk {
+		return streamChunk{ID: id, Object: "chat.completion.chunk", Created: created, Model: req.Model, Choices: []streamChoice{{Delta: delta}}}
+	}
+	events := []any{chunk(streamDelta{Role: sdk.RoleAssistant})}
+	for _, word := range words(reply.Content) {
+		events = append(events, chunk(streamDelta{Content: word}))
+	}
+	if len(reply.ToolCalls) > 0 {
+		events = append(events, chunk(streamDelta{ToolCalls: reply.ToolCalls}))
+	}
+	last := chunk(streamDelta{})
+	last.Choices[0].FinishReason = &reply.FinishReason
+	last.Usage = &used
+	x.stream(append(events, last))
+}
+
+func (s *Server) handleEmbeddings(x *exchange) {
+	var req struct {
+		Model           string `json:"model"`
+		Input           any    `json:"input"`
+		OutputDimension *int   `json:"output_dimension"`
+	}
+	if !x.decode(&req) {
+		return
+	}
+	var inputs []string
+	switch input := req.Input.(type) {
+	case string:
+		inputs = []string{input}
+	case []any:
+		for _, item := range input {
+			text, ok := item.(string)
+			if !ok {
+				x.error(http.StatusBadRequest, "input must be a string or a list of strings")
+				return
+			}
+			inputs = append(inputs, text)
+		}
+	}
+	if len(inputs) == 0 {
+		x.error(http.StatusBadRequest, "input must not be empty")
+		return
+	}
+	if !s.checkModel(x, req.Model) {
+		return
+	}
+
+	dimensions := s.embeddingDimensions
+	if req.OutputDimension != nil && *req.OutputDimension > 0 {
+		dimensions = *req.OutputDimension
+	}
+	resp := sdk.EmbeddingResponse{Object: "list", Model: req.Model}
+	for i, text := range inputs {
+		resp.Data = append(resp.Data, sdk.EmbeddingObject{Object: "embedding", Embedding: Embedding(text, dimensions), Index: i})
+		resp.Usage.PromptTokens += countTokens(text)
+	}
+	resp.Usage.TotalTokens = resp.Usage.PromptTokens
+	s.mu.Lock()
+	resp.ID = s.nextID("embd")
+	s.mu.Unlock()
+	x.json(http.StatusOK, resp)
+}
+
+// Embedding returns the unit vector the server produces for text. The same text always maps to the
+// same vector, so tests can compute expected similarities.
+func Embedding(text string, dimensions int) []float64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(text))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+	vector := make([]float64, dimensions)
+	var norm float64
+	for i := range vector {
+		vector[i] = rng.Float64()*2 - 1
+		norm += vector[i] * vector[i]
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
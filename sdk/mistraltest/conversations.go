@@ -0,0 +1,409 @@
+package mistraltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+type conversation struct {
+	id           string
+	model        string
+	agentID      string
+	instructions string
+	tools        []sdk.Tool
+	created      int64
+	entries      []entry
+}
+
+// entry is a conversation entry in the shape the Conversations API returns it.
+type entry struct {
+	ID         string `json:"id"`
+	Object     string `json:"object"`
+	Type       string `json:"type"`
+	Role       string `json:"role,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Model      string `json:"model,omitempty"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Arguments  string `json:"arguments,omitempty"`
+	Result     string `json:"result,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+type conversationRequest struct {
+	Inputs       json.RawMessage `json:"inputs"`
+	Model        string          `json:"model"`
+	AgentID      string          `json:"agent_id"`
+	Instructions string          `json:"instructions"`
+	Tools        []sdk.Tool      `json:"tools"`
+	Stream       bool            `json:"stream"`
+	Store        *bool           `json:"store"`
+	FromEntryID  string          `json:"from_entry_id"`
+}
+
+// inputs converts the inputs of req, a string or a list of entries, to entries; s.mu must be held.
+func (s *Server) inputs(req *conversationRequest) ([]entry, error) {
+	var text string
+	if json.Unmarshal(req.Inputs, &text) == nil {
+		return []entry{s.newEntry(entry{Type: "message.input", Role: sdk.RoleUser, Content: text})}, nil
+	}
+	var raw []struct {
+		Type       string `json:"type"`
+		Role       string `json:"role"`
+		Content    any    `json:"content"`
+		ToolCallID string `json:"tool_call_id"`
+		Result     string `json:"result"`
+	}
+	if err := json.Unmarshal(req.Inputs, &raw); err != nil {
+		return nil, fmt.Errorf("inputs must be a string or a list of entries: %w", err)
+	}
+	var out []entry
+	for _, input := range raw {
+		if input.Type == "function.result" {
+			out = append(out, s.newEntry(entry{Type: input.Type, ToolCallID: input.ToolCallID, Result: input.Result}))
+			continue
+		}
+		content, ok := input.Content.(string)
+		if !ok {
+			data, _ := json.Marshal(input.Content)
+			content = string(data)
+		}
+		role := input.Role
+		if role == "" {
+			role = sdk.RoleUser
+		}
+		out = append(out, s.newEntry(entry{Type: "message.input", Role: role, Content: content}))
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("inputs must not be empty")
+	}
+	return out, nil
+}
+
+// newEntry fills in the identity of e; s.mu must be held.
+func (s *Server) newEntry(e entry) entry {
+	e.ID = s.nextID("entry")
+	e.Object = "entry"
+	e.CreatedAt = now()
+	e.Timestamp = e.CreatedAt
+	return e
+}
+
+// messages converts the conversation to chat messages, with its instructions as the system message.
+func (c *conversation) messages() []sdk.ChatMessage {
+	var out []sdk.ChatMessage
+	if c.instructions != "" {
+		out = append(out, sdk.SystemMessage(c.instructions))
+	}
+	for _, e := range c.entries {
+		switch e.Type {
+		case "message.input", "message.output":
+			out = append(out, sdk.ChatMessage{Role: e.Role, Content: e.Content})
+		case "function.call":
+			out = append(out, sdk.ChatMessage{Role: sdk.RoleAssistant, ToolCalls: []sdk.ToolCall{{
+				Id:       e.ToolCallID,
+				Type:     sdk.ToolTypeFunction,
+				Function: sdk.FunctionCall{Name: e.Name, Arguments: e.Arguments},
+			}}})
+		case "function.result":
+			out = append(out, sdk.ChatMessage{Role: sdk.RoleTool, ToolCallID: e.ToolCallID, Content: e.Result})
+		}
+	}
+	return out
+}
+
+func (s *Server) handleConversations(x *exchange, rest []string) {
+	var req conversationRequest
+	if x.r.Method == http.MethodPost && !x.decode(&req) {
+		return
+	}
+
+	s.mu.Lock()
+	var conv *conversation
+	store := false
+	switch {
+	case x.is(http.MethodPost, rest):
+		conv = &conversation{id: s.nextID("conv"), model: req.Model, instructions: req.Instructions, tools: req.Tools, created: now()}
+		if req.AgentID != "" {
+			agent, ok := s.agents.get(req.AgentID)
+			if !ok {
+				s.mu.Unlock()
+				x.missing("agent", req.AgentID)
+				return
+			}
+			conv.agentID, conv.model = agent.ID, agent.Model
+			if agent.Instructions != nil && conv.instructions == "" {
+				conv.instructions = *agent.Instructions
+			}
+			if len(conv.tools) == 0 {
+				conv.tools = agent.Tools
+			}
+		}
+		if _, ok := s.models.get(conv.model); !ok {
+			s.mu.Unlock()
+			x.error(http.StatusBadRequest, fmt.Sprintf("invalid model: %s", conv.model))
+			return
+		}
+		store = req.Store == nil || *req.Store
+	case x.is(http.MethodGet, rest):
+		defer s.mu.Unlock()
+		data := []any{}
+		for _, c := range page(s.conversations.list(), x.r.URL.Query()) {
+			data = append(data, c.summary())
+		}
+		x.json(http.StatusOK, map[string]any{"object": "list", "data": data, "total": len(s.conversations.ids)})
+		return
+	case len(rest) == 0:
+		s.mu.Unlock()
+		x.notFound()
+		return
+	default:
+		existing, ok := s.conversations.get(rest[0])
+		if !ok {
+			s.mu.Unlock()
+			x.missing("conversation", rest[0])
+			return
+		}
+		switch {
+		case x.is(http.MethodPost, rest[1:]):
+			conv = existing
+		case x.is(http.MethodPost, rest[1:], "restart"):
+			conv = &conversation{id: s.nextID("conv"), model: existing.model, agentID: existing.agentID,
+				instructions: existing.instructions, tools: existing.tools, created: now()}
+			found := req.FromEntryID == ""
+			for _, e := range existing.entries {
+				conv.entries = append(conv.entries, e)
+				if e.ID == req.FromEntryID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				s.mu.Unlock()
+				x.missing("entry", req.FromEntryID)
+				return
+			}
+			store = true
+		case x.is(http.MethodDelete, rest[1:]):
+			s.conversations.delete(existing.id)
+			s.mu.Unlock()
+			x.w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			defer s.mu.Unlock()
+			existing.serve(x, rest[1:])
+			return
+		}
+	}
+
+	entries, err := s.inputs(&req)
+	if err != nil {
+		s.mu.Unlock()
+		x.error(http.StatusBadRequest, err.Error())
+		return
+	}
+	conv.entries = append(conv.entries, entries...)
+	if store {
+		s.conversations.put(conv.id, conv)
+	}
+	chat := &ChatRequest{Model: conv.model, AgentID: conv.agentID, Messages: conv.messages(), Tools: conv.tools}
+	s.mu.Unlock()
+
+	s.converse(x, conv, chat, req.Stream)
+}
+
+// serve answers the read-only requests for a stored conversation; s.mu must be held.
+func (c *conversation) serve(x *exchange, rest []string) {
+	switch {
+	case x.is(http.MethodGet, rest):
+		x.json(http.StatusOK, c.summary())
+	case x.is(http.MethodGet, rest, "history"):
+		entries := append([]entry{}, c.entries...)
+		x.json(http.StatusOK, map[string]any{"object": "conversation.history", "conversation_id": c.id, "entries": entries})
+	case x.is(http.MethodGet, rest, "messages"):
+		var messages []sdk.ChatMessage
+		for _, msg := range c.messages() {
+			if msg.Role != sdk.RoleSystem {
+				messages = append(messages, msg)
+			}
+		}
+		x.json(http.StatusOK, map[string]any{"object": "conversation.messages", "conversation_id": c.id, "messages": messages})
+	default:
+		x.notFound()
+	}
+}
+
+func (c *conversation) summary() map[string]any {
+	out := map[string]any{"id": c.id, "conversation_id": c.id, "object": "conversation", "model": c.model, "created": c.created}
+	if c.agentID != "" {
+		out["agent_id"] = c.agentID
+	}
+	return out
+}
+
+// converse runs a conversation turn and answers with its outputs.
+func (s *Server) converse(x *exchange, conv *conversation, chat *ChatRequest, stream bool) {
+	reply := s.reply(chat)
+	used := usage(chat, reply)
+
+	s.mu.Lock()
+	var outputs []entry
+	if len(reply.ToolCalls) == 0 {
+		outputs = append(outputs, s.newEntry(entry{Type: "message.output", Role: sdk.RoleAssistant, Content: reply.Content, Model: conv.model}))
+	}
+	for _, call := range reply.ToolCalls {
+		outputs = append(outputs, s.newEntry(entry{Type: "function.call", ToolCallID: call.Id, Name: call.Function.Name, Arguments: call.Function.Arguments}))
+	}
+	conv.entries = append(conv.entries, outputs...)
+	s.mu.Unlock()
+
+	if !stream {
+		x.json(http.StatusOK, map[string]any{
+			"conversation_id": conv.id,
+			"object":          "conversation.response",
+			"created":         now(),
+			"status":          "completed",
+			"outputs":         outputs,
+			"usage":           used,
+		})
+		return
+	}
+
+	events := []any{map[string]any{"type": "conversation.response.started", "conversation_id": conv.id, "created_at": now()}}
+	for i, output := range outputs {
+		if output.Type == "function.call" {
+			events = append(events, map[string]any{"type": "function.call.delta", "output_index": i, "id": output.ID,
+				"tool_call_id": output.ToolCallID, "name": output.Name, "arguments": output.Arguments})
+			continue
+		}
+		for _, word := range words(output.Content) {
+			events = append(events, map[string]any{"type": "message.output.delta", "output_index": i, "id": output.ID,
+				"role": sdk.RoleAssistant, "model": output.Model, "content": word})
+		}
+	}
+	events = append(events, map[string]any{"type": "conversation.response.done", "usage": used})
+	x.stream(events)
+}
+
+func (s *Server) handleAgents(x *exchange, rest []string) {
+	if x.is(http.MethodPost, rest, "completions") {
+		s.agentCompletion(x)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case x.is(http.MethodPost, rest):
+		var req sdk.CreateMistralAgentRequest
+		if !x.decode(&req) {
+			return
+		}
+		if _, ok := s.models.get(req.Model); !ok {
+			x.error(http.StatusBadRequest, fmt.Sprintf("invalid model: %s", req.Model))
+			return
+		}
+		agent := &sdk.MistralAgent{
+			ID:           s.nextID("agent"),
+			Object:       "agent",
+			Model:        req.Model,
+			Name:         req.Name,
+			Description:  req.Description,
+			Instructions: req.Instructions,
+			Tools:        req.Tools,
+			Metadata:     req.Metadata,
+			Created:      now(),
+		}
+		agent.Updated = agent.Created
+		s.agents.put(agent.ID, agent)
+		x.json(http.StatusOK, agent)
+		return
+	case x.is(http.MethodGet, rest):
+		query := x.r.URL.Query()
+		var matched []*sdk.MistralAgent
+		for _, agent := range s.agents.list() {
+			if name := query.Get("name"); name != "" && (agent.Name == nil || *agent.Name != name) {
+				continue
+			}
+			matched = append(matched, agent)
+		}
+		out := sdk.MistralAgentListResponse{Object: "list", Data: []sdk.MistralAgent{}, Total: len(matched)}
+		for _, agent := range page(matched, query) {
+			out.Data = append(out.Data, *agent)
+		}
+		x.json(http.StatusOK, out)
+		return
+	case len(rest) == 0:
+		x.notFound()
+		return
+	}
+
+	agent, ok := s.agents.get(rest[0])
+	if !ok {
+		x.missing("agent", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		x.json(http.StatusOK, agent)
+	case x.is(http.MethodPatch, rest[1:]):
+		var req sdk.UpdateMistralAgentRequest
+		if !x.decode(&req) {
+			return
+		}
+		if req.Model != nil {
+			agent.Model = *req.Model
+		}
+		if req.Name != nil {
+			agent.Name = req.Name
+		}
+		if req.Description != nil {
+			agent.Description = req.Description
+		}
+		if req.Instructions != nil {
+			agent.Instructions = req.Instructions
+		}
+		if req.Tools != nil {
+			agent.Tools = req.Tools
+		}
+		if req.Metadata != nil {
+			agent.Metadata = req.Metadata
+		}
+		agent.Updated = now()
+		x.json(http.StatusOK, agent)
+	case x.is(http.MethodDelete, rest[1:]):
+		s.agents.delete(agent.ID)
+		x.w.WriteHeader(http.StatusNoContent)
+	default:
+		x.notFound()
+	}
+}
+
+// agentCompletion answers /v1/agents/completions with the agent's model, instructions and tools.
+func (s *Server) agentCompletion(x *exchange) {
+	var req ChatRequest
+	if !x.decode(&req) {
+		return
+	}
+	s.mu.Lock()
+	agent, ok := s.agents.get(req.AgentID)
+	if ok {
+		req.Model = agent.Model
+		if agent.Instructions != nil {
+			req.Messages = append([]sdk.ChatMessage{sdk.SystemMessage(*agent.Instructions)}, req.Messages...)
+		}
+		if len(req.Tools) == 0 {
+			req.Tools = agent.Tools
+		}
+	}
+	s.mu.Unlock()
+	if !ok {
+		x.missing("agent", req.AgentID)
+		return
+	}
+	s.complete(x, &req)
+}
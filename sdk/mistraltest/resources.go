@@ -0,0 +1,729 @@
+package mistraltest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+// Processing statuses of library documents. Documents are reported as processed from the first
+// retrieval after the upload.
+const (
+	DocumentStatusRunning   = "Running"
+	DocumentStatusCompleted = "Completed"
+)
+
+// jobStatusValidated is the status of fine-tuning jobs created with auto_start false until they are started.
+const jobStatusValidated sdk.JobStatus = "VALIDATED"
+
+type file struct {
+	sdk.FileSchema
+	content []byte
+}
+
+type batchJob struct {
+	sdk.BatchJobOut
+	output  []byte
+	results []json.RawMessage
+}
+
+type fineTuningJob struct {
+	sdk.JobOut
+}
+
+type document struct {
+	sdk.Document
+	content []byte
+}
+
+func (s *Server) handleModels(x *exchange, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case x.is(http.MethodGet, rest):
+		list := sdk.ModelList{Object: "list", Data: []sdk.ModelCard{}}
+		for _, model := range s.models.list() {
+			list.Data = append(list.Data, *model)
+		}
+		x.json(http.StatusOK, list)
+	case len(rest) == 1 && x.r.Method == http.MethodGet:
+		model, ok := s.models.get(rest[0])
+		if !ok {
+			x.missing("model", rest[0])
+			return
+		}
+		x.json(http.StatusOK, model)
+	case len(rest) == 1 && x.r.Method == http.MethodDelete:
+		if !s.models.delete(rest[0]) {
+			x.missing("model", rest[0])
+			return
+		}
+		x.json(http.StatusOK, sdk.DeleteModelResponse{ID: rest[0], Object: "model", Deleted: true})
+	default:
+		x.notFound()
+	}
+}
+
+// readUpload returns the "file" part of a multipart request and the form.
+func (x *exchange) readUpload() (name string, content []byte, form map[string][]string, ok bool) {
+	if err := x.r.ParseMultipartForm(32 << 20); err != nil {
+		x.error(http.StatusBadRequest, "invalid multipart body: "+err.Error())
+		return "", nil, nil, false
+	}
+	part, header, err := x.r.FormFile("file")
+	if err != nil {
+		x.error(http.StatusBadRequest, "file is required")
+		return "", nil, nil, false
+	}
+	defer part.Close()
+	content, err = io.ReadAll(part)
+	if err != nil {
+		x.error(http.StatusBadRequest, err.Error())
+		return "", nil, nil, false
+	}
+	return header.Filename, content, x.r.MultipartForm.Value, true
+}
+
+func (s *Server) handleFiles(x *exchange, rest []string) {
+	if x.is(http.MethodPost, rest) {
+		name, content, form, ok := x.readUpload()
+		if !ok {
+			return
+		}
+		purpose := sdk.FilePurposeFineTune
+		if values := form["purpose"]; len(values) > 0 && values[0] != "" {
+			purpose = sdk.FilePurpose(values[0])
+		}
+		s.mu.Lock()
+		f := s.addFile(name, purpose, content)
+		s.mu.Unlock()
+		x.json(http.StatusOK, f.FileSchema)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if x.is(http.MethodGet, rest) {
+		purpose := x.r.URL.Query().Get("purpose")
+		var matched []*file
+		for _, f := range s.files.list() {
+			if purpose == "" || string(f.Purpose) == purpose {
+				matched = append(matched, f)
+			}
+		}
+		total := len(matched)
+		out := sdk.ListFilesOut{Object: "list", Data: []sdk.FileSchema{}, Total: &total}
+		for _, f := range page(matched, x.r.URL.Query()) {
+			out.Data = append(out.Data, f.FileSchema)
+		}
+		x.json(http.StatusOK, out)
+		return
+	}
+	if len(rest) == 0 {
+		x.notFound()
+		return
+	}
+	f, ok := s.files.get(rest[0])
+	if !ok {
+		x.missing("file", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		x.json(http.StatusOK, f.FileSchema)
+	case x.is(http.MethodDelete, rest[1:]):
+		s.files.delete(f.ID)
+		x.json(http.StatusOK, sdk.DeleteFileOut{ID: f.ID, Object: "file", Deleted: true})
+	case x.is(http.MethodGet, rest[1:], "content"):
+		x.w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = x.w.Write(f.content)
+	case x.is(http.MethodGet, rest[1:], "url"):
+		x.json(http.StatusOK, sdk.FileSignedURL{URL: s.signedURL("files", f.ID)})
+	default:
+		x.notFound()
+	}
+}
+
+// addFile stores an uploaded file; s.mu must be held.
+func (s *Server) addFile(name string, purpose sdk.FilePurpose, content []byte) *file {
+	f := &file{
+		FileSchema: sdk.FileSchema{
+			ID:        s.nextID("file"),
+			Object:    "file",
+			Bytes:     int64(len(content)),
+			CreatedAt: now(),
+			Filename:  name,
+			Purpose:   purpose,
+		},
+		content: content,
+	}
+	if strings.HasSuffix(name, ".jsonl") {
+		lines := bytes.Count(bytes.TrimSpace(content), []byte("\n")) + 1
+		f.NumLines = &lines
+	}
+	s.files.put(f.ID, f)
+	return f
+}
+
+// signedURL returns a URL on the server that serves the content of a file or document without authentication.
+func (s *Server) signedURL(kind, id string) string {
+	return fmt.Sprintf("%s/signed/%s/%s", s.URL, kind, id)
+}
+
+func (s *Server) handleSigned(x *exchange, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if x.r.Method != http.MethodGet || len(rest) != 2 {
+		x.notFound()
+		return
+	}
+	var content []byte
+	switch rest[0] {
+	case "files":
+		f, ok := s.files.get(rest[1])
+		if !ok {
+			x.missing("file", rest[1])
+			return
+		}
+		content = f.content
+	case "documents":
+		doc, ok := s.documents.get(rest[1])
+		if !ok {
+			x.missing("document", rest[1])
+			return
+		}
+		content = doc.content
+	default:
+		x.notFound()
+		return
+	}
+	x.w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = x.w.Write(content)
+}
+
+type batchLine struct {
+	CustomID string          `json:"custom_id"`
+	Body     json.RawMessage `json:"body"`
+}
+
+func (s *Server) handleBatchJobs(x *exchange, rest []string) {
+	if x.is(http.MethodPost, rest) {
+		s.createBatchJob(x)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if x.is(http.MethodGet, rest) {
+		query := x.r.URL.Query()
+		var matched []*batchJob
+		for _, job := range s.batchJobs.list() {
+			if statuses := query["status"]; len(statuses) > 0 && !contains(statuses, string(job.Status)) {
+				continue
+			}
+			if model := query.Get("model"); model != "" && (job.Model == nil || *job.Model != model) {
+				continue
+			}
+			matched = append(matched, job)
+		}
+		out := sdk.BatchJobsOut{Object: "list", Data: []sdk.BatchJobOut{}, Total: len(matched)}
+		for _, job := range page(matched, query) {
+			out.Data = append(out.Data, job.BatchJobOut)
+		}
+		x.json(http.StatusOK, out)
+		return
+	}
+	if len(rest) == 0 {
+		x.notFound()
+		return
+	}
+	job, ok := s.batchJobs.get(rest[0])
+	if !ok {
+		x.missing("batch job", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		s.advanceBatchJob(job)
+		if x.r.URL.Query().Get("inline") == "true" && job.Status == sdk.BatchJobStatusSuccess {
+			x.json(http.StatusOK, struct {
+				sdk.BatchJobOut
+				Outputs []json.RawMessage `json:"outputs"`
+			}{job.BatchJobOut, job.results})
+			return
+		}
+		x.json(http.StatusOK, job.BatchJobOut)
+	case x.is(http.MethodPost, rest[1:], "cancel"):
+		if job.Status == sdk.BatchJobStatusQueued || job.Status == sdk.BatchJobStatusRunning {
+			job.Status = sdk.BatchJobStatusCancelled
+		}
+		x.json(http.StatusOK, job.BatchJobOut)
+	case x.is(http.MethodDelete, rest[1:]):
+		s.batchJobs.delete(job.ID)
+		x.json(http.StatusOK, sdk.DeleteBatchJobResponse{ID: job.ID, Object: "batch", Deleted: true})
+	default:
+		x.notFound()
+	}
+}
+
+// createBatchJob runs the requests of a new batch job right away and keeps the results until
+// the job is reported as finished.
+func (s *Server) createBatchJob(x *exchange) {
+	var req struct {
+		InputFiles   []string          `json:"input_files"`
+		Requests     []batchLine       `json:"requests"`
+		Endpoint     sdk.BatchEndpoint `json:"endpoint"`
+		Model        *string           `json:"model"`
+		AgentID      *string           `json:"agent_id"`
+		TimeoutHours *int              `json:"timeout_hours"`
+	}
+	if !x.decode(&req) {
+		return
+	}
+	if req.Endpoint == "" {
+		x.error(http.StatusBadRequest, "endpoint is required")
+		return
+	}
+
+	lines := req.Requests
+	s.mu.Lock()
+	for _, id := range req.InputFiles {
+		f, ok := s.files.get(id)
+		if !ok {
+			s.mu.Unlock()
+			x.missing("file", id)
+			return
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(f.content))
+		scanner.Buffer(nil, len(f.content)+1)
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var line batchLine
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				s.mu.Unlock()
+				x.error(http.StatusBadRequest, fmt.Sprintf("invalid line in %s: %v", id, err))
+				return
+			}
+			lines = append(lines, line)
+		}
+	}
+	job := &batchJob{BatchJobOut: sdk.BatchJobOut{
+		ID:           s.nextID("batch"),
+		Object:       "batch",
+		Endpoint:     req.Endpoint,
+		InputFiles:   append([]string{}, req.InputFiles...),
+		CreatedAt:    now(),
+		Status:       sdk.BatchJobStatusQueued,
+		Model:        req.Model,
+		TimeoutHours: req.TimeoutHours,
+	}}
+	s.mu.Unlock()
+
+	var output bytes.Buffer
+	succeeded := 0
+	for i, line := range lines {
+		status, body := s.runBatchLine(job, line)
+		if status == http.StatusOK {
+			succeeded++
+		}
+		result, _ := json.Marshal(map[string]any{
+			"id":        fmt.Sprintf("%s-%d", job.ID, i),
+			"custom_id": line.CustomID,
+			"response":  map[string]any{"status_code": status, "body": body},
+		})
+		job.results = append(job.results, result)
+		output.Write(result)
+		output.WriteByte('\n')
+	}
+	total, failed := len(lines), len(lines)-succeeded
+	job.Metadata = &sdk.BatchJobMetadata{TotalRequests: &total, SucceededRequests: &succeeded, FailedRequests: &failed}
+	job.output = output.Bytes()
+
+	s.mu.Lock()
+	s.batchJobs.put(job.ID, job)
+	out := job.BatchJobOut
+	s.mu.Unlock()
+	x.json(http.StatusOK, out)
+}
+
+func (s *Server) runBatchLine(job *batchJob, line batchLine) (int, any) {
+	switch job.Endpoint {
+	case sdk.BatchEndpointChat, sdk.BatchEndpointFIM:
+		var req ChatRequest
+		if err := json.Unmarshal(line.Body, &req); err != nil {
+			return http.StatusBadRequest, map[string]any{"message": err.Error()}
+		}
+		if req.Model == "" && job.Model != nil {
+			req.Model = *job.Model
+		}
+		reply := s.reply(&req)
+		return http.StatusOK, sdk.ChatCompletionResponse{
+			ID:      fmt.Sprintf("%s-%s", job.ID, line.CustomID),
+			Object:  "chat.completion",
+			Created: int(now()),
+			Model:   req.Model,
+			Choices: []sdk.ChatCompletionResponseChoice{{
+				Message:      sdk.ChatMessage{Role: sdk.RoleAssistant, Content: reply.Content, ToolCalls: reply.ToolCalls},
+				FinishReason: reply.FinishReason,
+			}},
+			Usage: usage(&req, reply),
+		}
+	case sdk.BatchEndpointEmbeddings:
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.Unmarshal(line.Body, &req); err != nil {
+			return http.StatusBadRequest, map[string]any{"message": err.Error()}
+		}
+		resp := sdk.EmbeddingResponse{Object: "list"}
+		if job.Model != nil {
+			resp.Model = *job.Model
+		}
+		for i, text := range req.Input {
+			resp.Data = append(resp.Data, sdk.EmbeddingObject{Object: "embedding", Embedding: Embedding(text, s.embeddingDimensions), Index: i})
+		}
+		return http.StatusOK, resp
+	default:
+		return http.StatusBadRequest, map[string]any{"message": fmt.Sprintf("endpoint %s is not supported by mistraltest", job.Endpoint)}
+	}
+}
+
+// advanceBatchJob moves a job one step from QUEUED through RUNNING to SUCCESS, publishing its
+// output file at the end; s.mu must be held.
+func (s *Server) advanceBatchJob(job *batchJob) {
+	switch job.Status {
+	case sdk.BatchJobStatusQueued:
+		started := now()
+		job.Status, job.StartedAt = sdk.BatchJobStatusRunning, &started
+	case sdk.BatchJobStatusRunning:
+		completed := now()
+		output := s.addFile(job.ID+".jsonl", sdk.FilePurposeBatch, job.output)
+		job.Status, job.CompletedAt, job.OutputFile = sdk.BatchJobStatusSuccess, &completed, &output.ID
+	}
+}
+
+func (s *Server) handleFineTuningJobs(x *exchange, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case x.is(http.MethodPost, rest):
+		s.createFineTuningJob(x)
+		return
+	case x.is(http.MethodGet, rest):
+		query := x.r.URL.Query()
+		var matched []*fineTuningJob
+		for _, job := range s.fineTuning.list() {
+			if model := query.Get("model"); model != "" && job.Model != model {
+				continue
+			}
+			if status := query.Get("status"); status != "" && string(job.Status) != status {
+				continue
+			}
+			matched = append(matched, job)
+		}
+		out := sdk.JobsOut{Object: "list", Data: []sdk.JobOut{}, Total: len(matched)}
+		for _, job := range page(matched, query) {
+			out.Data = append(out.Data, job.JobOut)
+		}
+		x.json(http.StatusOK, out)
+		return
+	case len(rest) == 0:
+		x.notFound()
+		return
+	}
+
+	job, ok := s.fineTuning.get(rest[0])
+	if !ok {
+		x.missing("fine-tuning job", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		s.advanceFineTuningJob(job)
+	case x.is(http.MethodPost, rest[1:], "cancel"):
+		switch job.Status {
+		case sdk.JobStatusSuccess, sdk.JobStatusFailed, sdk.JobStatusCancelled:
+		default:
+			job.Status = sdk.JobStatusCancelled
+		}
+	case x.is(http.MethodPost, rest[1:], "start"):
+		if job.Status != jobStatusValidated {
+			x.error(http.StatusBadRequest, fmt.Sprintf("job %s cannot be started in status %s", job.ID, job.Status))
+			return
+		}
+		job.Status = sdk.JobStatusQueued
+	default:
+		x.notFound()
+		return
+	}
+	job.ModifiedAt = now()
+	x.json(http.StatusOK, job.JobOut)
+}
+
+// createFineTuningJob validates and stores a job; s.mu must be held.
+func (s *Server) createFineTuningJob(x *exchange) {
+	var req struct {
+		Model           string                     `json:"model"`
+		TrainingFiles   []sdk.TrainingFile         `json:"training_files"`
+		ValidationFiles []string                   `json:"validation_files"`
+		Hyperparameters sdk.Hyperparameters        `json:"hyperparameters"`
+		Suffix          *string                    `json:"suffix"`
+		AutoStart       *bool                      `json:"auto_start"`
+		JobType         *sdk.FineTuneableModelType `json:"job_type"`
+	}
+	if !x.decode(&req) {
+		return
+	}
+	if _, ok := s.models.get(req.Model); !ok {
+		x.error(http.StatusBadRequest, fmt.Sprintf("invalid model: %s", req.Model))
+		return
+	}
+	job := &fineTuningJob{JobOut: sdk.JobOut{
+		ID:              s.nextID("ftjob"),
+		Object:          "job",
+		Model:           req.Model,
+		Status:          sdk.JobStatusQueued,
+		Hyperparameters: req.Hyperparameters,
+		Suffix:          req.Suffix,
+		AutoStart:       req.AutoStart,
+		JobType:         req.JobType,
+		TrainingFiles:   []string{},
+		ValidationFiles: req.ValidationFiles,
+		CreatedAt:       now(),
+	}}
+	for _, training := range req.TrainingFiles {
+		if _, ok := s.files.get(training.FileID); !ok {
+			x.missing("file", training.FileID)
+			return
+		}
+		job.TrainingFiles = append(job.TrainingFiles, training.FileID)
+	}
+	if req.AutoStart != nil && !*req.AutoStart {
+		job.Status = jobStatusValidated
+	}
+	job.ModifiedAt = job.CreatedAt
+	s.fineTuning.put(job.ID, job)
+	x.json(http.StatusOK, job.JobOut)
+}
+
+// advanceFineTuningJob moves a job one step from QUEUED through RUNNING to SUCCESS. A successful
+// job registers its fine-tuned model; s.mu must be held.
+func (s *Server) advanceFineTuningJob(job *fineTuningJob) {
+	switch job.Status {
+	case sdk.JobStatusQueued:
+		job.Status = sdk.JobStatusRunning
+	case sdk.JobStatusRunning:
+		suffix := strings.TrimPrefix(job.ID, "ftjob-")
+		if job.Suffix != nil && *job.Suffix != "" {
+			suffix = *job.Suffix
+		}
+		model := fmt.Sprintf("ft:%s:%s:%s", job.Model, suffix, job.ID)
+		job.Status, job.FineTunedModel = sdk.JobStatusSuccess, &model
+		s.models.put(model, &sdk.ModelCard{ID: model, Object: "model", Created: int(now()), OwnedBy: "mistraltest", Type: "fine-tuned", Root: job.Model})
+	}
+}
+
+func (s *Server) handleLibraries(x *exchange, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case x.is(http.MethodGet, rest):
+		out := sdk.LibraryListResponse{Object: "list", Data: []sdk.Library{}}
+		for _, library := range s.libraries.list() {
+			out.Data = append(out.Data, *library)
+		}
+		x.json(http.StatusOK, out)
+		return
+	case x.is(http.MethodPost, rest):
+		var req sdk.CreateLibraryRequest
+		if !x.decode(&req) {
+			return
+		}
+		if req.Name == "" {
+			x.error(http.StatusBadRequest, "name is required")
+			return
+		}
+		library := &sdk.Library{
+			ID:          s.nextID("lib"),
+			Object:      "library",
+			Name:        req.Name,
+			Description: req.Description,
+			ChunkSize:   req.ChunkSize,
+			CreatedAt:   now(),
+			OwnerType:   "User",
+		}
+		library.UpdatedAt = library.CreatedAt
+		s.libraries.put(library.ID, library)
+		x.json(http.StatusOK, library)
+		return
+	case len(rest) == 0:
+		x.notFound()
+		return
+	}
+
+	library, ok := s.libraries.get(rest[0])
+	if !ok {
+		x.missing("library", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		x.json(http.StatusOK, library)
+	case x.is(http.MethodPut, rest[1:]):
+		var req sdk.UpdateLibraryRequest
+		if !x.decode(&req) {
+			return
+		}
+		if req.Name != nil {
+			library.Name = *req.Name
+		}
+		if req.Description != nil {
+			library.Description = req.Description
+		}
+		library.UpdatedAt = now()
+		x.json(http.StatusOK, library)
+	case x.is(http.MethodDelete, rest[1:]):
+		for _, doc := range s.documents.list() {
+			if doc.LibraryID == library.ID {
+				s.documents.delete(doc.ID)
+			}
+		}
+		s.libraries.delete(library.ID)
+		x.json(http.StatusOK, sdk.DeleteLibraryResponse{ID: library.ID, Object: "library", Deleted: true})
+	case len(rest) > 1 && rest[1] == "documents":
+		s.handleDocuments(x, library, rest[2:])
+	default:
+		x.notFound()
+	}
+}
+
+// handleDocuments serves the documents of library; s.mu must be held.
+func (s *Server) handleDocuments(x *exchange, library *sdk.Library, rest []string) {
+	switch {
+	case x.is(http.MethodGet, rest):
+		query := x.r.URL.Query()
+		var matched []*document
+		for _, doc := range s.documents.list() {
+			if doc.LibraryID != library.ID {
+				continue
+			}
+			if search := query.Get("search"); search != "" && !strings.Contains(strings.ToLower(doc.Name), strings.ToLower(search)) {
+				continue
+			}
+			matched = append(matched, doc)
+		}
+		out := sdk.DocumentListResponse{Object: "list", Data: []sdk.Document{}, Total: len(matched)}
+		for _, doc := range page(matched, query) {
+			out.Data = append(out.Data, doc.Document)
+		}
+		x.json(http.StatusOK, out)
+		return
+	case x.is(http.MethodPost, rest):
+		name, content, _, ok := x.readUpload()
+		if !ok {
+			return
+		}
+		sum := sha256.Sum256(content)
+		hash, size, ext := hex.EncodeToString(sum[:]), int64(len(content)), strings.TrimPrefix(filepath.Ext(name), ".")
+		mimeType := mime.TypeByExtension(filepath.Ext(name))
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		doc := &document{Document: sdk.Document{
+			ID:               s.nextID("doc"),
+			Object:           "document",
+			LibraryID:        library.ID,
+			Hash:             &hash,
+			MimeType:         &mimeType,
+			Extension:        &ext,
+			Size:             &size,
+			Name:             name,
+			CreatedAt:        now(),
+			ProcessingStatus: DocumentStatusRunning,
+			UploadedByType:   "User",
+		}, content: content}
+		s.documents.put(doc.ID, doc)
+		library.NbDocuments++
+		library.TotalSize += len(content)
+		library.UpdatedAt = now()
+		x.json(http.StatusOK, struct {
+			sdk.Document
+			Status string `json:"status"`
+		}{doc.Document, doc.ProcessingStatus})
+		return
+	case len(rest) == 0:
+		x.notFound()
+		return
+	}
+
+	doc, ok := s.documents.get(rest[0])
+	if !ok || doc.LibraryID != library.ID {
+		x.missing("document", rest[0])
+		return
+	}
+	switch {
+	case x.is(http.MethodGet, rest[1:]):
+		advanceDocument(doc)
+		x.json(http.StatusOK, doc.Document)
+	case x.is(http.MethodPatch, rest[1:]):
+		var req sdk.UpdateDocumentRequest
+		if !x.decode(&req) {
+			return
+		}
+		if req.Name != nil {
+			doc.Name = *req.Name
+		}
+		if req.Description != nil {
+			doc.Summary = req.Description
+		}
+		if req.Attributes != nil {
+			doc.Attributes = req.Attributes
+		}
+		x.json(http.StatusOK, doc.Document)
+	case x.is(http.MethodDelete, rest[1:]):
+		s.documents.delete(doc.ID)
+		library.NbDocuments--
+		library.TotalSize -= len(doc.content)
+		x.json(http.StatusOK, sdk.DeleteDocumentResponse{ID: doc.ID, Object: "document", Deleted: true})
+	case x.is(http.MethodGet, rest[1:], "status"):
+		advanceDocument(doc)
+		x.json(http.StatusOK, sdk.DocumentStatusResponse{ID: doc.ID, Status: doc.ProcessingStatus})
+	case x.is(http.MethodGet, rest[1:], "text_content"):
+		x.json(http.StatusOK, sdk.DocumentTextContent{Content: string(doc.content)})
+	case x.is(http.MethodGet, rest[1:], "signed-url"), x.is(http.MethodGet, rest[1:], "extracted-text-signed-url"):
+		x.json(http.StatusOK, sdk.DocumentSignedURLResponse{URL: s.signedURL("documents", doc.ID)})
+	case x.is(http.MethodPost, rest[1:], "reprocess"):
+		doc.ProcessingStatus = DocumentStatusRunning
+		x.w.WriteHeader(http.StatusNoContent)
+	default:
+		x.notFound()
+	}
+}
+
+func advanceDocument(doc *document) {
+	if doc.ProcessingStatus == DocumentStatusRunning {
+		doc.ProcessingStatus = DocumentStatusCompleted
+		doc.TokensProcessingTotal = countTokens(string(doc.content))
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package mistraltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+func TestFiles(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	uploaded, err := client.UploadFile(strings.NewReader("line one\nline two\n"), "train.jsonl", sdk.FilePurposeFineTune)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uploaded.Filename != "train.jsonl" || uploaded.Bytes != 18 || uploaded.Purpose != sdk.FilePurposeFineTune {
+		t.Errorf("unexpected upload %+v", uploaded)
+	}
+
+	list, err := client.ListFiles(nil)
+	if err != nil || len(list.Data) != 1 || list.Total == nil || *list.Total != 1 {
+		t.Fatalf("unexpected file list %+v, %v", list, err)
+	}
+	content, err := client.DownloadFile(uploaded.ID)
+	if err != nil || string(content) != "line one\nline two\n" {
+		t.Errorf("unexpected content %q, %v", content, err)
+	}
+
+	signed, err := client.GetSignedURL(uploaded.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(signed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != string(content) {
+		t.Errorf("expected the signed URL to serve the file, got %q", body)
+	}
+
+	if deleted, err := client.DeleteFile(uploaded.ID); err != nil || !deleted.Deleted {
+		t.Errorf("unexpected delete response %+v, %v", deleted, err)
+	}
+	if _, err := client.RetrieveFile(uploaded.ID); status(err) != http.StatusNotFound {
+		t.Errorf("expected a deleted file to be gone, got %v", err)
+	}
+}
+
+func TestBatchJobProgress(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	input := `{"custom_id": "a", "body": {"messages": [{"role": "user", "content": "first"}]}}` + "\n" +
+		`{"custom_id": "b", "body": {"messages": [{"role": "user", "content": "second"}]}}` + "\n"
+	file, err := client.UploadFile(strings.NewReader(input), "batch.jsonl", sdk.FilePurposeBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := "mistral-small-latest"
+	job, err := client.CreateBatchJob(&sdk.CreateBatchJobRequest{InputFiles: []string{file.ID}, Endpoint: sdk.BatchEndpointChat, Model: &model})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != sdk.BatchJobStatusQueued || *job.Metadata.TotalRequests != 2 {
+		t.Fatalf("unexpected new job %+v", job)
+	}
+
+	var statuses []sdk.BatchJobStatus
+	for job.Status != sdk.BatchJobStatusSuccess && len(statuses) < 5 {
+		if job, err = client.GetBatchJob(job.ID); err != nil {
+			t.Fatal(err)
+		}
+		statuses = append(statuses, job.Status)
+	}
+	if len(statuses) != 2 || statuses[0] != sdk.BatchJobStatusRunning || job.OutputFile == nil {
+		t.Fatalf("expected RUNNING then SUCCESS with an output file, got %v and %+v", statuses, job)
+	}
+
+	output, err := client.DownloadFile(*job.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	var result struct {
+		CustomID string `json:"custom_id"`
+		Response struct {
+			StatusCode int                        `json:"status_code"`
+			Body       sdk.ChatCompletionResponse `json:"body"`
+		} `json:"response"`
+	}
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[1]), &result) != nil {
+		t.Fatalf("unexpected output %s", output)
+	}
+	if result.CustomID != "b" || result.Response.StatusCode != http.StatusOK || result.Response.Body.Choices[0].Message.Content != "second" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	cancelled, err := client.CreateBatchJob(&sdk.CreateBatchJobRequest{InputFiles: []string{file.ID}, Endpoint: sdk.BatchEndpointChat, Model: &model})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled, err = client.CancelBatchJob(cancelled.ID); err != nil || cancelled.Status != sdk.BatchJobStatusCancelled {
+		t.Errorf("expected a cancelled job, got %+v, %v", cancelled, err)
+	}
+	list, err := client.ListBatchJobs(&sdk.ListBatchJobsParams{Status: []sdk.BatchJobStatus{sdk.BatchJobStatusSuccess}})
+	if err != nil || list.Total != 1 || list.Data[0].ID != job.ID {
+		t.Errorf("expected the status filter to match one job, got %+v, %v", list, err)
+	}
+}
+
+func TestFineTuningJobProgress(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	file, err := client.UploadFile(strings.NewReader(`{"messages": []}`), "train.jsonl", sdk.FilePurposeFineTune)
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffix, autoStart := "support", false
+	job, err := client.CreateFineTuningJob(&sdk.CreateFineTuningJobRequest{
+		Model:         "mistral-small-latest",
+		TrainingFiles: []sdk.TrainingFile{{FileID: file.ID}},
+		Suffix:        &suffix,
+		AutoStart:     &autoStart,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job, err = client.GetFineTuningJob(job.ID); err != nil || job.Status == sdk.JobStatusRunning {
+		t.Fatalf("expected a job without auto start to wait, got %+v, %v", job, err)
+	}
+	if job, err = client.StartFineTuningJob(job.ID); err != nil || job.Status != sdk.JobStatusQueued {
+		t.Fatalf("expected a queued job after start, got %+v, %v", job, err)
+	}
+	for i := 0; i < 2; i++ {
+		if job, err = client.GetFineTuningJob(job.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if job.Status != sdk.JobStatusSuccess || job.FineTunedModel == nil || !strings.Contains(*job.FineTunedModel, "support") {
+		t.Fatalf("expected a finished job with a model, got %+v", job)
+	}
+
+	resp, err := client.Chat(*job.FineTunedModel, []sdk.ChatMessage{sdk.UserMessage("hi")}, nil)
+	if err != nil || resp.Model != *job.FineTunedModel {
+		t.Errorf("expected the fine-tuned model to be usable, got %+v, %v", resp, err)
+	}
+}
+
+func TestLibrariesAndDocuments(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	library, err := client.CreateLibrary(&sdk.CreateLibraryRequest{Name: "handbook"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uploaded, err := client.UploadDocument(library.ID, bytes.NewReader([]byte("vacation policy text")), "policy.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uploaded.Status != DocumentStatusRunning {
+		t.Errorf("expected a document being processed, got %+v", uploaded)
+	}
+	processing, err := client.GetDocumentStatus(library.ID, uploaded.ID)
+	if err != nil || processing.Status != DocumentStatusCompleted {
+		t.Errorf("expected the document to be processed on the next poll, got %+v, %v", processing, err)
+	}
+	text, err := client.GetDocumentTextContent(library.ID, uploaded.ID)
+	if err != nil || text.Content != "vacation policy text" {
+		t.Errorf("unexpected text content %+v, %v", text, err)
+	}
+
+	if library, err = client.GetLibrary(library.ID); err != nil || library.NbDocuments != 1 || library.TotalSize != 20 {
+		t.Errorf("expected the library to count the document, got %+v, %v", library, err)
+	}
+	docs, err := client.ListDocuments(library.ID, 0)
+	if err != nil || docs.Total != 1 || docs.Data[0].Name != "policy.txt" {
+		t.Errorf("unexpected documents %+v, %v", docs, err)
+	}
+	if _, err := client.DeleteLibrary(library.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetDocument(library.ID, uploaded.ID); status(err) != http.StatusNotFound {
+		t.Errorf("expected documents to go with their library, got %v", err)
+	}
+}
+
+func TestConversations(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	model := "mistral-small-latest"
+	conv, err := client.StartConversation(&sdk.ConversationStartRequest{Model: &model, Inputs: []sdk.ConversationInput{{Type: "message.input", Content: "first"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conv.Outputs) != 1 || conv.Outputs[0].Content != "first" {
+		t.Errorf("unexpected outputs %+v", conv.Outputs)
+	}
+
+	events, err := client.AppendToConversationStreamChan(conv.ConversationID, &sdk.ConversationAppendRequest{Inputs: []sdk.ConversationInput{{Type: "message.input", Content: "second turn"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	var text strings.Builder
+	for event := range events {
+		if event.Error != nil {
+			t.Fatal(event.Error)
+		}
+		types = append(types, event.Type)
+		if content, ok := event.Data["content"].(string); ok {
+			text.WriteString(content)
+		}
+	}
+	if text.String() != "second turn" || types[0] != "conversation.response.started" || types[len(types)-1] != "conversation.response.done" {
+		t.Errorf("unexpected stream %v %q", types, text.String())
+	}
+
+	history, err := client.GetConversationHistory(conv.ConversationID)
+	if err != nil || len(history.Entries) != 4 {
+		t.Fatalf("expected four entries, got %+v, %v", history, err)
+	}
+	messages, err := client.GetConversationMessages(conv.ConversationID)
+	if err != nil || len(messages.Messages) != 4 || messages.Messages[3].Role != sdk.RoleAssistant {
+		t.Errorf("unexpected messages %+v, %v", messages, err)
+	}
+
+	if err := client.DeleteConversation(conv.ConversationID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetConversation(conv.ConversationID); status(err) != http.StatusNotFound {
+		t.Errorf("expected the conversation to be deleted, got %v", err)
+	}
+}
+
+func TestAgents(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	name, instructions := "weather", "Answer briefly."
+	tools := []sdk.Tool{{Type: sdk.ToolTypeFunction, Function: sdk.Function{Name: "get_weather"}}}
+	agent, err := client.CreateMistralAgent(&sdk.CreateMistralAgentRequest{Model: "mistral-large-latest", Name: &name, Instructions: &instructions, Tools: tools})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.AgentComplete(agent.ID, []sdk.ChatMessage{sdk.UserMessage("Paris?")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Model != "mistral-large-latest" || len(resp.Choices[0].Message.ToolCalls) != 1 {
+		t.Errorf("expected the agent's model and tools to be used, got %+v", resp)
+	}
+
+	model := "mistral-small-latest"
+	if agent, err = client.UpdateMistralAgent(agent.ID, &sdk.UpdateMistralAgentRequest{Model: &model}); err != nil || agent.Model != model {
+		t.Errorf("unexpected update %+v, %v", agent, err)
+	}
+	list, err := client.ListMistralAgentsWithParams(&sdk.ListMistralAgentsParams{Name: &name})
+	if err != nil || list.Total != 1 {
+		t.Errorf("unexpected agent list %+v, %v", list, err)
+	}
+	if err := client.DeleteMistralAgent(agent.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AgentComplete(agent.ID, []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); status(err) != http.StatusNotFound {
+		t.Errorf("expected a deleted agent to be gone, got %v", err)
+	}
+}
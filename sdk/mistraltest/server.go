@@ -0,0 +1,573 @@
+// Package mistraltest provides an in-memory fake of the Mistral API for integration tests.
+//
+//	srv := mistraltest.NewServer(t)
+//	client := srv.Client()
+//	resp, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil)
+//
+// The server is stateful: uploaded files, batch and fine-tuning jobs, libraries, documents,
+// conversations and agents behave like their API counterparts until the server is closed. Chat
+// replies come from a Responder, which echoes the last message by default. Tests can script exact
+// responses with Enqueue and EnqueueReply, inject failures with InjectFault, and inspect what the
+// client sent with Requests.
+package mistraltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+// APIKey is the API key used by clients returned from Server.Client. The server accepts any key.
+const APIKey = "mistraltest-key"
+
+// Server is a fake Mistral API backed by httptest.Server.
+type Server struct {
+	// URL is the base URL of the server, for use with sdk.WithBaseURL.
+	URL string
+
+	httpServer          *httptest.Server
+	embeddingDimensions int
+	streamDelay         time.Duration
+
+	mu        sync.Mutex
+	seq       int
+	responder Responder
+	replies   []Reply
+	requests  []Request
+	scripts   map[string][]Response
+	faults    []*Fault
+
+	models        store[sdk.ModelCard]
+	files         store[file]
+	batchJobs     store[batchJob]
+	fineTuning    store[fineTuningJob]
+	libraries     store[sdk.Library]
+	documents     store[document]
+	conversations store[conversation]
+	agents        store[sdk.MistralAgent]
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithResponder sets the function that produces chat, FIM, agent and conversation replies.
+func WithResponder(responder Responder) Option {
+	return func(s *Server) {
+		s.responder = responder
+	}
+}
+
+// WithStreamDelay pauses between the events of every streamed response, to simulate a slow model.
+func WithStreamDelay(d time.Duration) Option {
+	return func(s *Server) {
+		s.streamDelay = d
+	}
+}
+
+// WithEmbeddingDimensions sets the length of the vectors returned by the embeddings endpoint. The default is 16.
+func WithEmbeddingDimensions(n int) Option {
+	return func(s *Server) {
+		s.embeddingDimensions = n
+	}
+}
+
+// WithModels replaces the default model list with models with these IDs.
+func WithModels(ids ...string) Option {
+	return func(s *Server) {
+		s.models = store[sdk.ModelCard]{}
+		for _, id := range ids {
+			s.AddModel(sdk.ModelCard{ID: id})
+		}
+	}
+}
+
+// NewServer starts a fake Mistral API. When t is not nil the server is closed when the test ends.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	s := &Server{
+		embeddingDimensions: 16,
+		responder:           DefaultResponder,
+		scripts:             map[string][]Response{},
+	}
+	for _, id := range []string{"mistral-small-latest", "mistral-large-latest", "codestral-latest", "mistral-embed"} {
+		s.AddModel(sdk.ModelCard{ID: id})
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+	if t != nil {
+		t.Cleanup(s.Close)
+	}
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Client returns a client for the server. Retries back off for a millisecond instead of the
+// default half second, so failure injection does not slow tests down; opts are applied last.
+func (s *Server) Client(opts ...sdk.Option) *sdk.MistralClient {
+	policy := sdk.NewDefaultRetryPolicy(sdk.DefaultMaxRetries)
+	policy.BaseDelay = time.Millisecond
+	policy.Jitter = 0
+	base := []sdk.Option{sdk.WithAPIKey(APIKey), sdk.WithBaseURL(s.URL), sdk.WithRetryPolicy(policy)}
+	return sdk.NewClient(append(base, opts...)...)
+}
+
+// AddModel adds a model to the list served by /v1/models.
+func (s *Server) AddModel(model sdk.ModelCard) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if model.Object == "" {
+		model.Object = "model"
+	}
+	if model.Created == 0 {
+		model.Created = int(now())
+	}
+	if model.OwnedBy == "" {
+		model.OwnedBy = "mistralai"
+	}
+	s.models.put(model.ID, &model)
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// JSON decodes the request body into v.
+func (r Request) JSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Requests returns every request received so far, including the ones answered by faults and scripts.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for method and path. An empty method matches any method.
+func (s *Server) RequestsTo(method, path string) []Request {
+	var out []Request
+	for _, req := range s.Requests() {
+		if (method == "" || req.Method == method) && req.Path == path {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// AssertRequestCount fails t unless the server received want requests for method and path.
+func (s *Server) AssertRequestCount(t testing.TB, method, path string, want int) {
+	t.Helper()
+	if got := len(s.RequestsTo(method, path)); got != want {
+		t.Errorf("expected %d %s %s requests, got %d", want, method, path, got)
+	}
+}
+
+// Response is a scripted response. Body values other than string and []byte are encoded as JSON.
+// When Events is set the response is a server-sent event stream of the JSON encoded events,
+// terminated by data: [DONE].
+type Response struct {
+	Status int // Defaults to 200
+	Header http.Header
+	Body   any
+	Events []any
+}
+
+// Enqueue scripts the responses to the next requests for method and path, in order. Scripted
+// responses take precedence over the built-in behaviour and are consumed as they are served.
+func (s *Server) Enqueue(method, path string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := method + " " + path
+	s.scripts[key] = append(s.scripts[key], responses...)
+}
+
+// Fault makes matching requests fail or respond slowly.
+type Fault struct {
+	Method string // Empty matches any method
+	Path   string // Empty matches any path; a trailing * matches any path with that prefix
+
+	Status     int           // Error status to answer with; zero lets the request through after the delays
+	Message    string        // Error message; defaults to the status text
+	RetryAfter time.Duration // Sent as a Retry-After header with the error
+
+	Delay       time.Duration // Wait before responding
+	StreamDelay time.Duration // Pause between the events of a streamed response
+
+	Times int // Number of matching requests affected; zero means all of them
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added and take precedence over scripts.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// FailNext answers the next n requests for method and path with status.
+func (s *Server) FailNext(method, path string, status, n int) {
+	s.InjectFault(Fault{Method: method, Path: path, Status: status, Times: n})
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+		return strings.HasPrefix(r.URL.Path, prefix)
+	}
+	return f.Path == "" || f.Path == r.URL.Path
+}
+
+// takeFault returns the first fault matching r and uses up one of its Times; s.mu must be held.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// exchange is a request being served.
+type exchange struct {
+	w           http.ResponseWriter
+	r           *http.Request
+	body        []byte
+	streamDelay time.Duration
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	x := &exchange{w: w, r: r, body: body, streamDelay: s.streamDelay}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	var fault Fault
+	if f := s.takeFault(r); f != nil {
+		fault = *f
+	}
+	s.mu.Unlock()
+
+	if !x.sleep(fault.Delay) {
+		return
+	}
+	if fault.Status != 0 {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+		}
+		message := fault.Message
+		if message == "" {
+			message = http.StatusText(fault.Status)
+		}
+		x.error(fault.Status, message)
+		return
+	}
+	if fault.StreamDelay > 0 {
+		x.streamDelay = fault.StreamDelay
+	}
+
+	s.mu.Lock()
+	key := r.Method + " " + r.URL.Path
+	scripted, ok := s.scripts[key]
+	if ok {
+		if len(scripted) == 1 {
+			delete(s.scripts, key)
+		} else {
+			s.scripts[key] = scripted[1:]
+		}
+	}
+	s.mu.Unlock()
+	if ok {
+		x.scripted(scripted[0])
+		return
+	}
+	s.route(x)
+}
+
+func (s *Server) route(x *exchange) {
+	parts := strings.Split(strings.Trim(x.r.URL.Path, "/"), "/")
+	if parts[0] == "signed" {
+		s.handleSigned(x, parts[1:])
+		return
+	}
+	if len(parts) < 2 || parts[0] != "v1" {
+		x.notFound()
+		return
+	}
+	rest := parts[2:]
+	switch parts[1] {
+	case "models":
+		s.handleModels(x, rest)
+	case "chat":
+		if x.is(http.MethodPost, rest, "completions") {
+			s.handleChat(x)
+			return
+		}
+		x.notFound()
+	case "fim":
+		if x.is(http.MethodPost, rest, "completions") {
+			s.handleFIM(x)
+			return
+		}
+		x.notFound()
+	case "embeddings":
+		if x.is(http.MethodPost, rest) {
+			s.handleEmbeddings(x)
+			return
+		}
+		x.notFound()
+	case "files":
+		s.handleFiles(x, rest)
+	case "batch":
+		if len(rest) > 0 && rest[0] == "jobs" {
+			s.handleBatchJobs(x, rest[1:])
+			return
+		}
+		x.notFound()
+	case "fine_tuning":
+		if len(rest) > 0 && rest[0] == "jobs" {
+			s.handleFineTuningJobs(x, rest[1:])
+			return
+		}
+		x.notFound()
+	case "libraries":
+		s.handleLibraries(x, rest)
+	case "conversations":
+		s.handleConversations(x, rest)
+	case "agents":
+		s.handleAgents(x, rest)
+	default:
+		x.notFound()
+	}
+}
+
+// nextID returns a new identifier with prefix; s.mu must be held.
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%d", prefix, s.seq)
+}
+
+// is reports whether the request uses method and its remaining path segments equal path.
+func (x *exchange) is(method string, rest []string, path ...string) bool {
+	if x.r.Method != method || len(rest) != len(path) {
+		return false
+	}
+	for i := range rest {
+		if rest[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (x *exchange) decode(v any) bool {
+	if err := json.Unmarshal(x.body, v); err != nil {
+		x.error(http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (x *exchange) json(status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		x.error(http.StatusInternalServerError, err.Error())
+		return
+	}
+	x.w.Header().Set("Content-Type", "application/json")
+	x.w.WriteHeader(status)
+	_, _ = x.w.Write(data)
+}
+
+func (x *exchange) error(status int, message string) {
+	errType := "invalid_request_error"
+	if status >= 500 {
+		errType = "api_error"
+	}
+	x.json(status, map[string]any{"object": "error", "message": message, "type": errType, "code": strconv.Itoa(status)})
+}
+
+func (x *exchange) notFound() {
+	x.error(http.StatusNotFound, fmt.Sprintf("no route for %s %s", x.r.Method, x.r.URL.Path))
+}
+
+func (x *exchange) missing(kind, id string) {
+	x.error(http.StatusNotFound, fmt.Sprintf("%s %s not found", kind, id))
+}
+
+// stream writes events as server-sent events, pausing streamDelay between them, followed by data: [DONE].
+func (x *exchange) stream(events []any) {
+	x.w.Header().Set("Content-Type", "text/event-stream")
+	x.w.Header().Set("Cache-Control", "no-cache")
+	x.w.WriteHeader(http.StatusOK)
+	flusher, _ := x.w.(http.Flusher)
+	for i, event := range events {
+		if i > 0 && !x.sleep(x.streamDelay) {
+			return
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(x.w, "data: %s\n\n", data); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	_, _ = io.WriteString(x.w, "data: [DONE]\n\n")
+}
+
+func (x *exchange) scripted(resp Response) {
+	for key, values := range resp.Header {
+		x.w.Header()[key] = values
+	}
+	if resp.Events != nil {
+		x.stream(resp.Events)
+		return
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	switch body := resp.Body.(type) {
+	case nil:
+		x.w.WriteHeader(status)
+	case string:
+		x.w.WriteHeader(status)
+		_, _ = io.WriteString(x.w, body)
+	case []byte:
+		x.w.WriteHeader(status)
+		_, _ = x.w.Write(body)
+	default:
+		x.json(status, body)
+	}
+}
+
+// sleep waits for d and reports false if the client went away first.
+func (x *exchange) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-x.r.Context().Done():
+		return false
+	}
+}
+
+// store keeps resources in creation order.
+type store[T any] struct {
+	ids   []string
+	items map[string]*T
+}
+
+func (st *store[T]) put(id string, item *T) {
+	if st.items == nil {
+		st.items = map[string]*T{}
+	}
+	if _, ok := st.items[id]; !ok {
+		st.ids = append(st.ids, id)
+	}
+	st.items[id] = item
+}
+
+func (st *store[T]) get(id string) (*T, bool) {
+	item, ok := st.items[id]
+	return item, ok
+}
+
+func (st *store[T]) delete(id string) bool {
+	if _, ok := st.items[id]; !ok {
+		return false
+	}
+	delete(st.items, id)
+	for i, existing := range st.ids {
+		if existing == id {
+			st.ids = append(st.ids[:i], st.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (st *store[T]) list() []*T {
+	out := make([]*T, 0, len(st.ids))
+	for _, id := range st.ids {
+		out = append(out, st.items[id])
+	}
+	return out
+}
+
+// page returns the items of a zero-based page selected by the page and page_size query parameters.
+func page[T any](items []*T, query url.Values) []*T {
+	size, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || size <= 0 {
+		return items
+	}
+	n, _ := strconv.Atoi(query.Get("page"))
+	start := n * size
+	if start >= len(items) || start < 0 {
+		return nil
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func now() int64 {
+	return time.Now().Unix()
+}
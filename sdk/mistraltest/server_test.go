@@ -0,0 +1,268 @@
+package mistraltest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+func status(err error) int {
+	var apiErr *sdk.MistralAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus
+	}
+	return 0
+}
+
+func TestChatEchoAndRequestAssertions(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	resp, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hello there")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "hello there" || resp.Choices[0].FinishReason != sdk.FinishReasonStop {
+		t.Errorf("expected the message to be echoed, got %+v", resp.Choices[0])
+	}
+	if resp.Usage.PromptTokens != 2 || resp.Usage.CompletionTokens != 2 || resp.Usage.TotalTokens != 4 {
+		t.Errorf("unexpected usage %+v", resp.Usage)
+	}
+
+	srv.AssertRequestCount(t, http.MethodPost, "/v1/chat/completions", 1)
+	req := srv.RequestsTo(http.MethodPost, "/v1/chat/completions")[0]
+	var body ChatRequest
+	if err := req.JSON(&body); err != nil || body.Model != "mistral-small-latest" {
+		t.Errorf("unexpected request body %s: %v", req.Body, err)
+	}
+	if req.Header.Get("Authorization") != "Bearer "+APIKey {
+		t.Errorf("unexpected Authorization header %q", req.Header.Get("Authorization"))
+	}
+
+	if _, err := client.Chat("no-such-model", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); status(err) != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an unknown model, got %v", err)
+	}
+}
+
+func TestChatStream(t *testing.T) {
+	srv := NewServer(t)
+	stream, err := srv.Client().ChatStreamChan("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("one two three")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var text strings.Builder
+	var last sdk.ChatCompletionStreamResponse
+	chunks := 0
+	for chunk := range stream {
+		if chunk.Error != nil {
+			t.Fatalf("stream error: %v", chunk.Error)
+		}
+		text.WriteString(chunk.Choices[0].Delta.Content)
+		last = chunk
+		chunks++
+	}
+	if text.String() != "one two three" || chunks != 5 {
+		t.Errorf("expected 5 chunks spelling the echo, got %d: %q", chunks, text.String())
+	}
+	if last.Choices[0].FinishReason != sdk.FinishReasonStop || last.Usage.TotalTokens != 6 {
+		t.Errorf("expected the last chunk to carry finish reason and usage, got %+v", last)
+	}
+}
+
+func TestChatStreamAccumulatesToChatResponse(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	reply := Reply{Content: "Checking the weather", ToolCalls: []sdk.ToolCall{{Id: "call-1", Type: sdk.ToolTypeFunction, Function: sdk.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}}}}
+	srv.EnqueueReply(reply, reply)
+	messages := []sdk.ChatMessage{sdk.UserMessage("weather in Paris?")}
+
+	want, err := client.Chat("mistral-small-latest", messages, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := client.ChatStream("mistral-small-latest", messages, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := sdk.AccumulateChatStream(stream, nil)
+	if err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Choices, want.Choices) || got.Usage != want.Usage || got.Model != want.Model || got.Object != want.Object {
+		t.Errorf("expected the stream to rebuild %+v, got %+v", want, got)
+	}
+}
+
+func TestChatToolCalls(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	tools := []sdk.Tool{{Type: sdk.ToolTypeFunction, Function: sdk.Function{Name: "get_weather", Parameters: map[string]any{"type": "object"}}}}
+	messages := []sdk.ChatMessage{sdk.UserMessage("weather in Paris?")}
+
+	resp, err := client.Chat("mistral-large-latest", messages, &sdk.ChatRequestParams{Tools: tools})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	choice := resp.Choices[0]
+	if choice.FinishReason != sdk.FinishReasonToolCalls || len(choice.Message.ToolCalls) != 1 || choice.Message.ToolCalls[0].Function.Name != "get_weather" {
+		t.Fatalf("expected a call to get_weather, got %+v", choice)
+	}
+
+	call := choice.Message.ToolCalls[0]
+	messages = append(messages, choice.Message, sdk.ChatMessage{Role: sdk.RoleTool, ToolCallID: call.Id, Name: call.Function.Name, Content: "sunny"})
+	resp, err = client.Chat("mistral-large-latest", messages, &sdk.ChatRequestParams{Tools: tools})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "Tool result: sunny" {
+		t.Errorf("expected the tool result to be used, got %+v", resp.Choices[0].Message)
+	}
+
+	resp, err = client.Chat("mistral-large-latest", messages[:1], &sdk.ChatRequestParams{Tools: tools, ToolChoice: sdk.ToolChoiceNone})
+	if err != nil || len(resp.Choices[0].Message.ToolCalls) != 0 {
+		t.Errorf("expected no tool call with tool_choice none, got %+v, %v", resp, err)
+	}
+}
+
+func TestEnqueueReplyAndResponder(t *testing.T) {
+	srv := NewServer(t, WithResponder(func(req *ChatRequest) Reply {
+		return Reply{Content: "from " + req.Model}
+	}))
+	client := srv.Client()
+	srv.EnqueueReply(Reply{Content: "scripted", FinishReason: sdk.FinishReasonLength})
+
+	resp, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil)
+	if err != nil || resp.Choices[0].Message.Content != "scripted" || resp.Choices[0].FinishReason != sdk.FinishReasonLength {
+		t.Fatalf("expected the scripted reply, got %+v, %v", resp, err)
+	}
+	resp, err = client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil)
+	if err != nil || resp.Choices[0].Message.Content != "from mistral-small-latest" {
+		t.Errorf("expected the responder after the script ran out, got %+v, %v", resp, err)
+	}
+}
+
+func TestEnqueueResponses(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	srv.Enqueue(http.MethodGet, "/v1/models",
+		Response{Body: sdk.ModelList{Object: "list", Data: []sdk.ModelCard{{ID: "scripted-model"}}}},
+		Response{Status: http.StatusForbidden, Body: `{"message":"no access"}`},
+	)
+
+	models, err := client.ListModels()
+	if err != nil || len(models.Data) != 1 || models.Data[0].ID != "scripted-model" {
+		t.Fatalf("expected the scripted model list, got %+v, %v", models, err)
+	}
+	if _, err := client.ListModels(); status(err) != http.StatusForbidden {
+		t.Errorf("expected the scripted 403, got %v", err)
+	}
+	models, err = client.ListModels()
+	if err != nil || len(models.Data) != 4 {
+		t.Errorf("expected the built-in model list once the script ran out, got %+v, %v", models, err)
+	}
+
+	srv.Enqueue(http.MethodPost, "/v1/chat/completions", Response{Events: []any{
+		map[string]any{"id": "s", "choices": []any{map[string]any{"index": 0, "delta": map[string]any{"content": "scripted stream"}}}},
+	}})
+	stream, err := client.ChatStreamChan("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for chunk := range stream {
+		if chunk.Error != nil || chunk.Choices[0].Delta.Content != "scripted stream" {
+			t.Errorf("unexpected chunk %+v", chunk)
+		}
+	}
+}
+
+func TestFaults(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+
+	srv.FailNext(http.MethodPost, "/v1/chat/completions", http.StatusTooManyRequests, 2)
+	if _, err := client.Chat("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("expected the client to retry past two 429s, got %v", err)
+	}
+	srv.AssertRequestCount(t, http.MethodPost, "/v1/chat/completions", 3)
+
+	srv.InjectFault(Fault{Path: "/v1/models", Status: http.StatusInternalServerError, Message: "boom"})
+	_, err := client.ListModels()
+	var apiErr *sdk.MistralAPIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusInternalServerError || apiErr.Message != "boom" {
+		t.Errorf("expected the injected 500, got %v", err)
+	}
+	srv.ClearFaults()
+	if _, err := client.ListModels(); err != nil {
+		t.Errorf("expected success after ClearFaults, got %v", err)
+	}
+}
+
+func TestSlowStream(t *testing.T) {
+	srv := NewServer(t)
+	srv.InjectFault(Fault{Path: "/v1/chat/*", StreamDelay: 200 * time.Millisecond, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stream, err := srv.Client().ChatStreamChanCtx(ctx, "mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("a b c d")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chunks := 0
+	for chunk := range stream {
+		if chunk.Error == nil {
+			chunks++
+		}
+	}
+	if chunks >= 6 {
+		t.Errorf("expected the deadline to cut the slow stream short, got all %d chunks", chunks)
+	}
+
+	// The fault applied once, so the next stream is fast.
+	stream, err = srv.Client().ChatStreamChan("mistral-small-latest", []sdk.ChatMessage{sdk.UserMessage("a b c d")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chunks = 0
+	for range stream {
+		chunks++
+	}
+	if chunks != 6 {
+		t.Errorf("expected 6 chunks, got %d", chunks)
+	}
+}
+
+func TestEmbeddingsAndFIM(t *testing.T) {
+	srv := NewServer(t, WithEmbeddingDimensions(8))
+	client := srv.Client()
+
+	resp, err := client.Embeddings("mistral-embed", []string{"alpha", "beta", "alpha"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 3 || len(resp.Data[0].Embedding) != 8 {
+		t.Fatalf("unexpected embeddings %+v", resp)
+	}
+	var norm float64
+	for i, v := range resp.Data[0].Embedding {
+		norm += v * v
+		if v != resp.Data[2].Embedding[i] {
+			t.Fatal("expected identical inputs to get identical vectors")
+		}
+	}
+	if math.Abs(norm-1) > 1e-9 {
+		t.Errorf("expected unit vectors, got norm %f", norm)
+	}
+
+	suffix := "}"
+	fim, err := client.FIM(&sdk.FIMRequestParams{Model: "codestral-latest", Prompt: "func main() {", Suffix: &suffix})
+	if err != nil || fim.Choices[0].Message.Content != "func main() {" {
+		t.Errorf("unexpected FIM response %+v, %v", fim, err)
+	}
+}
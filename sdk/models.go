@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -98,19 +99,13 @@ type UnarchiveModelResponse struct {
 }
 
 func (c *MistralClient) ListModels() (*ModelList, error) {
-	response, err := c.request(http.MethodGet, nil, "v1/models", false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.ListModelsCtx(context.Background())
+}
 
+func (c *MistralClient) ListModelsCtx(ctx context.Context) (*ModelList, error) {
+	ctx = withOperation(ctx, "models.list")
 	var modelList ModelList
-	err = mapToStruct(respData, &modelList)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, "v1/models", &modelList); err != nil {
 		return nil, err
 	}
 
@@ -124,19 +119,14 @@ func (c *MistralClient) ListModels() (*ModelList, error) {
 //
 // Returns detailed information about the model
 func (c *MistralClient) RetrieveModel(modelID string) (*ModelCard, error) {
-	response, err := c.request(http.MethodGet, nil, fmt.Sprintf("v1/models/%s", modelID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.RetrieveModelCtx(context.Background(), modelID)
+}
 
+// RetrieveModelCtx is like RetrieveModel but uses ctx for cancellation and deadlines.
+func (c *MistralClient) RetrieveModelCtx(ctx context.Context, modelID string) (*ModelCard, error) {
+	ctx = withOperation(ctx, "models.retrieve")
 	var model ModelCard
-	err = mapToStruct(respData, &model)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/models/%s", modelID), &model); err != nil {
 		return nil, err
 	}
 
@@ -150,19 +140,14 @@ func (c *MistralClient) RetrieveModel(modelID string) (*ModelCard, error) {
 //
 // Returns confirmation of deletion
 func (c *MistralClient) DeleteModel(modelID string) (*DeleteModelResponse, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/models/%s", modelID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.DeleteModelCtx(context.Background(), modelID)
+}
 
+// DeleteModelCtx is like DeleteModel but uses ctx for cancellation and deadlines.
+func (c *MistralClient) DeleteModelCtx(ctx context.Context, modelID string) (*DeleteModelResponse, error) {
+	ctx = withOperation(ctx, "models.delete")
 	var deleteResponse DeleteModelResponse
-	err = mapToStruct(respData, &deleteResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/models/%s", modelID), &deleteResponse); err != nil {
 		return nil, err
 	}
 
@@ -183,6 +168,12 @@ type UpdateModelRequest struct {
 //
 // Returns the updated model information
 func (c *MistralClient) UpdateModel(modelID string, req *UpdateModelRequest) (*FineTunedModel, error) {
+	return c.UpdateModelCtx(context.Background(), modelID, req)
+}
+
+// UpdateModelCtx is like UpdateModel but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UpdateModelCtx(ctx context.Context, modelID string, req *UpdateModelRequest) (*FineTunedModel, error) {
+	ctx = withOperation(ctx, "models.update")
 	if req == nil {
 		return nil, fmt.Errorf("update request cannot be nil")
 	}
@@ -195,19 +186,8 @@ func (c *MistralClient) UpdateModel(modelID string, req *UpdateModelRequest) (*F
 		reqMap["description"] = req.Description
 	}
 
-	response, err := c.request(http.MethodPatch, reqMap, fmt.Sprintf("v1/fine_tuning/models/%s", modelID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-
 	var model FineTunedModel
-	err = mapToStruct(respData, &model)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPatch, reqMap, fmt.Sprintf("v1/fine_tuning/models/%s", modelID), &model); err != nil {
 		return nil, err
 	}
 
@@ -221,19 +201,14 @@ func (c *MistralClient) UpdateModel(modelID string, req *UpdateModelRequest) (*F
 //
 // Returns confirmation of archival
 func (c *MistralClient) ArchiveModel(modelID string) (*ArchiveModelResponse, error) {
-	response, err := c.request(http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/models/%s/archive", modelID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.ArchiveModelCtx(context.Background(), modelID)
+}
 
+// ArchiveModelCtx is like ArchiveModel but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ArchiveModelCtx(ctx context.Context, modelID string) (*ArchiveModelResponse, error) {
+	ctx = withOperation(ctx, "models.archive")
 	var archiveResponse ArchiveModelResponse
-	err = mapToStruct(respData, &archiveResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, nil, fmt.Sprintf("v1/fine_tuning/models/%s/archive", modelID), &archiveResponse); err != nil {
 		return nil, err
 	}
 
@@ -247,19 +222,14 @@ func (c *MistralClient) ArchiveModel(modelID string) (*ArchiveModelResponse, err
 //
 // Returns confirmation of unarchival
 func (c *MistralClient) UnarchiveModel(modelID string) (*UnarchiveModelResponse, error) {
-	response, err := c.request(http.MethodDelete, nil, fmt.Sprintf("v1/fine_tuning/models/%s/archive", modelID), false, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
+	return c.UnarchiveModelCtx(context.Background(), modelID)
+}
 
+// UnarchiveModelCtx is like UnarchiveModel but uses ctx for cancellation and deadlines.
+func (c *MistralClient) UnarchiveModelCtx(ctx context.Context, modelID string) (*UnarchiveModelResponse, error) {
+	ctx = withOperation(ctx, "models.unarchive")
 	var unarchiveResponse UnarchiveModelResponse
-	err = mapToStruct(respData, &unarchiveResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/fine_tuning/models/%s/archive", modelID), &unarchiveResponse); err != nil {
 		return nil, err
 	}
 
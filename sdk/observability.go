@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -68,6 +69,11 @@ type SearchChatCompletionEventsRequest struct {
 }
 
 func (c *MistralClient) CreateCampaign(req *CreateCampaignRequest) (APIResponse, error) {
+	return c.CreateCampaignCtx(context.Background(), req)
+}
+
+func (c *MistralClient) CreateCampaignCtx(ctx context.Context, req *CreateCampaignRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.create_campaign")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -78,155 +84,305 @@ func (c *MistralClient) CreateCampaign(req *CreateCampaignRequest) (APIResponse,
 		"description":   req.Description,
 		"max_nb_events": req.MaxNbEvents,
 	})
-	return c.requestMap(http.MethodPost, body, "v1/observability/campaigns")
+	return c.requestMap(ctx, http.MethodPost, body, "v1/observability/campaigns")
 }
 
 func (c *MistralClient) ListCampaigns(params *ListObservabilityParams) (APIResponse, error) {
-	return c.listObservability("v1/observability/campaigns", params)
+	return c.ListCampaignsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListCampaignsCtx(ctx context.Context, params *ListObservabilityParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_campaigns")
+	return c.listObservability(ctx, "v1/observability/campaigns", params)
 }
 
 func (c *MistralClient) FetchCampaign(campaignID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/campaigns/%s", campaignID))
+	return c.FetchCampaignCtx(context.Background(), campaignID)
+}
+
+func (c *MistralClient) FetchCampaignCtx(ctx context.Context, campaignID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_campaign")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/campaigns/%s", campaignID))
 }
 
 func (c *MistralClient) DeleteCampaign(campaignID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/observability/campaigns/%s", campaignID))
+	return c.DeleteCampaignCtx(context.Background(), campaignID)
+}
+
+func (c *MistralClient) DeleteCampaignCtx(ctx context.Context, campaignID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.delete_campaign")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/observability/campaigns/%s", campaignID))
 }
 
 func (c *MistralClient) FetchCampaignStatus(campaignID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/campaigns/%s/status", campaignID))
+	return c.FetchCampaignStatusCtx(context.Background(), campaignID)
+}
+
+func (c *MistralClient) FetchCampaignStatusCtx(ctx context.Context, campaignID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_campaign_status")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/campaigns/%s/status", campaignID))
 }
 
 func (c *MistralClient) ListCampaignEvents(campaignID string, pageSize, page *int) (APIResponse, error) {
+	return c.ListCampaignEventsCtx(context.Background(), campaignID, pageSize, page)
+}
+
+func (c *MistralClient) ListCampaignEventsCtx(ctx context.Context, campaignID string, pageSize, page *int) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_campaign_events")
 	query := queryWithOptionalValues(map[string]any{"page_size": pageSize, "page": page})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/campaigns/%s/selected-events", campaignID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/campaigns/%s/selected-events", campaignID), query))
 }
 
 func (c *MistralClient) CreateDataset(req *CreateDatasetRequest) (APIResponse, error) {
+	return c.CreateDatasetCtx(context.Background(), req)
+}
+
+func (c *MistralClient) CreateDatasetCtx(ctx context.Context, req *CreateDatasetRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.create_dataset")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "description": req.Description})
-	return c.requestMap(http.MethodPost, body, "v1/observability/datasets")
+	return c.requestMap(ctx, http.MethodPost, body, "v1/observability/datasets")
 }
 
 func (c *MistralClient) ListDatasets(params *ListObservabilityParams) (APIResponse, error) {
-	return c.listObservability("v1/observability/datasets", params)
+	return c.ListDatasetsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListDatasetsCtx(ctx context.Context, params *ListObservabilityParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_datasets")
+	return c.listObservability(ctx, "v1/observability/datasets", params)
 }
 
 func (c *MistralClient) FetchDataset(datasetID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
+	return c.FetchDatasetCtx(context.Background(), datasetID)
+}
+
+func (c *MistralClient) FetchDatasetCtx(ctx context.Context, datasetID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_dataset")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
 }
 
 func (c *MistralClient) DeleteDataset(datasetID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
+	return c.DeleteDatasetCtx(context.Background(), datasetID)
+}
+
+func (c *MistralClient) DeleteDatasetCtx(ctx context.Context, datasetID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.delete_dataset")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
 }
 
 func (c *MistralClient) UpdateDataset(datasetID string, req *UpdateDatasetRequest) (APIResponse, error) {
+	return c.UpdateDatasetCtx(context.Background(), datasetID, req)
+}
+
+func (c *MistralClient) UpdateDatasetCtx(ctx context.Context, datasetID string, req *UpdateDatasetRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.update_dataset")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "description": req.Description})
-	return c.requestMap(http.MethodPatch, body, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
+	return c.requestMap(ctx, http.MethodPatch, body, fmt.Sprintf("v1/observability/datasets/%s", datasetID))
 }
 
 func (c *MistralClient) ListDatasetRecords(datasetID string, pageSize, page *int) (APIResponse, error) {
+	return c.ListDatasetRecordsCtx(context.Background(), datasetID, pageSize, page)
+}
+
+func (c *MistralClient) ListDatasetRecordsCtx(ctx context.Context, datasetID string, pageSize, page *int) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_dataset_records")
 	query := queryWithOptionalValues(map[string]any{"page_size": pageSize, "page": page})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/datasets/%s/records", datasetID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/datasets/%s/records", datasetID), query))
 }
 
 func (c *MistralClient) CreateDatasetRecord(datasetID string, req *DatasetRecordRequest) (APIResponse, error) {
+	return c.CreateDatasetRecordCtx(context.Background(), datasetID, req)
+}
+
+func (c *MistralClient) CreateDatasetRecordCtx(ctx context.Context, datasetID string, req *DatasetRecordRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.create_dataset_record")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"payload": req.Payload, "properties": req.Properties})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/observability/datasets/%s/records", datasetID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/observability/datasets/%s/records", datasetID))
 }
 
 func (c *MistralClient) ImportDatasetFromCampaign(datasetID, campaignID string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"campaign_id": campaignID}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-campaign", datasetID))
+	return c.ImportDatasetFromCampaignCtx(context.Background(), datasetID, campaignID)
+}
+
+func (c *MistralClient) ImportDatasetFromCampaignCtx(ctx context.Context, datasetID, campaignID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.import_dataset_from_campaign")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"campaign_id": campaignID}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-campaign", datasetID))
 }
 
 func (c *MistralClient) ImportDatasetFromExplorer(datasetID string, completionEventIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"completion_event_ids": completionEventIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-explorer", datasetID))
+	return c.ImportDatasetFromExplorerCtx(context.Background(), datasetID, completionEventIDs)
+}
+
+func (c *MistralClient) ImportDatasetFromExplorerCtx(ctx context.Context, datasetID string, completionEventIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.import_dataset_from_explorer")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"completion_event_ids": completionEventIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-explorer", datasetID))
 }
 
 func (c *MistralClient) ImportDatasetFromFile(datasetID, fileID string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"file_id": fileID}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-file", datasetID))
+	return c.ImportDatasetFromFileCtx(context.Background(), datasetID, fileID)
+}
+
+func (c *MistralClient) ImportDatasetFromFileCtx(ctx context.Context, datasetID, fileID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.import_dataset_from_file")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"file_id": fileID}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-file", datasetID))
 }
 
 func (c *MistralClient) ImportDatasetFromPlayground(datasetID string, conversationIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"conversation_ids": conversationIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-playground", datasetID))
+	return c.ImportDatasetFromPlaygroundCtx(context.Background(), datasetID, conversationIDs)
+}
+
+func (c *MistralClient) ImportDatasetFromPlaygroundCtx(ctx context.Context, datasetID string, conversationIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.import_dataset_from_playground")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"conversation_ids": conversationIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-playground", datasetID))
 }
 
 func (c *MistralClient) ImportDatasetFromDatasetRecords(datasetID string, datasetRecordIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"dataset_record_ids": datasetRecordIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-dataset", datasetID))
+	return c.ImportDatasetFromDatasetRecordsCtx(context.Background(), datasetID, datasetRecordIDs)
+}
+
+func (c *MistralClient) ImportDatasetFromDatasetRecordsCtx(ctx context.Context, datasetID string, datasetRecordIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.import_dataset_from_dataset_records")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"dataset_record_ids": datasetRecordIDs}, fmt.Sprintf("v1/observability/datasets/%s/imports/from-dataset", datasetID))
 }
 
 func (c *MistralClient) ExportDatasetToJSONL(datasetID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s/exports/to-jsonl", datasetID))
+	return c.ExportDatasetToJSONLCtx(context.Background(), datasetID)
+}
+
+func (c *MistralClient) ExportDatasetToJSONLCtx(ctx context.Context, datasetID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.export_dataset_to_jsonl")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s/exports/to-jsonl", datasetID))
 }
 
 func (c *MistralClient) FetchDatasetTask(datasetID, taskID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s/tasks/%s", datasetID, taskID))
+	return c.FetchDatasetTaskCtx(context.Background(), datasetID, taskID)
+}
+
+func (c *MistralClient) FetchDatasetTaskCtx(ctx context.Context, datasetID, taskID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_dataset_task")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/datasets/%s/tasks/%s", datasetID, taskID))
 }
 
 func (c *MistralClient) ListDatasetTasks(datasetID string, pageSize, page *int) (APIResponse, error) {
+	return c.ListDatasetTasksCtx(context.Background(), datasetID, pageSize, page)
+}
+
+func (c *MistralClient) ListDatasetTasksCtx(ctx context.Context, datasetID string, pageSize, page *int) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_dataset_tasks")
 	query := queryWithOptionalValues(map[string]any{"page_size": pageSize, "page": page})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/datasets/%s/tasks", datasetID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/datasets/%s/tasks", datasetID), query))
 }
 
 func (c *MistralClient) ListChatCompletionFields() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/observability/chat-completion-fields")
+	return c.ListChatCompletionFieldsCtx(context.Background())
+}
+
+func (c *MistralClient) ListChatCompletionFieldsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_chat_completion_fields")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/observability/chat-completion-fields")
 }
 
 func (c *MistralClient) FetchChatCompletionFieldOptions(fieldName string, operator *string) (APIResponse, error) {
+	return c.FetchChatCompletionFieldOptionsCtx(context.Background(), fieldName, operator)
+}
+
+func (c *MistralClient) FetchChatCompletionFieldOptionsCtx(ctx context.Context, fieldName string, operator *string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_chat_completion_field_options")
 	query := queryWithOptionalValues(map[string]any{"operator": operator})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/chat-completion-fields/%s/options", fieldName), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/chat-completion-fields/%s/options", fieldName), query))
 }
 
 func (c *MistralClient) FetchChatCompletionFieldOptionCounts(fieldName string, req *FieldOptionCountsRequest) (APIResponse, error) {
+	return c.FetchChatCompletionFieldOptionCountsCtx(context.Background(), fieldName, req)
+}
+
+func (c *MistralClient) FetchChatCompletionFieldOptionCountsCtx(ctx context.Context, fieldName string, req *FieldOptionCountsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_chat_completion_field_option_counts")
 	body := map[string]interface{}{}
 	if req != nil {
 		body = optionalRequestMap(map[string]any{"filter_params": req.FilterParams})
 	}
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/observability/chat-completion-fields/%s/options-counts", fieldName))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/observability/chat-completion-fields/%s/options-counts", fieldName))
 }
 
 func (c *MistralClient) SearchChatCompletionEvents(req *SearchChatCompletionEventsRequest) (APIResponse, error) {
+	return c.SearchChatCompletionEventsCtx(context.Background(), req)
+}
+
+func (c *MistralClient) SearchChatCompletionEventsCtx(ctx context.Context, req *SearchChatCompletionEventsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_chat_completion_events")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	query := queryWithOptionalValues(map[string]any{"page_size": req.PageSize, "cursor": req.Cursor})
 	body := optionalRequestMap(map[string]any{"search_params": req.SearchParams, "extra_fields": req.ExtraFields})
-	return c.requestMap(http.MethodPost, body, appendQuery("v1/observability/chat-completion-events/search", query))
+	return c.requestMap(ctx, http.MethodPost, body, appendQuery("v1/observability/chat-completion-events/search", query))
 }
 
 func (c *MistralClient) SearchChatCompletionEventIDs(searchParams map[string]any, extraFields []string) (APIResponse, error) {
+	return c.SearchChatCompletionEventIDsCtx(context.Background(), searchParams, extraFields)
+}
+
+func (c *MistralClient) SearchChatCompletionEventIDsCtx(ctx context.Context, searchParams map[string]any, extraFields []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_chat_completion_event_ids")
 	body := optionalRequestMap(map[string]any{"search_params": searchParams, "extra_fields": extraFields})
-	return c.requestMap(http.MethodPost, body, "v1/observability/chat-completion-events/search-ids")
+	return c.requestMap(ctx, http.MethodPost, body, "v1/observability/chat-completion-events/search-ids")
 }
 
 func (c *MistralClient) FetchChatCompletionEvent(eventID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/chat-completion-events/%s", eventID))
+	return c.FetchChatCompletionEventCtx(context.Background(), eventID)
+}
+
+func (c *MistralClient) FetchChatCompletionEventCtx(ctx context.Context, eventID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_chat_completion_event")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/chat-completion-events/%s", eventID))
 }
 
 func (c *MistralClient) FetchSimilarChatCompletionEvents(eventID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/chat-completion-events/%s/similar-events", eventID))
+	return c.FetchSimilarChatCompletionEventsCtx(context.Background(), eventID)
+}
+
+func (c *MistralClient) FetchSimilarChatCompletionEventsCtx(ctx context.Context, eventID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_similar_chat_completion_events")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/chat-completion-events/%s/similar-events", eventID))
 }
 
 func (c *MistralClient) JudgeChatCompletionEvent(eventID string, judgeDefinition any) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"judge_definition": judgeDefinition}, fmt.Sprintf("v1/observability/chat-completion-events/%s/live-judging", eventID))
+	return c.JudgeChatCompletionEventCtx(context.Background(), eventID, judgeDefinition)
+}
+
+func (c *MistralClient) JudgeChatCompletionEventCtx(ctx context.Context, eventID string, judgeDefinition any) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.judge_chat_completion_event")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"judge_definition": judgeDefinition}, fmt.Sprintf("v1/observability/chat-completion-events/%s/live-judging", eventID))
 }
 
 func (c *MistralClient) CreateJudge(req *JudgeRequest) (APIResponse, error) {
+	return c.CreateJudgeCtx(context.Background(), req)
+}
+
+func (c *MistralClient) CreateJudgeCtx(ctx context.Context, req *JudgeRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.create_judge")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
-	return c.requestMap(http.MethodPost, judgeRequestMap(req), "v1/observability/judges")
+	return c.requestMap(ctx, http.MethodPost, judgeRequestMap(req), "v1/observability/judges")
 }
 
 func (c *MistralClient) ListJudges(params *ListJudgesParams) (APIResponse, error) {
+	return c.ListJudgesCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListJudgesCtx(ctx context.Context, params *ListJudgesParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_judges")
 	if params == nil {
 		params = &ListJudgesParams{}
 	}
@@ -237,62 +393,112 @@ func (c *MistralClient) ListJudges(params *ListJudgesParams) (APIResponse, error
 		"page":         params.Page,
 		"q":            params.Q,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/observability/judges", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/observability/judges", query))
 }
 
 func (c *MistralClient) FetchJudge(judgeID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/judges/%s", judgeID))
+	return c.FetchJudgeCtx(context.Background(), judgeID)
+}
+
+func (c *MistralClient) FetchJudgeCtx(ctx context.Context, judgeID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_judge")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/judges/%s", judgeID))
 }
 
 func (c *MistralClient) DeleteJudge(judgeID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/observability/judges/%s", judgeID))
+	return c.DeleteJudgeCtx(context.Background(), judgeID)
+}
+
+func (c *MistralClient) DeleteJudgeCtx(ctx context.Context, judgeID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.delete_judge")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/observability/judges/%s", judgeID))
 }
 
 func (c *MistralClient) UpdateJudge(judgeID string, req *JudgeRequest) (APIResponse, error) {
+	return c.UpdateJudgeCtx(context.Background(), judgeID, req)
+}
+
+func (c *MistralClient) UpdateJudgeCtx(ctx context.Context, judgeID string, req *JudgeRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.update_judge")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
-	return c.requestMap(http.MethodPut, judgeRequestMap(req), fmt.Sprintf("v1/observability/judges/%s", judgeID))
+	return c.requestMap(ctx, http.MethodPut, judgeRequestMap(req), fmt.Sprintf("v1/observability/judges/%s", judgeID))
 }
 
 func (c *MistralClient) JudgeConversation(judgeID string, req *JudgeConversationRequest) (APIResponse, error) {
+	return c.JudgeConversationCtx(context.Background(), judgeID, req)
+}
+
+func (c *MistralClient) JudgeConversationCtx(ctx context.Context, judgeID string, req *JudgeConversationRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.judge_conversation")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"messages": req.Messages, "properties": req.Properties})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/observability/judges/%s/live-judging", judgeID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/observability/judges/%s/live-judging", judgeID))
 }
 
 func (c *MistralClient) FetchDatasetRecord(datasetRecordID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/dataset-records/%s", datasetRecordID))
+	return c.FetchDatasetRecordCtx(context.Background(), datasetRecordID)
+}
+
+func (c *MistralClient) FetchDatasetRecordCtx(ctx context.Context, datasetRecordID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_dataset_record")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/dataset-records/%s", datasetRecordID))
 }
 
 func (c *MistralClient) DeleteDatasetRecord(datasetRecordID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/observability/dataset-records/%s", datasetRecordID))
+	return c.DeleteDatasetRecordCtx(context.Background(), datasetRecordID)
+}
+
+func (c *MistralClient) DeleteDatasetRecordCtx(ctx context.Context, datasetRecordID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.delete_dataset_record")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/observability/dataset-records/%s", datasetRecordID))
 }
 
 func (c *MistralClient) BulkDeleteDatasetRecords(datasetRecordIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"dataset_record_ids": datasetRecordIDs}, "v1/observability/dataset-records/bulk-delete")
+	return c.BulkDeleteDatasetRecordsCtx(context.Background(), datasetRecordIDs)
+}
+
+func (c *MistralClient) BulkDeleteDatasetRecordsCtx(ctx context.Context, datasetRecordIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.bulk_delete_dataset_records")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"dataset_record_ids": datasetRecordIDs}, "v1/observability/dataset-records/bulk-delete")
 }
 
 func (c *MistralClient) JudgeDatasetRecord(datasetRecordID string, judgeDefinition any) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"judge_definition": judgeDefinition}, fmt.Sprintf("v1/observability/dataset-records/%s/live-judging", datasetRecordID))
+	return c.JudgeDatasetRecordCtx(context.Background(), datasetRecordID, judgeDefinition)
+}
+
+func (c *MistralClient) JudgeDatasetRecordCtx(ctx context.Context, datasetRecordID string, judgeDefinition any) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.judge_dataset_record")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"judge_definition": judgeDefinition}, fmt.Sprintf("v1/observability/dataset-records/%s/live-judging", datasetRecordID))
 }
 
 func (c *MistralClient) UpdateDatasetRecordPayload(datasetRecordID string, payload any) (APIResponse, error) {
-	return c.requestMap(http.MethodPut, map[string]interface{}{"payload": payload}, fmt.Sprintf("v1/observability/dataset-records/%s/payload", datasetRecordID))
+	return c.UpdateDatasetRecordPayloadCtx(context.Background(), datasetRecordID, payload)
+}
+
+func (c *MistralClient) UpdateDatasetRecordPayloadCtx(ctx context.Context, datasetRecordID string, payload any) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.update_dataset_record_payload")
+	return c.requestMap(ctx, http.MethodPut, map[string]interface{}{"payload": payload}, fmt.Sprintf("v1/observability/dataset-records/%s/payload", datasetRecordID))
 }
 
 func (c *MistralClient) UpdateDatasetRecordProperties(datasetRecordID string, properties map[string]any) (APIResponse, error) {
-	return c.requestMap(http.MethodPut, map[string]interface{}{"properties": properties}, fmt.Sprintf("v1/observability/dataset-records/%s/properties", datasetRecordID))
+	return c.UpdateDatasetRecordPropertiesCtx(context.Background(), datasetRecordID, properties)
+}
+
+func (c *MistralClient) UpdateDatasetRecordPropertiesCtx(ctx context.Context, datasetRecordID string, properties map[string]any) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.update_dataset_record_properties")
+	return c.requestMap(ctx, http.MethodPut, map[string]interface{}{"properties": properties}, fmt.Sprintf("v1/observability/dataset-records/%s/properties", datasetRecordID))
 }
 
-func (c *MistralClient) listObservability(path string, params *ListObservabilityParams) (APIResponse, error) {
+func (c *MistralClient) listObservability(ctx context.Context, path string, params *ListObservabilityParams) (APIResponse, error) {
 	if params == nil {
 		params = &ListObservabilityParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"page_size": params.PageSize, "page": params.Page, "q": params.Q})
-	return c.requestMap(http.MethodGet, nil, appendQuery(path, query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(path, query))
 }
 
 func judgeRequestMap(req *JudgeRequest) map[string]interface{} {
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -21,6 +22,11 @@ type ObservabilityFieldOptionsParams struct {
 }
 
 func (c *MistralClient) SearchLogs(params *ObservabilitySearchParams) (APIResponse, error) {
+	return c.SearchLogsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) SearchLogsCtx(ctx context.Context, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_logs")
 	if params == nil {
 		params = &ObservabilitySearchParams{}
 	}
@@ -29,88 +35,163 @@ func (c *MistralClient) SearchLogs(params *ObservabilitySearchParams) (APIRespon
 		"search_expression": params.SearchExpression,
 		"order":             params.Order,
 	})
-	return c.requestMap(http.MethodPost, body, appendQuery("v1/observability/logs/search", query))
+	return c.requestMap(ctx, http.MethodPost, body, appendQuery("v1/observability/logs/search", query))
 }
 
 func (c *MistralClient) ListLogFields() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/observability/logs/fields")
+	return c.ListLogFieldsCtx(context.Background())
+}
+
+func (c *MistralClient) ListLogFieldsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_log_fields")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/observability/logs/fields")
 }
 
 func (c *MistralClient) FetchLogFieldOptions(fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
-	return c.observabilityFieldOptions(fmt.Sprintf("v1/observability/logs/fields/%s/options", fieldName), params)
+	return c.FetchLogFieldOptionsCtx(context.Background(), fieldName, params)
+}
+
+func (c *MistralClient) FetchLogFieldOptionsCtx(ctx context.Context, fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_log_field_options")
+	return c.observabilityFieldOptions(ctx, fmt.Sprintf("v1/observability/logs/fields/%s/options", fieldName), params)
 }
 
 func (c *MistralClient) SearchSpans(params *ObservabilitySearchParams) (APIResponse, error) {
-	return c.searchObservabilitySignals("v1/observability/spans/search", params)
+	return c.SearchSpansCtx(context.Background(), params)
+}
+
+func (c *MistralClient) SearchSpansCtx(ctx context.Context, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_spans")
+	return c.searchObservabilitySignals(ctx, "v1/observability/spans/search", params)
 }
 
 func (c *MistralClient) SearchSpanEvaluations(params *ObservabilitySearchParams) (APIResponse, error) {
-	return c.searchObservabilitySignals("v1/observability/spans/evaluations/search", params)
+	return c.SearchSpanEvaluationsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) SearchSpanEvaluationsCtx(ctx context.Context, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_span_evaluations")
+	return c.searchObservabilitySignals(ctx, "v1/observability/spans/evaluations/search", params)
 }
 
 func (c *MistralClient) SearchLatestSpanEvaluations(params *ObservabilitySearchParams) (APIResponse, error) {
-	return c.searchObservabilitySignals("v1/observability/spans/evaluations/search/latest", params)
+	return c.SearchLatestSpanEvaluationsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) SearchLatestSpanEvaluationsCtx(ctx context.Context, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_latest_span_evaluations")
+	return c.searchObservabilitySignals(ctx, "v1/observability/spans/evaluations/search/latest", params)
 }
 
 func (c *MistralClient) ListSpanFields() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/observability/spans/fields")
+	return c.ListSpanFieldsCtx(context.Background())
+}
+
+func (c *MistralClient) ListSpanFieldsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_span_fields")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/observability/spans/fields")
 }
 
 func (c *MistralClient) ListSpanEvaluationFields() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/observability/spans/evaluations/fields")
+	return c.ListSpanEvaluationFieldsCtx(context.Background())
+}
+
+func (c *MistralClient) ListSpanEvaluationFieldsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_span_evaluation_fields")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/observability/spans/evaluations/fields")
 }
 
 func (c *MistralClient) FetchSpanFieldOptions(fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
-	return c.observabilityFieldOptions(fmt.Sprintf("v1/observability/spans/fields/%s/options", fieldName), params)
+	return c.FetchSpanFieldOptionsCtx(context.Background(), fieldName, params)
+}
+
+func (c *MistralClient) FetchSpanFieldOptionsCtx(ctx context.Context, fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_span_field_options")
+	return c.observabilityFieldOptions(ctx, fmt.Sprintf("v1/observability/spans/fields/%s/options", fieldName), params)
 }
 
 func (c *MistralClient) FetchSpanEvaluationFieldOptions(fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
-	return c.observabilityFieldOptions(fmt.Sprintf("v1/observability/spans/evaluations/fields/%s/options", fieldName), params)
+	return c.FetchSpanEvaluationFieldOptionsCtx(context.Background(), fieldName, params)
+}
+
+func (c *MistralClient) FetchSpanEvaluationFieldOptionsCtx(ctx context.Context, fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_span_evaluation_field_options")
+	return c.observabilityFieldOptions(ctx, fmt.Sprintf("v1/observability/spans/evaluations/fields/%s/options", fieldName), params)
 }
 
 func (c *MistralClient) SearchTraces(params *ObservabilitySearchParams) (APIResponse, error) {
-	return c.searchObservabilitySignals("v1/observability/traces/search", params)
+	return c.SearchTracesCtx(context.Background(), params)
+}
+
+func (c *MistralClient) SearchTracesCtx(ctx context.Context, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.search_traces")
+	return c.searchObservabilitySignals(ctx, "v1/observability/traces/search", params)
 }
 
 func (c *MistralClient) ListTraceFields() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/observability/traces/fields")
+	return c.ListTraceFieldsCtx(context.Background())
+}
+
+func (c *MistralClient) ListTraceFieldsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.list_trace_fields")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/observability/traces/fields")
 }
 
 func (c *MistralClient) GetTraceByID(traceID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/traces/%s", traceID))
+	return c.GetTraceByIDCtx(context.Background(), traceID)
+}
+
+func (c *MistralClient) GetTraceByIDCtx(ctx context.Context, traceID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.get_trace_by_id")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/traces/%s", traceID))
 }
 
 func (c *MistralClient) GetTraceSpans(traceID string, params *ObservabilitySearchParams) (APIResponse, error) {
+	return c.GetTraceSpansCtx(context.Background(), traceID, params)
+}
+
+func (c *MistralClient) GetTraceSpansCtx(ctx context.Context, traceID string, params *ObservabilitySearchParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.get_trace_spans")
 	if params == nil {
 		params = &ObservabilitySearchParams{}
 	}
 	query := observabilitySearchQuery(params)
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/traces/%s/spans", traceID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/observability/traces/%s/spans", traceID), query))
 }
 
 func (c *MistralClient) FetchTraceFieldOptions(fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
-	return c.observabilityFieldOptions(fmt.Sprintf("v1/observability/traces/fields/%s/options", fieldName), params)
+	return c.FetchTraceFieldOptionsCtx(context.Background(), fieldName, params)
+}
+
+func (c *MistralClient) FetchTraceFieldOptionsCtx(ctx context.Context, fieldName string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.fetch_trace_field_options")
+	return c.observabilityFieldOptions(ctx, fmt.Sprintf("v1/observability/traces/fields/%s/options", fieldName), params)
 }
 
 func (c *MistralClient) GetSpanByID(traceID, spanID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/observability/traces/%s/spans/%s", traceID, spanID))
+	return c.GetSpanByIDCtx(context.Background(), traceID, spanID)
+}
+
+func (c *MistralClient) GetSpanByIDCtx(ctx context.Context, traceID, spanID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "observability.get_span_by_id")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/observability/traces/%s/spans/%s", traceID, spanID))
 }
 
-func (c *MistralClient) searchObservabilitySignals(path string, params *ObservabilitySearchParams) (APIResponse, error) {
+func (c *MistralClient) searchObservabilitySignals(ctx context.Context, path string, params *ObservabilitySearchParams) (APIResponse, error) {
 	if params == nil {
 		params = &ObservabilitySearchParams{}
 	}
 	query := observabilitySearchQuery(params)
 	body := optionalRequestMap(map[string]any{"search_expression": params.SearchExpression})
-	return c.requestMap(http.MethodPost, body, appendQuery(path, query))
+	return c.requestMap(ctx, http.MethodPost, body, appendQuery(path, query))
 }
 
-func (c *MistralClient) observabilityFieldOptions(path string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
+func (c *MistralClient) observabilityFieldOptions(ctx context.Context, path string, params *ObservabilityFieldOptionsParams) (APIResponse, error) {
 	if params == nil {
 		params = &ObservabilityFieldOptionsParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"from": params.From, "to": params.To})
-	return c.requestMap(http.MethodGet, nil, appendQuery(path, query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(path, query))
 }
 
 func observabilitySearchQuery(params *ObservabilitySearchParams) string {
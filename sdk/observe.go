@@ -0,0 +1,246 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxObservedBody caps how much of a JSON response is buffered to extract usage and finish reasons.
+const maxObservedBody = 1 << 20
+
+// Call describes one SDK API call, from the first attempt until its response body is closed.
+// Retries of the same call share one Call.
+type Call struct {
+	Operation     string         // SDK operation, e.g. "chat.completions"
+	Method        string         // HTTP method
+	Path          string         // URL path
+	ServerAddress string         // API host
+	ServerPort    int            // API port
+	Model         string         // Requested model, when the request carries one
+	Stream        bool           // True for SSE calls
+	Request       map[string]any // Decoded JSON payload; nil for multipart and bodiless requests
+	Start         time.Time
+}
+
+// CallResult is the outcome of a Call.
+type CallResult struct {
+	StatusCode    int    // 0 when no response was received
+	Err           error  // *MistralAPIError for error statuses, *MistralConnectionError for transport failures
+	RequestID     string // x-request-id response header
+	ResponseID    string
+	ResponseModel string
+	Usage         *UsageInfo
+	FinishReasons []string
+	Retries       int
+	Chunks        int           // Number of SSE events received
+	FirstChunk    time.Duration // Time to the first SSE event; 0 for non-streaming calls
+	Duration      time.Duration
+}
+
+// Observer is notified about every API call a client makes. Tracing and metrics integrations implement it.
+type Observer interface {
+	// CallStart is called before the first attempt. The returned context is used for every attempt,
+	// so middleware sees values the observer stores in it.
+	CallStart(ctx context.Context, call *Call) context.Context
+	// CallRetry is called before the client waits delay and retries a failed attempt.
+	CallRetry(ctx context.Context, call *Call, attempt *RetryAttempt, delay time.Duration)
+	// CallChunk is called for every SSE event of a streaming call.
+	CallChunk(ctx context.Context, call *Call, at time.Time)
+	// CallEnd is called once the call failed or its response body was consumed and closed.
+	CallEnd(ctx context.Context, call *Call, result *CallResult)
+}
+
+// WithObserver adds observer to the client. Observers are called in the order they were added.
+func WithObserver(observer Observer) Option {
+	return func(c *MistralClient) {
+		c.observers = append(c.observers, observer)
+	}
+}
+
+// observedCall tracks a Call for the client's observers.
+type observedCall struct {
+	observers []Observer
+	ctx       context.Context
+	call      *Call
+	result    CallResult
+	once      sync.Once
+}
+
+func (c *MistralClient) startCall(req *http.Request, kind RequestKind) *observedCall {
+	call := &Call{
+		Operation:     OperationName(req.Context()),
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		ServerAddress: req.URL.Hostname(),
+		Stream:        kind == RequestKindStream,
+		Start:         time.Now(),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		call.ServerPort = port
+	} else if req.URL.Scheme == "https" {
+		call.ServerPort = 443
+	} else {
+		call.ServerPort = 80
+	}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		if data, err := RequestBody(req); err == nil {
+			_ = json.Unmarshal(data, &call.Request)
+		}
+		call.Model, _ = call.Request["model"].(string)
+	}
+
+	ctx := req.Context()
+	for _, observer := range c.observers {
+		ctx = observer.CallStart(ctx, call)
+	}
+	return &observedCall{observers: c.observers, ctx: ctx, call: call}
+}
+
+func (o *observedCall) retry(attempt *RetryAttempt, delay time.Duration) {
+	o.result.Retries++
+	for _, observer := range o.observers {
+		observer.CallRetry(o.ctx, o.call, attempt, delay)
+	}
+}
+
+func (o *observedCall) chunk(at time.Time) {
+	if o.result.Chunks == 0 {
+		o.result.FirstChunk = at.Sub(o.call.Start)
+	}
+	o.result.Chunks++
+	for _, observer := range o.observers {
+		observer.CallChunk(o.ctx, o.call, at)
+	}
+}
+
+func (o *observedCall) end(err error) {
+	o.once.Do(func() {
+		if o.result.Err == nil {
+			o.result.Err = err
+		}
+		o.result.Duration = time.Since(o.call.Start)
+		for _, observer := range o.observers {
+			observer.CallEnd(o.ctx, o.call, &o.result)
+		}
+	})
+}
+
+// watch wraps resp.Body so the call ends, with usage and finish reasons, when the caller closes the body.
+func (o *observedCall) watch(resp *http.Response) {
+	o.result.StatusCode = resp.StatusCode
+	o.result.RequestID = resp.Header.Get("X-Request-Id")
+	body := &observedBody{ReadCloser: resp.Body, call: o, header: resp.Header}
+	body.stream = strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+	resp.Body = body
+}
+
+// observedBody passes a response body through, collecting the fields observers report.
+type observedBody struct {
+	io.ReadCloser
+	call   *observedCall
+	header http.Header
+	stream bool
+	buf    []byte // Current SSE line, or the buffered JSON body
+	over   bool   // JSON body exceeded maxObservedBody
+	done   bool
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.stream {
+		for _, ch := range p[:n] {
+			if ch != '\n' {
+				b.buf = append(b.buf, ch)
+				continue
+			}
+			b.line(bytes.TrimSpace(b.buf))
+			b.buf = b.buf[:0]
+		}
+	} else if !b.over {
+		if len(b.buf)+n > maxObservedBody {
+			b.over, b.buf = true, nil
+		} else {
+			b.buf = append(b.buf, p[:n]...)
+		}
+	}
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	b.finish()
+	return b.ReadCloser.Close()
+}
+
+func (b *observedBody) line(line []byte) {
+	data, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
+		return
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("[DONE]")) {
+		return
+	}
+	b.call.chunk(time.Now())
+	if apiErr := streamAPIError(data); apiErr != nil {
+		b.call.result.Err = apiErr
+		return
+	}
+	b.call.result.summarize(data)
+}
+
+func (b *observedBody) finish() {
+	if b.done {
+		return
+	}
+	b.done = true
+	result := &b.call.result
+	if !b.stream && !b.over && len(b.buf) > 0 {
+		if result.StatusCode >= 400 {
+			result.Err = newAPIError(result.StatusCode, b.header, b.buf)
+		} else {
+			result.summarize(b.buf)
+		}
+	} else if result.StatusCode >= 400 && result.Err == nil {
+		result.Err = NewMistralAPIError(http.StatusText(result.StatusCode), result.StatusCode, b.header)
+	}
+	b.call.end(nil)
+}
+
+// summarize merges the response ID, model, usage and finish reasons found in a response body or stream event.
+func (r *CallResult) summarize(data []byte) {
+	var payload struct {
+		ID      string     `json:"id"`
+		Model   string     `json:"model"`
+		Usage   *UsageInfo `json:"usage"`
+		Choices []struct {
+			FinishReason *string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	if json.Unmarshal(data, &payload) != nil {
+		return
+	}
+	if payload.ID != "" {
+		r.ResponseID = payload.ID
+	}
+	if payload.Model != "" {
+		r.ResponseModel = payload.Model
+	}
+	if payload.Usage != nil {
+		r.Usage = payload.Usage
+	}
+	for _, choice := range payload.Choices {
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			r.FinishReasons = append(r.FinishReasons, *choice.FinishReason)
+		}
+	}
+}
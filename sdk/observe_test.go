@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observerKey struct{}
+
+type recordingObserver struct {
+	mu      sync.Mutex
+	calls   []*Call
+	results []*CallResult
+	retries int
+	chunks  int
+}
+
+func (o *recordingObserver) CallStart(ctx context.Context, call *Call) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.calls = append(o.calls, call)
+	return context.WithValue(ctx, observerKey{}, call.Operation)
+}
+
+func (o *recordingObserver) CallRetry(ctx context.Context, call *Call, attempt *RetryAttempt, delay time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.retries++
+}
+
+func (o *recordingObserver) CallChunk(ctx context.Context, call *Call, at time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.chunks++
+}
+
+func (o *recordingObserver) CallEnd(ctx context.Context, call *Call, result *CallResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results = append(o.results, result)
+}
+
+func TestObserverSeesCallLifecycle(t *testing.T) {
+	attempts := 0
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-obs")
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	var seen []any
+	observer := &recordingObserver{}
+	policy := NewDefaultRetryPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	client := NewClient(
+		WithBaseURL(mock.Server.URL),
+		WithRetryPolicy(policy),
+		WithObserver(observer),
+		WithMiddleware(func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				seen = append(seen, req.Context().Value(observerKey{}))
+				return next(req)
+			}
+		}),
+	)
+	temperature := 0.2
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, &ChatRequestParams{Temperature: &temperature}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(observer.calls) != 1 || len(observer.results) != 1 {
+		t.Fatalf("expected one observed call for two attempts, got %d calls and %d results", len(observer.calls), len(observer.results))
+	}
+	call, result := observer.calls[0], observer.results[0]
+	if call.Operation != "chat.completions" || call.Model != "mistral-small-latest" || call.Stream || call.Request["temperature"] != 0.2 {
+		t.Errorf("unexpected call %+v", call)
+	}
+	if result.StatusCode != http.StatusOK || result.Retries != 1 || observer.retries != 1 || result.RequestID != "req-obs" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if result.ResponseID != "chat-123" || result.Usage == nil || result.Usage.TotalTokens != 30 || len(result.FinishReasons) != 1 || result.FinishReasons[0] != "stop" {
+		t.Errorf("expected response summary, got %+v", result)
+	}
+	if len(seen) != 2 || seen[0] != "chat.completions" || seen[1] != "chat.completions" {
+		t.Errorf("expected observer context on every attempt, got %v", seen)
+	}
+}
+
+func TestObserverStreamsAndErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/models" {
+			MockErrorResponse(http.StatusUnauthorized, "bad key").Write(w)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"model\":\"mistral-small-latest\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"s-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"},\"finish_reason\":\"length\"}],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2,\"total_tokens\":5}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	observer := &recordingObserver{}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1), WithObserver(observer))
+
+	stream, err := client.ChatStreamChan("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range stream {
+	}
+	if _, err := client.ListModels(); err == nil {
+		t.Fatal("expected error")
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.results) != 2 {
+		t.Fatalf("expected two finished calls, got %d", len(observer.results))
+	}
+	streamResult := observer.results[0]
+	if !observer.calls[0].Stream || streamResult.Chunks != 2 || observer.chunks != 2 || streamResult.FirstChunk <= 0 {
+		t.Errorf("unexpected stream result %+v", streamResult)
+	}
+	if streamResult.Usage == nil || streamResult.Usage.CompletionTokens != 2 || streamResult.ResponseModel != "mistral-small-latest" || len(streamResult.FinishReasons) != 1 {
+		t.Errorf("expected stream summary, got %+v", streamResult)
+	}
+
+	var apiErr *MistralAPIError
+	if !errors.As(observer.results[1].Err, &apiErr) || apiErr.HTTPStatus != http.StatusUnauthorized || apiErr.Message != "bad key" {
+		t.Errorf("expected typed error in result, got %v", observer.results[1].Err)
+	}
+}
+
+func TestObserverConnectionError(t *testing.T) {
+	observer := &recordingObserver{}
+	client := NewClient(WithBaseURL("http://127.0.0.1:1"), WithMaxRetries(1), WithObserver(observer))
+	if _, err := client.ListModels(); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var connErr *MistralConnectionError
+	if len(observer.results) != 1 || !errors.As(observer.results[0].Err, &connErr) || observer.results[0].StatusCode != 0 {
+		t.Errorf("expected a connection error result, got %+v", observer.results)
+	}
+}
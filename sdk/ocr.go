@@ -1,7 +1,7 @@
 package sdk
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 )
 
@@ -23,6 +23,8 @@ type OCRRequest struct {
 	ImageMinSize             *int            `json:"image_min_size,omitempty"`
 	BboxAnnotationFormat     *ResponseFormat `json:"bbox_annotation_format,omitempty"`
 	DocumentAnnotationFormat *ResponseFormat `json:"document_annotation_format,omitempty"`
+	BboxAnnotationSchema     *JSONSchema     `json:"-"` // Requests json_schema bounding box annotations, overriding BboxAnnotationFormat
+	DocumentAnnotationSchema *JSONSchema     `json:"-"` // Requests a json_schema document annotation, overriding DocumentAnnotationFormat
 }
 
 // OCRPageDimensions represents the dimensions of a page
@@ -33,9 +35,10 @@ type OCRPageDimensions struct {
 
 // OCRImageObject represents an extracted image from the document
 type OCRImageObject struct {
-	ImageURL    *string   `json:"image_url,omitempty"`
-	ImageBase64 *string   `json:"image_base64,omitempty"`
-	BBox        []float64 `json:"bbox,omitempty"` // [x, y, width, height]
+	ImageURL        *string   `json:"image_url,omitempty"`
+	ImageBase64     *string   `json:"image_base64,omitempty"`
+	BBox            []float64 `json:"bbox,omitempty"`             // [x, y, width, height]
+	ImageAnnotation *string   `json:"image_annotation,omitempty"` // JSON matching BboxAnnotationSchema
 }
 
 // OCRTableFormat represents the format of extracted tables
@@ -73,11 +76,12 @@ type OCRUsageInfo struct {
 
 // OCRResponse represents the response from OCR processing
 type OCRResponse struct {
-	ID     string          `json:"id"`
-	Object string          `json:"object"`
-	Model  string          `json:"model"`
-	Pages  []OCRPageObject `json:"pages"`
-	Usage  *OCRUsageInfo   `json:"usage,omitempty"`
+	ID                 string          `json:"id"`
+	Object             string          `json:"object"`
+	Model              string          `json:"model"`
+	Pages              []OCRPageObject `json:"pages"`
+	Usage              *OCRUsageInfo   `json:"usage,omitempty"`
+	DocumentAnnotation *string         `json:"document_annotation,omitempty"` // JSON matching DocumentAnnotationSchema
 }
 
 // ProcessOCR processes a document with OCR
@@ -89,6 +93,12 @@ type OCRResponse struct {
 //
 // Returns OCR results with extracted text and images
 func (c *MistralClient) ProcessOCR(model string, document OCRDocument, params *OCRRequest) (*OCRResponse, error) {
+	return c.ProcessOCRCtx(context.Background(), model, document, params)
+}
+
+// ProcessOCRCtx is like ProcessOCR but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ProcessOCRCtx(ctx context.Context, model string, document OCRDocument, params *OCRRequest) (*OCRResponse, error) {
+	ctx = withOperation(ctx, "ocr.process")
 	if params == nil {
 		params = &OCRRequest{}
 	}
@@ -128,20 +138,15 @@ func (c *MistralClient) ProcessOCR(model string, document OCRDocument, params *O
 			"type": *params.DocumentAnnotationFormat,
 		}
 	}
-
-	response, err := c.request(http.MethodPost, reqMap, "v1/ocr", false, nil)
-	if err != nil {
-		return nil, err
+	if params.BboxAnnotationSchema != nil {
+		reqMap["bbox_annotation_format"] = params.BboxAnnotationSchema.ResponseFormat()
 	}
-
-	respData, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
+	if params.DocumentAnnotationSchema != nil {
+		reqMap["document_annotation_format"] = params.DocumentAnnotationSchema.ResponseFormat()
 	}
 
 	var ocrResponse OCRResponse
-	err = mapToStruct(respData, &ocrResponse)
-	if err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, reqMap, "v1/ocr", &ocrResponse); err != nil {
 		return nil, err
 	}
 
@@ -150,24 +155,42 @@ func (c *MistralClient) ProcessOCR(model string, document OCRDocument, params *O
 
 // ProcessOCRFromURL is a convenience method for processing a document from a URL
 func (c *MistralClient) ProcessOCRFromURL(model string, url string, params *OCRRequest) (*OCRResponse, error) {
+	return c.ProcessOCRFromURLCtx(context.Background(), model, url, params)
+}
+
+// ProcessOCRFromURLCtx is like ProcessOCRFromURL but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ProcessOCRFromURLCtx(ctx context.Context, model string, url string, params *OCRRequest) (*OCRResponse, error) {
+	ctx = withOperation(ctx, "ocr.process")
 	document := OCRDocument{
 		URL: &url,
 	}
-	return c.ProcessOCR(model, document, params)
+	return c.ProcessOCRCtx(ctx, model, document, params)
 }
 
 // ProcessOCRFromBase64 is a convenience method for processing a base64-encoded document
 func (c *MistralClient) ProcessOCRFromBase64(model string, base64Data string, params *OCRRequest) (*OCRResponse, error) {
+	return c.ProcessOCRFromBase64Ctx(context.Background(), model, base64Data, params)
+}
+
+// ProcessOCRFromBase64Ctx is like ProcessOCRFromBase64 but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ProcessOCRFromBase64Ctx(ctx context.Context, model string, base64Data string, params *OCRRequest) (*OCRResponse, error) {
+	ctx = withOperation(ctx, "ocr.process")
 	document := OCRDocument{
 		Base64: &base64Data,
 	}
-	return c.ProcessOCR(model, document, params)
+	return c.ProcessOCRCtx(ctx, model, document, params)
 }
 
 // ProcessOCRFromFileID is a convenience method for processing an uploaded file
 func (c *MistralClient) ProcessOCRFromFileID(model string, fileID string, params *OCRRequest) (*OCRResponse, error) {
+	return c.ProcessOCRFromFileIDCtx(context.Background(), model, fileID, params)
+}
+
+// ProcessOCRFromFileIDCtx is like ProcessOCRFromFileID but uses ctx for cancellation and deadlines.
+func (c *MistralClient) ProcessOCRFromFileIDCtx(ctx context.Context, model string, fileID string, params *OCRRequest) (*OCRResponse, error) {
+	ctx = withOperation(ctx, "ocr.process")
 	document := OCRDocument{
 		FileID: &fileID,
 	}
-	return c.ProcessOCR(model, document, params)
+	return c.ProcessOCRCtx(ctx, model, document, params)
 }
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Option configures a MistralClient created with NewClient.
+type Option func(*MistralClient)
+
+// NewClient creates a new Mistral API client configured by opts.
+// Without options it uses the default endpoint, MISTRAL_API_KEY from the environment,
+// DefaultMaxRetries and DefaultTimeout. The returned client shares one http.Client
+// across all calls so connections are kept alive between requests.
+func NewClient(opts ...Option) *MistralClient {
+	c := &MistralClient{
+		endpoint:   Endpoint,
+		maxRetries: DefaultMaxRetries,
+		timeout:    DefaultTimeout,
+		userAgent:  UserAgent,
+		headers:    http.Header{},
+		metrics:    NoopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.apiKey == "" {
+		c.apiKey = os.Getenv("MISTRAL_API_KEY")
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: c.timeout}
+	}
+	if c.transport != nil {
+		httpClient := *c.httpClient
+		httpClient.Transport = c.transport
+		c.httpClient = &httpClient
+	}
+	if c.retryPolicy == nil {
+		c.retryPolicy = NewDefaultRetryPolicy(c.maxRetries)
+	}
+	if c.rateLimiter != nil {
+		// Inside user middleware, so the limiter sees the final payload of every attempt. Only
+		// logging runs inside it, so that logged latency excludes limiter waits.
+		c.middleware = append(c.middleware, c.rateLimiter.Middleware())
+	}
+	if c.logger != nil {
+		c.middleware = append(c.middleware, c.logMiddleware)
+	}
+	if c.metrics == nil {
+		c.metrics = NoopMetrics{}
+	}
+	if _, noop := c.metrics.(NoopMetrics); !noop {
+		c.observers = append(c.observers, metricsObserver{metrics: c.metrics})
+	}
+	return c
+}
+
+// WithAPIKey sets the API key sent as a bearer token.
+func WithAPIKey(apiKey string) Option {
+	return func(c *MistralClient) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBaseURL sets the API endpoint, e.g. CodestralEndpoint or a self-hosted gateway.
+func WithBaseURL(baseURL string) Option {
+	return func(c *MistralClient) {
+		c.endpoint = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the http.Client used for every request. Its Timeout takes precedence over WithTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *MistralClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for every request, e.g. for proxies or mTLS.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *MistralClient) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout sets the per-request timeout of the default http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *MistralClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithMaxRetries sets the number of attempts made by the default retry policy.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *MistralClient) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *MistralClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithOnRetry registers hook, called before every retry with the failed attempt and the chosen delay.
+func WithOnRetry(hook RetryHook) Option {
+	return func(c *MistralClient) {
+		c.onRetry = hook
+	}
+}
+
+// WithHeaders adds headers sent with every request.
+func WithHeaders(headers http.Header) Option {
+	return func(c *MistralClient) {
+		for key, values := range headers {
+			for _, value := range values {
+				c.headers.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the SDK User-Agent.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *MistralClient) {
+		if suffix != "" {
+			c.userAgent = UserAgent + " " + suffix
+		}
+	}
+}
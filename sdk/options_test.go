@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+type countingTransport struct {
+	calls int
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return t.next.RoundTrip(req)
+}
+
+type countingRetryPolicy struct {
+	calls int
+}
+
+func (p *countingRetryPolicy) ShouldRetry(attempt *RetryAttempt) (time.Duration, bool) {
+	p.calls++
+	return 0, attempt.Attempt < 2 && attempt.Response != nil && attempt.Response.StatusCode == http.StatusServiceUnavailable
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	os.Setenv("MISTRAL_API_KEY", "env-key")
+	defer os.Unsetenv("MISTRAL_API_KEY")
+
+	client := NewClient()
+	if client.apiKey != "env-key" {
+		t.Errorf("expected API key from environment, got %q", client.apiKey)
+	}
+	if client.endpoint != Endpoint {
+		t.Errorf("expected default endpoint, got %s", client.endpoint)
+	}
+	if client.maxRetries != DefaultMaxRetries {
+		t.Errorf("expected default max retries, got %d", client.maxRetries)
+	}
+	if client.httpClient == nil || client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("expected shared http client with default timeout, got %+v", client.httpClient)
+	}
+	if client.userAgent != UserAgent {
+		t.Errorf("expected default user agent, got %s", client.userAgent)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	client := NewClient(
+		WithAPIKey("key"),
+		WithBaseURL("https://gateway.example.com/"),
+		WithHTTPClient(httpClient),
+		WithMaxRetries(2),
+	)
+	if client.apiKey != "key" {
+		t.Errorf("expected API key to be set, got %q", client.apiKey)
+	}
+	if client.endpoint != "https://gateway.example.com" {
+		t.Errorf("expected trailing slash to be trimmed, got %s", client.endpoint)
+	}
+	if client.httpClient != httpClient {
+		t.Error("expected the provided http client to be used")
+	}
+	if policy, ok := client.retryPolicy.(*DefaultRetryPolicy); !ok || policy.MaxRetries != 2 {
+		t.Errorf("expected default retry policy with 2 attempts, got %+v", client.retryPolicy)
+	}
+}
+
+func TestNewClientHeadersAndTransport(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("expected tenant header, got %q", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != UserAgent+" my-app/1.0" {
+			t.Errorf("unexpected user agent %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer key" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+		MockListModelsResponse().Write(w)
+	})
+	defer mock.Close()
+
+	transport := &countingTransport{next: http.DefaultTransport}
+	client := NewClient(
+		WithAPIKey("key"),
+		WithBaseURL(mock.Server.URL),
+		WithTransport(transport),
+		WithHeaders(http.Header{"X-Tenant": []string{"acme"}}),
+		WithUserAgentSuffix("my-app/1.0"),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListModels(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := client.GetSignedURL("file-1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.calls != 3 {
+		t.Errorf("expected custom transport to serve 3 calls, got %d", transport.calls)
+	}
+}
+
+func TestNewClientRetryPolicy(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+	})
+	defer mock.Close()
+
+	policy := &countingRetryPolicy{}
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(policy))
+	if _, err := client.ListModels(); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(mock.Requests) != 3 {
+		t.Errorf("expected 3 attempts, got %d", len(mock.Requests))
+	}
+	if policy.calls != 3 {
+		t.Errorf("expected policy to be consulted 3 times, got %d", policy.calls)
+	}
+}
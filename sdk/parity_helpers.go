@@ -1,14 +1,13 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +15,7 @@ type APIResponse map[string]any
 
 type StreamEvent struct {
 	Type  string         `json:"type,omitempty"`
+	ID    string         `json:"id,omitempty"` // SSE event ID, usable as LastEventID to resume the stream
 	Data  map[string]any `json:"data,omitempty"`
 	Error error          `json:"-"`
 }
@@ -139,8 +139,8 @@ func appendQuery(path string, query string) string {
 	return path + "?" + query
 }
 
-func (c *MistralClient) requestMap(method string, body map[string]interface{}, path string) (APIResponse, error) {
-	response, err := c.request(method, body, path, false, nil)
+func (c *MistralClient) requestMap(ctx context.Context, method string, body map[string]interface{}, path string) (APIResponse, error) {
+	response, err := c.request(ctx, method, body, path, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -151,20 +151,18 @@ func (c *MistralClient) requestMap(method string, body map[string]interface{}, p
 	return APIResponse(respData), nil
 }
 
-func (c *MistralClient) requestBytes(method string, path string, accept string) ([]byte, error) {
-	req, err := http.NewRequest(method, c.endpoint+"/"+path, nil)
+func (c *MistralClient) requestBytes(ctx context.Context, method string, path string, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/"+path, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(req.Header)
 	if accept != "" {
 		req.Header.Set("Accept", accept)
 	}
-	req.Header.Set("User-Agent", UserAgent)
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.doWithRetry(req, RequestKindDefault)
 	if err != nil {
-		return nil, NewMistralConnectionError(err.Error())
+		return nil, err
 	}
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
@@ -172,44 +170,49 @@ func (c *MistralClient) requestBytes(method string, path string, accept string)
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, NewMistralAPIError(string(body), resp.StatusCode, resp.Header)
+		return nil, newAPIError(resp.StatusCode, resp.Header, body)
 	}
 	return body, nil
 }
 
-func parseGenericStream(body io.ReadCloser) <-chan StreamEvent {
-	out := make(chan StreamEvent)
-	go func() {
-		defer close(out)
-		defer body.Close()
-		reader := bufio.NewReader(body)
-		for {
-			line, readErr := reader.ReadBytes('\n')
-			if readErr == io.EOF {
-				break
-			}
-			if readErr != nil {
-				out <- StreamEvent{Error: fmt.Errorf("error reading stream response: %w", readErr)}
-				return
-			}
-			if bytes.Equal(line, []byte("\n")) || !bytes.HasPrefix(line, []byte("data: ")) {
-				continue
-			}
-			jsonLine := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data: ")))
-			if bytes.Equal(jsonLine, []byte("[DONE]")) {
-				break
-			}
-			var payload map[string]any
-			if err := json.Unmarshal(jsonLine, &payload); err != nil {
-				out <- StreamEvent{Error: fmt.Errorf("error decoding stream event: %w", err)}
-				continue
-			}
-			event := StreamEvent{Data: payload}
-			if eventType, ok := payload["type"].(string); ok {
-				event.Type = eventType
-			}
-			out <- event
+// streamEvents opens the GET event stream at path. When resumable, a stream that drops after an
+// event with an ID reopens with last_event_id set to that ID.
+func (c *MistralClient) streamEvents(ctx context.Context, path string, resumable bool) (*Stream[StreamEvent], error) {
+	resp, err := c.call(ctx, http.MethodGet, nil, path, true)
+	if err != nil {
+		return nil, err
+	}
+	var resume func(ctx context.Context, lastEventID string) (io.ReadCloser, error)
+	if resumable {
+		resume = func(ctx context.Context, lastEventID string) (io.ReadCloser, error) {
+			resp, err := c.call(ctx, http.MethodGet, nil, withQueryValue(path, "last_event_id", lastEventID), true)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Body, nil
 		}
-	}()
-	return out
+	}
+	return newStream(ctx, resp.Body, c.streamIdleTimeout, decodeStreamEvent, resume), nil
+}
+
+// streamEventsChan is streamEvents for the methods that return a channel.
+func (c *MistralClient) streamEventsChan(ctx context.Context, path string, resumable bool) (<-chan StreamEvent, error) {
+	stream, err := c.streamEvents(ctx, path, resumable)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) StreamEvent { return StreamEvent{Error: err} }), nil
+}
+
+// withQueryValue returns path with the query parameter key set to value.
+func withQueryValue(path, key, value string) string {
+	base, rawQuery, _ := strings.Cut(path, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	query.Set(key, value)
+	return base + "?" + query.Encode()
+}
+
+func decodeStreamEvent(event *SSEEvent) (StreamEvent, error) {
+	eventType, payload, err := decodeEventPayload(event)
+	return StreamEvent{Type: eventType, ID: event.ID, Data: payload}, err
 }
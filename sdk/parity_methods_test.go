@@ -349,7 +349,7 @@ func TestNewParityBinaryAndStreamEndpoints(t *testing.T) {
 	if data, err := client.GetVoiceSampleAudio("voice"); err != nil || string(data) != "wav" {
 		t.Fatalf("unexpected voice sample result: %q %v", string(data), err)
 	}
-	if events, err := client.SpeechStream(&SpeechRequest{Input: "hello"}); err != nil {
+	if events, err := client.SpeechStreamChan(&SpeechRequest{Input: "hello"}); err != nil {
 		t.Fatalf("unexpected speech stream error: %v", err)
 	} else {
 		for range events {
@@ -363,7 +363,7 @@ func TestNewParityBinaryAndStreamEndpoints(t *testing.T) {
 			break
 		}
 	}
-	if events, err := client.StreamWorkflowExecution("exec", nil); err != nil {
+	if events, err := client.StreamWorkflowExecutionChan("exec", nil); err != nil {
 		t.Fatalf("unexpected workflow execution stream error: %v", err)
 	} else {
 		for range events {
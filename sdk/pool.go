@@ -0,0 +1,411 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PoolStrategy selects which healthy backend serves the next call.
+type PoolStrategy string
+
+const (
+	PoolRoundRobin    PoolStrategy = "round_robin"     // Smooth weighted round-robin
+	PoolLeastInFlight PoolStrategy = "least_in_flight" // Fewest in-flight calls relative to weight
+)
+
+// Circuit breaker defaults used by NewClientPool.
+const (
+	DefaultPoolFailureThreshold = 3
+	DefaultPoolCooldown         = 30 * time.Second
+)
+
+// ErrNoHealthyBackend is returned when every backend able to serve a call is ejected or has already failed it.
+var ErrNoHealthyBackend = errors.New("no healthy backend available")
+
+// PoolMember configures one backend of a ClientPool.
+type PoolMember struct {
+	Name     string         // Used in PoolStatus; defaults to the endpoint
+	APIKey   string         // API key for this backend
+	Endpoint string         // Defaults to Endpoint; use CodestralEndpoint for Codestral keys
+	Weight   int            // Relative share of traffic; defaults to 1
+	Options  []Option       // Extra client options, applied after the pool defaults
+	Client   *MistralClient // Prebuilt client; when set, APIKey, Endpoint and Options are ignored
+}
+
+// PoolStatus reports the state of one pool backend.
+type PoolStatus struct {
+	Name     string
+	InFlight int
+	Failures int  // Consecutive failures counted by the circuit breaker
+	Ejected  bool // True while the circuit breaker keeps the backend out of rotation
+}
+
+// PoolOption configures a ClientPool created with NewClientPool.
+type PoolOption func(*ClientPool)
+
+// WithPoolStrategy sets how the pool picks a backend. The default is PoolRoundRobin.
+func WithPoolStrategy(strategy PoolStrategy) PoolOption {
+	return func(p *ClientPool) {
+		p.strategy = strategy
+	}
+}
+
+// WithCircuitBreaker ejects a backend for cooldown after threshold consecutive 429, 5xx or connection failures.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) PoolOption {
+	return func(p *ClientPool) {
+		p.threshold = threshold
+		p.cooldown = cooldown
+	}
+}
+
+// ClientPool spreads Chat, Embeddings and FIM calls over several keys and endpoints,
+// failing over to the next backend when one is rate limited, erroring or unreachable.
+//
+// Backends on CodestralEndpoint only receive FIM calls and chat calls for codestral models,
+// and FIM calls prefer them over La Plateforme backends.
+type ClientPool struct {
+	mu        sync.Mutex
+	backends  []*poolBackend
+	strategy  PoolStrategy
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+type poolBackend struct {
+	name      string
+	client    *MistralClient
+	weight    int
+	codestral bool
+
+	inFlight  int
+	current   int // Smooth weighted round-robin counter
+	failures  int
+	openUntil time.Time
+	probing   bool // A half-open trial call is in flight
+}
+
+// NewClientPool builds a pool from members. Member clients default to a single attempt per call,
+// so failures move to the next backend instead of being retried in place.
+func NewClientPool(members []PoolMember, opts ...PoolOption) (*ClientPool, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("client pool needs at least one member")
+	}
+	p := &ClientPool{
+		strategy:  PoolRoundRobin,
+		threshold: DefaultPoolFailureThreshold,
+		cooldown:  DefaultPoolCooldown,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	for _, member := range members {
+		client := member.Client
+		if client == nil {
+			endpoint := member.Endpoint
+			if endpoint == "" {
+				endpoint = Endpoint
+			}
+			options := append([]Option{WithAPIKey(member.APIKey), WithBaseURL(endpoint), WithMaxRetries(1)}, member.Options...)
+			client = NewClient(options...)
+		}
+		name := member.Name
+		if name == "" {
+			name = client.endpoint
+		}
+		weight := member.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		p.backends = append(p.backends, &poolBackend{
+			name:      name,
+			client:    client,
+			weight:    weight,
+			codestral: strings.TrimRight(client.endpoint, "/") == CodestralEndpoint,
+		})
+	}
+	return p, nil
+}
+
+// Status returns a snapshot of every backend in the pool.
+func (p *ClientPool) Status() []PoolStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	status := make([]PoolStatus, 0, len(p.backends))
+	for _, b := range p.backends {
+		status = append(status, PoolStatus{
+			Name:     b.name,
+			InFlight: b.inFlight,
+			Failures: b.failures,
+			Ejected:  now.Before(b.openUntil),
+		})
+	}
+	return status
+}
+
+// Do runs fn against pool backends until one succeeds or fails with an error that another backend would not fix.
+// operation and model drive routing the same way as the built-in methods, e.g. "fim.completions" and "codestral-latest".
+func (p *ClientPool) Do(ctx context.Context, operation string, model string, fn func(ctx context.Context, client *MistralClient) error) error {
+	_, err := p.do(ctx, operation, model, fn, false)
+	return err
+}
+
+// do is Do, returning the backend that served the call. When hold is set, a successful call stays
+// in flight on that backend until the caller passes it to done.
+func (p *ClientPool) do(ctx context.Context, operation string, model string, fn func(ctx context.Context, client *MistralClient) error, hold bool) (*poolBackend, error) {
+	tried := map[*poolBackend]bool{}
+	var lastErr error
+	for {
+		backend := p.acquire(operation, model, tried)
+		if backend == nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w: %w", ErrNoHealthyBackend, lastErr)
+			}
+			return nil, ErrNoHealthyBackend
+		}
+		tried[backend] = true
+
+		err := fn(ctx, backend.client)
+		failover := err != nil && ctx.Err() == nil && isFailoverError(err)
+		p.release(backend, failover, hold && err == nil)
+		if !failover {
+			return backend, err
+		}
+		lastErr = err
+	}
+}
+
+// doStream is do for stream methods: the backend stays in flight until the stream is closed or ends.
+func doStream[T any](ctx context.Context, p *ClientPool, operation string, model string, open func(ctx context.Context, client *MistralClient) (*Stream[T], error)) (*Stream[T], error) {
+	var stream *Stream[T]
+	backend, err := p.do(ctx, operation, model, func(ctx context.Context, client *MistralClient) (err error) {
+		stream, err = open(ctx, client)
+		return err
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	stream.onClose = func() { p.done(backend) }
+	return stream, nil
+}
+
+// Chat sends a chat completion to the next healthy backend.
+func (p *ClientPool) Chat(model string, messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	return p.ChatCtx(context.Background(), model, messages, params)
+}
+
+// ChatCtx is like Chat but uses ctx for cancellation and deadlines.
+func (p *ClientPool) ChatCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, error) {
+	var resp *ChatCompletionResponse
+	err := p.Do(ctx, "chat.completions", model, func(ctx context.Context, client *MistralClient) (err error) {
+		resp, err = client.ChatCtx(ctx, model, messages, params)
+		return err
+	})
+	return resp, err
+}
+
+// ChatStream opens a chat completion stream on the next healthy backend. Failover only happens before the stream starts.
+func (p *ClientPool) ChatStream(model string, messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], error) {
+	return p.ChatStreamCtx(context.Background(), model, messages, params)
+}
+
+// ChatStreamCtx is like ChatStream but uses ctx for cancellation and deadlines.
+func (p *ClientPool) ChatStreamCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], error) {
+	return doStream(ctx, p, "chat.completions.stream", model, func(ctx context.Context, client *MistralClient) (*Stream[ChatCompletionStreamResponse], error) {
+		return client.ChatStreamCtx(ctx, model, messages, params)
+	})
+}
+
+// ChatStreamChan is like ChatStream but delivers the chunks, and an error that ends the stream, on a channel.
+func (p *ClientPool) ChatStreamChan(model string, messages []ChatMessage, params *ChatRequestParams) (<-chan ChatCompletionStreamResponse, error) {
+	stream, err := p.ChatStream(model, messages, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(context.Background(), func(err error) ChatCompletionStreamResponse { return ChatCompletionStreamResponse{Error: err} }), nil
+}
+
+// Embeddings creates embeddings on the next healthy La Plateforme backend.
+func (p *ClientPool) Embeddings(model string, input []string) (*EmbeddingResponse, error) {
+	return p.EmbeddingsCtx(context.Background(), model, input)
+}
+
+// EmbeddingsCtx is like Embeddings but uses ctx for cancellation and deadlines.
+func (p *ClientPool) EmbeddingsCtx(ctx context.Context, model string, input []string) (*EmbeddingResponse, error) {
+	var resp *EmbeddingResponse
+	err := p.Do(ctx, "embeddings.create", model, func(ctx context.Context, client *MistralClient) (err error) {
+		resp, err = client.EmbeddingsCtx(ctx, model, input)
+		return err
+	})
+	return resp, err
+}
+
+// FIM sends a fill-in-the-middle completion, preferring Codestral backends.
+func (p *ClientPool) FIM(params *FIMRequestParams) (*FIMCompletionResponse, error) {
+	return p.FIMCtx(context.Background(), params)
+}
+
+// FIMCtx is like FIM but uses ctx for cancellation and deadlines.
+func (p *ClientPool) FIMCtx(ctx context.Context, params *FIMRequestParams) (*FIMCompletionResponse, error) {
+	var resp *FIMCompletionResponse
+	err := p.Do(ctx, "fim.completions", fimModel(params), func(ctx context.Context, client *MistralClient) (err error) {
+		resp, err = client.FIMCtx(ctx, params)
+		return err
+	})
+	return resp, err
+}
+
+// FIMStream opens a fill-in-the-middle stream, preferring Codestral backends. Failover only happens before the stream starts.
+func (p *ClientPool) FIMStream(params *FIMRequestParams) (*Stream[FIMCompletionStreamResponse], error) {
+	return p.FIMStreamCtx(context.Background(), params)
+}
+
+// FIMStreamCtx is like FIMStream but uses ctx for cancellation and deadlines.
+func (p *ClientPool) FIMStreamCtx(ctx context.Context, params *FIMRequestParams) (*Stream[FIMCompletionStreamResponse], error) {
+	return doStream(ctx, p, "fim.completions.stream", fimModel(params), func(ctx context.Context, client *MistralClient) (*Stream[FIMCompletionStreamResponse], error) {
+		return client.FIMStreamCtx(ctx, params)
+	})
+}
+
+// FIMStreamChan is like FIMStream but delivers the chunks, and an error that ends the stream, on a channel.
+func (p *ClientPool) FIMStreamChan(params *FIMRequestParams) (<-chan FIMCompletionStreamResponse, error) {
+	stream, err := p.FIMStream(params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(context.Background(), func(err error) FIMCompletionStreamResponse { return FIMCompletionStreamResponse{Error: err} }), nil
+}
+
+func fimModel(params *FIMRequestParams) string {
+	if params == nil {
+		return ""
+	}
+	return params.Model
+}
+
+// acquire picks the next backend for a call and marks it in flight, or returns nil when none is left.
+func (p *ClientPool) acquire(operation string, model string, tried map[*poolBackend]bool) *poolBackend {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	var preferred, fallback []*poolBackend
+	for _, b := range p.backends {
+		if tried[b] || !b.serves(operation, model) || !b.available(now) {
+			continue
+		}
+		if strings.HasPrefix(operation, "fim.") && !b.codestral {
+			fallback = append(fallback, b)
+		} else {
+			preferred = append(preferred, b)
+		}
+	}
+	candidates := preferred
+	if len(candidates) == 0 {
+		candidates = fallback
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	chosen := p.choose(candidates)
+	chosen.inFlight++
+	if !chosen.openUntil.IsZero() {
+		chosen.probing = true
+	}
+	return chosen
+}
+
+func (p *ClientPool) choose(candidates []*poolBackend) *poolBackend {
+	if p.strategy == PoolLeastInFlight {
+		var best *poolBackend
+		bestLoad := math.Inf(1)
+		for _, b := range candidates {
+			if load := float64(b.inFlight) / float64(b.weight); load < bestLoad {
+				best, bestLoad = b, load
+			}
+		}
+		return best
+	}
+
+	total := 0
+	var best *poolBackend
+	for _, b := range candidates {
+		b.current += b.weight
+		total += b.weight
+		if best == nil || b.current > best.current {
+			best = b
+		}
+	}
+	best.current -= total
+	return best
+}
+
+// release records the outcome of a call for the circuit breaker. Unless held, the call is no
+// longer in flight.
+func (p *ClientPool) release(b *poolBackend, failed bool, held bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !held {
+		b.inFlight--
+	}
+	wasProbing := b.probing
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	b.failures++
+	if wasProbing || b.failures >= p.threshold {
+		b.openUntil = p.now().Add(p.cooldown)
+	}
+}
+
+// done ends a call held in flight by release.
+func (p *ClientPool) done(b *poolBackend) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b.inFlight--
+}
+
+// serves reports whether the backend's endpoint supports operation for model.
+func (b *poolBackend) serves(operation string, model string) bool {
+	if !b.codestral {
+		return true
+	}
+	switch {
+	case strings.HasPrefix(operation, "fim."):
+		return true
+	case strings.HasPrefix(operation, "chat."):
+		return strings.HasPrefix(model, "codestral")
+	}
+	return false
+}
+
+// available reports whether the circuit is closed, or half-open with no trial call in flight.
+func (b *poolBackend) available(now time.Time) bool {
+	if b.openUntil.IsZero() {
+		return true
+	}
+	return !now.Before(b.openUntil) && !b.probing
+}
+
+// isFailoverError reports errors another backend may not hit: rate limits, server errors and connection failures.
+func isFailoverError(err error) bool {
+	var apiErr *MistralAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus == http.StatusTooManyRequests || apiErr.HTTPStatus >= 500
+	}
+	var connErr *MistralConnectionError
+	return errors.As(err, &connErr)
+}
@@ -0,0 +1,216 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientPoolWeightedRoundRobin(t *testing.T) {
+	primary := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) { MockChatResponse().Write(w) })
+	defer primary.Close()
+	secondary := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) { MockChatResponse().Write(w) })
+	defer secondary.Close()
+
+	pool, err := NewClientPool([]PoolMember{
+		{Name: "primary", Endpoint: primary.Server.URL, Weight: 2},
+		{Name: "secondary", Endpoint: secondary.Server.URL},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		if _, err := pool.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(primary.Requests) != 4 || len(secondary.Requests) != 2 {
+		t.Errorf("expected a 4/2 split, got %d/%d", len(primary.Requests), len(secondary.Requests))
+	}
+}
+
+func TestClientPoolFailoverAndCircuitBreaker(t *testing.T) {
+	failing := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusServiceUnavailable, "down").Write(w)
+	})
+	defer failing.Close()
+	healthy := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) { MockEmbeddingsResponse().Write(w) })
+	defer healthy.Close()
+
+	now := time.Unix(0, 0)
+	pool, err := NewClientPool([]PoolMember{
+		{Name: "failing", Endpoint: failing.Server.URL},
+		{Name: "healthy", Endpoint: healthy.Server.URL},
+	}, WithCircuitBreaker(2, time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pool.now = func() time.Time { return now }
+
+	for i := 0; i < 6; i++ {
+		if _, err := pool.Embeddings("mistral-embed", []string{"a"}); err != nil {
+			t.Fatalf("call %d: expected transparent failover, got %v", i, err)
+		}
+	}
+	if len(failing.Requests) != 2 {
+		t.Errorf("expected the failing backend to be ejected after 2 failures, got %d requests", len(failing.Requests))
+	}
+	if status := pool.Status(); !status[0].Ejected || status[1].Ejected {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := pool.Embeddings("mistral-embed", []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := pool.Embeddings("mistral-embed", []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(failing.Requests) != 3 {
+		t.Errorf("expected a single half-open probe after the cooldown, got %d requests", len(failing.Requests))
+	}
+	if !pool.Status()[0].Ejected {
+		t.Error("expected the failed probe to eject the backend again")
+	}
+}
+
+func TestClientPoolDoesNotFailOverClientErrors(t *testing.T) {
+	first := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusBadRequest, "bad request").Write(w)
+	})
+	defer first.Close()
+	second := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) { MockChatResponse().Write(w) })
+	defer second.Close()
+
+	pool, _ := NewClientPool([]PoolMember{{Endpoint: first.Server.URL}, {Endpoint: second.Server.URL}})
+	_, err := pool.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	var apiErr *MistralAPIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadRequest {
+		t.Fatalf("expected the 400 to be returned as is, got %v", err)
+	}
+	if len(second.Requests) != 0 {
+		t.Errorf("expected no failover for client errors, got %d requests", len(second.Requests))
+	}
+}
+
+func TestClientPoolAllBackendsFailing(t *testing.T) {
+	limited := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+	})
+	defer limited.Close()
+
+	pool, _ := NewClientPool([]PoolMember{{Endpoint: limited.Server.URL}, {Endpoint: limited.Server.URL}})
+	_, err := pool.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if !errors.Is(err, ErrNoHealthyBackend) || !IsRateLimited(err) {
+		t.Fatalf("expected ErrNoHealthyBackend wrapping the rate limit error, got %v", err)
+	}
+	if len(limited.Requests) != 2 {
+		t.Errorf("expected each backend to be tried once, got %d requests", len(limited.Requests))
+	}
+}
+
+func TestClientPoolRoutesCodestral(t *testing.T) {
+	platform := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/fim/completions" {
+			MockFIMResponse().Write(w)
+			return
+		}
+		MockEmbeddingsResponse().Write(w)
+	})
+	defer platform.Close()
+	codestral := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) { MockFIMResponse().Write(w) })
+	defer codestral.Close()
+
+	target, _ := url.Parse(codestral.Server.URL)
+	pool, _ := NewClientPool([]PoolMember{
+		{Name: "platform", Endpoint: platform.Server.URL},
+		{Name: "codestral", Endpoint: CodestralEndpoint, Options: []Option{WithTransport(&rewriteTransport{target: target})}},
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.FIM(&FIMRequestParams{Model: "codestral-latest", Prompt: "def f():"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := pool.Embeddings("mistral-embed", []string{"a"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(codestral.Requests) != 3 {
+		t.Errorf("expected every FIM call on the Codestral backend, got %d", len(codestral.Requests))
+	}
+	if len(platform.Requests) != 3 {
+		t.Errorf("expected every embeddings call on La Plateforme, got %d", len(platform.Requests))
+	}
+}
+
+func TestClientPoolLeastInFlight(t *testing.T) {
+	pool := &ClientPool{strategy: PoolLeastInFlight}
+	busy := &poolBackend{name: "busy", weight: 1, inFlight: 3}
+	heavy := &poolBackend{name: "heavy", weight: 4, inFlight: 4}
+	idle := &poolBackend{name: "idle", weight: 1, inFlight: 2}
+
+	if got := pool.choose([]*poolBackend{busy, heavy, idle}); got != heavy {
+		t.Errorf("expected the backend with the lowest load per weight, got %s", got.name)
+	}
+}
+
+func TestClientPoolStreamsStayInFlightUntilClosed(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\ndata: [DONE]\n\n")
+	}
+	first := NewMockHTTPServer(t, handler)
+	defer first.Close()
+	second := NewMockHTTPServer(t, handler)
+	defer second.Close()
+
+	pool, err := NewClientPool([]PoolMember{
+		{Name: "first", Endpoint: first.Server.URL},
+		{Name: "second", Endpoint: second.Server.URL},
+	}, WithPoolStrategy(PoolLeastInFlight))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inFlight := func() (int, int) {
+		status := pool.Status()
+		return status[0].InFlight, status[1].InFlight
+	}
+
+	open, err := pool.ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, b := inFlight(); a != 1 || b != 0 {
+		t.Fatalf("expected the open stream to be in flight, got %d/%d", a, b)
+	}
+	drained, err := pool.ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Requests) != 1 {
+		t.Errorf("expected the second stream on the idle backend, got %d requests", len(second.Requests))
+	}
+	for drained.Next() {
+	}
+	if a, b := inFlight(); a != 1 || b != 0 {
+		t.Errorf("expected a finished stream to leave flight, got %d/%d", a, b)
+	}
+	open.Close()
+	open.Close()
+	if a, b := inFlight(); a != 0 || b != 0 {
+		t.Errorf("expected a closed stream to leave flight once, got %d/%d", a, b)
+	}
+}
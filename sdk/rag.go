@@ -1,7 +1,7 @@
 package sdk
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -54,10 +54,20 @@ type SearchIndexResponse struct {
 }
 
 func (c *MistralClient) ListIngestionPipelineConfigurations() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/rag/ingestion_pipeline_configurations")
+	return c.ListIngestionPipelineConfigurationsCtx(context.Background())
+}
+
+func (c *MistralClient) ListIngestionPipelineConfigurationsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.list_ingestion_pipeline_configurations")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/rag/ingestion_pipeline_configurations")
 }
 
 func (c *MistralClient) RegisterIngestionPipelineConfiguration(req *RegisterIngestionPipelineConfigurationRequest) (APIResponse, error) {
+	return c.RegisterIngestionPipelineConfigurationCtx(context.Background(), req)
+}
+
+func (c *MistralClient) RegisterIngestionPipelineConfigurationCtx(ctx context.Context, req *RegisterIngestionPipelineConfigurationRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.register_ingestion_pipeline_configuration")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -65,10 +75,15 @@ func (c *MistralClient) RegisterIngestionPipelineConfiguration(req *RegisterInge
 		"name":                 req.Name,
 		"pipeline_composition": req.PipelineComposition,
 	})
-	return c.requestMap(http.MethodPut, body, "v1/rag/ingestion_pipeline_configurations")
+	return c.requestMap(ctx, http.MethodPut, body, "v1/rag/ingestion_pipeline_configurations")
 }
 
 func (c *MistralClient) UpdateIngestionPipelineRunInfo(id string, req *UpdateIngestionPipelineRunInfoRequest) (APIResponse, error) {
+	return c.UpdateIngestionPipelineRunInfoCtx(context.Background(), id, req)
+}
+
+func (c *MistralClient) UpdateIngestionPipelineRunInfoCtx(ctx context.Context, id string, req *UpdateIngestionPipelineRunInfoRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.update_ingestion_pipeline_run_info")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -76,30 +91,37 @@ func (c *MistralClient) UpdateIngestionPipelineRunInfo(id string, req *UpdateIng
 		"execution_time": req.ExecutionTime,
 		"chunks_count":   req.ChunksCount,
 	})
-	return c.requestMap(http.MethodPut, body, fmt.Sprintf("v1/rag/ingestion_pipeline_configurations/%s/run_info", id))
+	return c.requestMap(ctx, http.MethodPut, body, fmt.Sprintf("v1/rag/ingestion_pipeline_configurations/%s/run_info", id))
 }
 
 func (c *MistralClient) ListSearchIndexes() ([]SearchIndexResponse, error) {
-	return c.GetSearchIndexSummaries()
+	return c.ListSearchIndexesCtx(context.Background())
+}
+
+func (c *MistralClient) ListSearchIndexesCtx(ctx context.Context) ([]SearchIndexResponse, error) {
+	ctx = withOperation(ctx, "rag.list_search_indexes")
+	return c.GetSearchIndexSummariesCtx(ctx)
 }
 
 func (c *MistralClient) GetSearchIndexSummaries() ([]SearchIndexResponse, error) {
-	response, err := c.request(http.MethodGet, nil, "v1/rag/indexes/summary", false, nil)
-	if err != nil {
-		return nil, err
-	}
-	data, err := json.Marshal(response)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetSearchIndexSummariesCtx(context.Background())
+}
+
+func (c *MistralClient) GetSearchIndexSummariesCtx(ctx context.Context) ([]SearchIndexResponse, error) {
+	ctx = withOperation(ctx, "rag.get_search_index_summaries")
 	var indexes []SearchIndexResponse
-	if err := json.Unmarshal(data, &indexes); err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, "v1/rag/indexes/summary", &indexes); err != nil {
 		return nil, err
 	}
 	return indexes, nil
 }
 
 func (c *MistralClient) RegisterSearchIndex(req *RegisterSearchIndexRequest) (*SearchIndexResponse, error) {
+	return c.RegisterSearchIndexCtx(context.Background(), req)
+}
+
+func (c *MistralClient) RegisterSearchIndexCtx(ctx context.Context, req *RegisterSearchIndexRequest) (*SearchIndexResponse, error) {
+	ctx = withOperation(ctx, "rag.register_search_index")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -108,26 +130,28 @@ func (c *MistralClient) RegisterSearchIndex(req *RegisterSearchIndexRequest) (*S
 		"index":  req.Index,
 		"status": req.Status,
 	})
-	response, err := c.request(http.MethodPut, body, "v1/rag/indexes", false, nil)
-	if err != nil {
-		return nil, err
-	}
-	data, err := json.Marshal(response)
-	if err != nil {
-		return nil, err
-	}
 	var index SearchIndexResponse
-	if err := json.Unmarshal(data, &index); err != nil {
+	if err := c.requestInto(ctx, http.MethodPut, body, "v1/rag/indexes", &index); err != nil {
 		return nil, err
 	}
 	return &index, nil
 }
 
 func (c *MistralClient) UnregisterSearchIndex(indexID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/rag/indexes/index/%s", indexID))
+	return c.UnregisterSearchIndexCtx(context.Background(), indexID)
+}
+
+func (c *MistralClient) UnregisterSearchIndexCtx(ctx context.Context, indexID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.unregister_search_index")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/rag/indexes/index/%s", indexID))
 }
 
 func (c *MistralClient) UpdateSearchIndexMetrics(indexID string, req *UpdateSearchIndexMetricsRequest) (APIResponse, error) {
+	return c.UpdateSearchIndexMetricsCtx(context.Background(), indexID, req)
+}
+
+func (c *MistralClient) UpdateSearchIndexMetricsCtx(ctx context.Context, indexID string, req *UpdateSearchIndexMetricsRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.update_search_index_metrics")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -137,33 +161,58 @@ func (c *MistralClient) UpdateSearchIndexMetrics(indexID string, req *UpdateSear
 		"schema_metrics": req.SchemaMetrics,
 		"clear_metrics":  req.ClearMetrics,
 	})
-	return c.requestMap(http.MethodPut, body, fmt.Sprintf("v1/rag/indexes/index/%s/metrics", indexID))
+	return c.requestMap(ctx, http.MethodPut, body, fmt.Sprintf("v1/rag/indexes/index/%s/metrics", indexID))
 }
 
 func (c *MistralClient) GetSearchIndexDetail(indexID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/detail", indexID))
+	return c.GetSearchIndexDetailCtx(context.Background(), indexID)
+}
+
+func (c *MistralClient) GetSearchIndexDetailCtx(ctx context.Context, indexID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.get_search_index_detail")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/detail", indexID))
 }
 
 func (c *MistralClient) SetSearchIndexSummary(indexID string, req *SearchIndexSummaryRequest) (APIResponse, error) {
+	return c.SetSearchIndexSummaryCtx(context.Background(), indexID, req)
+}
+
+func (c *MistralClient) SetSearchIndexSummaryCtx(ctx context.Context, indexID string, req *SearchIndexSummaryRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.set_search_index_summary")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
-	return c.requestMap(http.MethodPut, map[string]interface{}{"summary": req.Summary}, fmt.Sprintf("v1/rag/indexes/index/%s/summary_field", indexID))
+	return c.requestMap(ctx, http.MethodPut, map[string]interface{}{"summary": req.Summary}, fmt.Sprintf("v1/rag/indexes/index/%s/summary_field", indexID))
 }
 
 func (c *MistralClient) GetSearchIndexSchemaDetail(indexID, schemaID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/detail", indexID, schemaID))
+	return c.GetSearchIndexSchemaDetailCtx(context.Background(), indexID, schemaID)
+}
+
+func (c *MistralClient) GetSearchIndexSchemaDetailCtx(ctx context.Context, indexID, schemaID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.get_search_index_schema_detail")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/detail", indexID, schemaID))
 }
 
 func (c *MistralClient) SetSearchIndexSchemaSummary(indexID, schemaID string, req *SearchIndexSummaryRequest) (APIResponse, error) {
+	return c.SetSearchIndexSchemaSummaryCtx(context.Background(), indexID, schemaID, req)
+}
+
+func (c *MistralClient) SetSearchIndexSchemaSummaryCtx(ctx context.Context, indexID, schemaID string, req *SearchIndexSummaryRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.set_search_index_schema_summary")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
-	return c.requestMap(http.MethodPut, map[string]interface{}{"summary": req.Summary}, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/summary_field", indexID, schemaID))
+	return c.requestMap(ctx, http.MethodPut, map[string]interface{}{"summary": req.Summary}, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/summary_field", indexID, schemaID))
 }
 
 func (c *MistralClient) GetSearchIndexSchemaFile(indexID, schemaID string) ([]byte, error) {
-	response, err := c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/file", indexID, schemaID))
+	return c.GetSearchIndexSchemaFileCtx(context.Background(), indexID, schemaID)
+}
+
+func (c *MistralClient) GetSearchIndexSchemaFileCtx(ctx context.Context, indexID, schemaID string) ([]byte, error) {
+	ctx = withOperation(ctx, "rag.get_search_index_schema_file")
+	response, err := c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/file", indexID, schemaID))
 	if err != nil {
 		return nil, err
 	}
@@ -172,5 +221,10 @@ func (c *MistralClient) GetSearchIndexSchemaFile(indexID, schemaID string) ([]by
 }
 
 func (c *MistralClient) GetSearchIndexSchemaFileResponse(indexID, schemaID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/file", indexID, schemaID))
+	return c.GetSearchIndexSchemaFileResponseCtx(context.Background(), indexID, schemaID)
+}
+
+func (c *MistralClient) GetSearchIndexSchemaFileResponseCtx(ctx context.Context, indexID, schemaID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "rag.get_search_index_schema_file_response")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/rag/indexes/index/%s/schemas/schema/%s/file", indexID, schemaID))
 }
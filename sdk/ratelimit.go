@@ -0,0 +1,389 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// RateLimit is a per-minute budget. Zero values mean unlimited.
+type RateLimit struct {
+	RequestsPerMinute int
+	TokensPerMinute   int
+}
+
+// TokenEstimator estimates the prompt tokens of a JSON request payload sent to model.
+type TokenEstimator func(model string, payload map[string]any) int
+
+// RateLimiter paces inference calls so they stay within requests-per-minute and tokens-per-minute budgets.
+//
+// Each model gets its own budget. Calls reserve capacity in arrival order, so goroutines sharing a
+// client queue fairly instead of all firing and retrying at once. Token reservations start from an
+// estimate of the prompt size plus max_tokens and are corrected from the usage returned by the API.
+// Budgets also adapt to x-ratelimit-* response headers when the API sends them.
+type RateLimiter struct {
+	mu       sync.Mutex
+	limit    RateLimit
+	models   map[string]RateLimit
+	buckets  map[string]*rateBuckets
+	estimate TokenEstimator
+	now      func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter applying limit to every model without a model-specific limit.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limit:    limit,
+		models:   map[string]RateLimit{},
+		buckets:  map[string]*rateBuckets{},
+		estimate: estimatePromptTokens,
+		now:      time.Now,
+	}
+}
+
+// WithRateLimiter paces inference calls with limiter. A limiter may be shared by several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *MistralClient) {
+		c.rateLimiter = limiter
+	}
+}
+
+// SetModelLimit sets the budget for model, replacing the default limit.
+func (l *RateLimiter) SetModelLimit(model string, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.models[model] = limit
+	if b, ok := l.buckets[model]; ok {
+		b.requests.setCapacity(float64(limit.RequestsPerMinute), l.now())
+		b.tokens.setCapacity(float64(limit.TokensPerMinute), l.now())
+	}
+}
+
+// SetTokenEstimator replaces the default characters-per-token prompt estimate.
+func (l *RateLimiter) SetTokenEstimator(estimate TokenEstimator) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.estimate = estimate
+}
+
+// Wait blocks until one request and tokens tokens are available for model, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, model string, tokens int) error {
+	l.mu.Lock()
+	b := l.bucketsFor(model)
+	now := l.now()
+	delay := b.requests.reserve(1, now)
+	if tokenDelay := b.tokens.reserve(float64(tokens), now); tokenDelay > delay {
+		delay = tokenDelay
+	}
+	l.mu.Unlock()
+
+	if err := sleepCtx(ctx, delay); err != nil {
+		l.mu.Lock()
+		b.requests.refund(1)
+		b.tokens.refund(float64(tokens))
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Middleware returns the limiter as client middleware. WithRateLimiter installs it automatically.
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			model, payload := inferencePayload(req)
+			if model == "" {
+				return next(req)
+			}
+
+			l.mu.Lock()
+			estimated := l.estimate(model, payload)
+			l.mu.Unlock()
+			reserved := estimated
+			if maxTokens, ok := payload["max_tokens"].(float64); ok {
+				reserved += int(maxTokens)
+			}
+			if err := l.Wait(req.Context(), model, reserved); err != nil {
+				return nil, err
+			}
+
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+			l.observeHeaders(model, resp.Header)
+			if resp.StatusCode >= 400 {
+				// Rejected calls do not consume tokens.
+				l.settle(model, reserved, 0)
+				return resp, nil
+			}
+			if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+				resp.Body = &usageReader{ReadCloser: resp.Body, done: func(used int, ok bool) {
+					if !ok {
+						// Closed early or without a usage block: charge the prompt estimate only.
+						used = estimated
+					}
+					l.settle(model, reserved, used)
+				}}
+				return resp, nil
+			}
+
+			if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+				// Binary bodies, such as speech audio, carry no usage block.
+				l.settle(model, reserved, estimated)
+				return resp, nil
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				l.settle(model, reserved, estimated)
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			used, ok := usageTokens(body)
+			if !ok {
+				used = estimated
+			}
+			l.settle(model, reserved, used)
+			return resp, nil
+		}
+	}
+}
+
+// settle corrects a token reservation once the actual usage is known.
+func (l *RateLimiter) settle(model string, reserved int, used int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bucketsFor(model).tokens.refund(float64(reserved - used))
+}
+
+// observeHeaders tightens the local budget with the limits reported by the API.
+func (l *RateLimiter) observeHeaders(model string, header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucketsFor(model)
+	now := l.now()
+	if limit, ok := headerInt(header, "X-Ratelimit-Limit-Requests", "X-Ratelimit-Limit-Req-Minute"); ok {
+		b.requests.setCapacity(float64(limit), now)
+	}
+	if limit, ok := headerInt(header, "X-Ratelimit-Limit-Tokens", "X-Ratelimit-Limit-Tokens-Minute", "X-Ratelimitbysize-Limit-Minute"); ok {
+		b.tokens.setCapacity(float64(limit), now)
+	}
+	if remaining, ok := headerInt(header, "X-Ratelimit-Remaining-Requests", "X-Ratelimit-Remaining-Req-Minute"); ok {
+		b.requests.cap(float64(remaining), now)
+	}
+	if remaining, ok := headerInt(header, "X-Ratelimit-Remaining-Tokens", "X-Ratelimit-Remaining-Tokens-Minute", "X-Ratelimitbysize-Remaining-Minute"); ok {
+		b.tokens.cap(float64(remaining), now)
+	}
+}
+
+func (l *RateLimiter) bucketsFor(model string) *rateBuckets {
+	b, ok := l.buckets[model]
+	if !ok {
+		limit, ok := l.models[model]
+		if !ok {
+			limit = l.limit
+		}
+		now := l.now()
+		b = &rateBuckets{
+			requests: newTokenBucket(float64(limit.RequestsPerMinute), now),
+			tokens:   newTokenBucket(float64(limit.TokensPerMinute), now),
+		}
+		l.buckets[model] = b
+	}
+	return b
+}
+
+type rateBuckets struct {
+	requests *tokenBucket
+	tokens   *tokenBucket
+}
+
+// tokenBucket refills capacity units per minute. Reservations may drive it negative;
+// the deficit is the queue ahead of the next caller.
+type tokenBucket struct {
+	capacity  float64 // 0 means unlimited
+	available float64
+	last      time.Time
+}
+
+func newTokenBucket(capacity float64, now time.Time) *tokenBucket {
+	return &tokenBucket{capacity: capacity, available: capacity, last: now}
+}
+
+func (b *tokenBucket) advance(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.available = math.Min(b.capacity, b.available+b.capacity*elapsed.Minutes())
+		b.last = now
+	}
+}
+
+// reserve takes n units and returns how long the caller must wait before using them.
+func (b *tokenBucket) reserve(n float64, now time.Time) time.Duration {
+	if b.capacity <= 0 {
+		return 0
+	}
+	b.advance(now)
+	b.available -= math.Min(n, b.capacity)
+	if b.available >= 0 {
+		return 0
+	}
+	return time.Duration(-b.available / b.capacity * float64(time.Minute))
+}
+
+func (b *tokenBucket) refund(n float64) {
+	if b.capacity > 0 {
+		b.available = math.Min(b.capacity, b.available+n)
+	}
+}
+
+func (b *tokenBucket) setCapacity(capacity float64, now time.Time) {
+	b.advance(now)
+	if b.capacity <= 0 {
+		b.available = capacity
+	}
+	b.capacity = capacity
+	b.available = math.Min(b.available, capacity)
+}
+
+// cap lowers the available units to remaining when the server knows of less capacity than we do.
+func (b *tokenBucket) cap(remaining float64, now time.Time) {
+	if b.capacity <= 0 {
+		return
+	}
+	b.advance(now)
+	b.available = math.Min(b.available, remaining)
+}
+
+// usageReader passes an SSE body through and reports the usage block of the stream once it is closed.
+type usageReader struct {
+	io.ReadCloser
+	line  []byte
+	used  int
+	ok    bool
+	usage []byte // Data of the last event with a usage block
+	once  sync.Once
+	done  func(used int, ok bool)
+}
+
+func (r *usageReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	for _, ch := range p[:n] {
+		if ch != '\n' {
+			r.line = append(r.line, ch)
+			continue
+		}
+		if data, found := bytes.CutPrefix(r.line, []byte("data:")); found && bytes.Contains(data, []byte(`"usage"`)) {
+			data = bytes.TrimPrefix(data, []byte(" "))
+			if used, ok := usageTokens(data); ok {
+				r.used, r.ok = used, true
+				r.usage = append(r.usage[:0], data...)
+			}
+		}
+		r.line = r.line[:0]
+	}
+	if err == io.EOF {
+		r.finish()
+	}
+	return n, err
+}
+
+func (r *usageReader) Close() error {
+	r.finish()
+	return r.ReadCloser.Close()
+}
+
+func (r *usageReader) finish() {
+	r.once.Do(func() { r.done(r.used, r.ok) })
+}
+
+// inferencePayload returns the model and decoded JSON body of an inference request, or "" for other requests.
+func inferencePayload(req *http.Request) (string, map[string]any) {
+	if req.Method != http.MethodPost || !isInferencePath(req.URL.Path) {
+		return "", nil
+	}
+	return jsonPayload(req)
+}
+
+// jsonPayload returns the model and decoded body of a JSON request, or "" for other requests.
+func jsonPayload(req *http.Request) (string, map[string]any) {
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "", nil
+	}
+	data, err := RequestBody(req)
+	if err != nil {
+		return "", nil
+	}
+	var payload map[string]any
+	if json.Unmarshal(data, &payload) != nil {
+		return "", nil
+	}
+	model, _ := payload["model"].(string)
+	return model, payload
+}
+
+func usageTokens(data []byte) (int, bool) {
+	var body struct {
+		Usage *UsageInfo `json:"usage"`
+	}
+	if json.Unmarshal(data, &body) != nil || body.Usage == nil {
+		return 0, false
+	}
+	if body.Usage.TotalTokens > 0 {
+		return body.Usage.TotalTokens, true
+	}
+	return body.Usage.PromptTokens + body.Usage.CompletionTokens, true
+}
+
+// estimatePromptTokens approximates prompt tokens at four characters per token,
+// plus a small per-message overhead for chat formatting.
+func estimatePromptTokens(model string, payload map[string]any) int {
+	chars := 0
+	for _, key := range []string{"messages", "input", "inputs", "prompt", "suffix"} {
+		chars += textLength(payload[key])
+	}
+	tokens := int(math.Ceil(float64(chars) / 4))
+	if messages, ok := payload["messages"].([]any); ok {
+		tokens += 4 * len(messages)
+	}
+	return tokens
+}
+
+func textLength(value any) int {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v)
+	case []any:
+		n := 0
+		for _, item := range v {
+			n += textLength(item)
+		}
+		return n
+	case map[string]any:
+		n := 0
+		for _, item := range v {
+			n += textLength(item)
+		}
+		return n
+	}
+	return 0
+}
+
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n >= 0 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,248 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketQueuesReservationsInOrder(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(60, now)
+
+	for i := 0; i < 60; i++ {
+		if delay := bucket.reserve(1, now); delay != 0 {
+			t.Fatalf("reservation %d should fit the burst, got delay %v", i, delay)
+		}
+	}
+	if delay := bucket.reserve(1, now); delay != time.Second {
+		t.Errorf("expected the first queued caller to wait 1s, got %v", delay)
+	}
+	if delay := bucket.reserve(1, now); delay != 2*time.Second {
+		t.Errorf("expected the second queued caller to wait 2s, got %v", delay)
+	}
+	if delay := bucket.reserve(1, now.Add(3*time.Second)); delay != 0 {
+		t.Errorf("expected refilled capacity after 3s, got delay %v", delay)
+	}
+}
+
+func TestRateLimiterCorrectsEstimateFromUsage(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{RequestsPerMinute: 100, TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	var estimated string
+	limiter.SetTokenEstimator(func(model string, payload map[string]any) int {
+		estimated = model
+		return 500
+	})
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+	resp, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Usage.TotalTokens != 30 {
+		t.Errorf("expected the response body to survive usage tracking, got %+v", resp.Usage)
+	}
+	if estimated != "mistral-small-latest" {
+		t.Errorf("expected estimator to be called for the chat model, got %q", estimated)
+	}
+
+	b := limiter.buckets["mistral-small-latest"]
+	if b.tokens.available != 970 {
+		t.Errorf("expected 30 tokens to be charged after correction, got %v available", b.tokens.available)
+	}
+	if b.requests.available != 99 {
+		t.Errorf("expected one request to be charged, got %v available", b.requests.available)
+	}
+}
+
+func TestRateLimiterCorrectsStreamsOnClose(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":7,\"total_tokens\":12}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+
+	stream, err := client.ChatStreamChan("mistral-small-latest", []ChatMessage{UserMessage("hello there")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range stream {
+	}
+
+	if got := limiter.buckets["mistral-small-latest"].tokens.available; got != 988 {
+		t.Errorf("expected 12 tokens to be charged from stream usage, got %v available", got)
+	}
+}
+
+func TestRateLimiterSettlesStreamsWithoutUsage(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"}}]}\n\n")
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	limiter.SetTokenEstimator(func(model string, payload map[string]any) int { return 20 })
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+
+	stream, err := client.ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hello there")}, &ChatRequestParams{MaxTokens: IntPtr(300)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.Next()
+	stream.Close()
+
+	if got := limiter.buckets["mistral-small-latest"].tokens.available; got != 980 {
+		t.Errorf("expected max_tokens to be refunded and the estimate charged, got %v available", got)
+	}
+}
+
+func TestRateLimiterReadsUsageWithoutDataSpace(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data:{\"id\":\"1\",\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":7,\"total_tokens\":12}}\r\n\r\n")
+		_, _ = io.WriteString(w, "data:[DONE]\r\n\r\n")
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+	stream, err := client.ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hello there")}, &ChatRequestParams{MaxTokens: IntPtr(300)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for stream.Next() {
+	}
+	if got := limiter.buckets["mistral-small-latest"].tokens.available; got != 988 {
+		t.Errorf("expected 12 tokens to be charged from stream usage, got %v available", got)
+	}
+}
+
+func TestRateLimiterReturnsBodyReadErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "1000")
+		_, _ = io.WriteString(w, `{"id":"cmpl`)
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	limiter.SetTokenEstimator(func(model string, payload map[string]any) int { return 20 })
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter), WithMaxRetries(1))
+	var connErr *MistralConnectionError
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); !errors.As(err, &connErr) || !strings.Contains(err.Error(), "EOF") {
+		t.Errorf("expected the truncated body to fail the call as a connection error, got %v", err)
+	}
+	if got := limiter.buckets["mistral-small-latest"].tokens.available; got != 980 {
+		t.Errorf("expected the estimate to be charged, got %v available", got)
+	}
+}
+
+func TestRateLimiterPassesBinaryBodiesThrough(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	limiter.SetTokenEstimator(func(model string, payload map[string]any) int { return 20 })
+
+	audio := io.NopCloser(strings.NewReader("ID3 audio"))
+	handler := limiter.Middleware()(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"audio/mpeg"}}, Body: audio}, nil
+	})
+	req, _ := http.NewRequest(http.MethodPost, "https://api.mistral.ai/v1/audio/speech", bytes.NewReader([]byte(`{"model":"voxtral-mini-tts","input":"hi","max_tokens":100}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := handler(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Body != audio {
+		t.Error("expected the audio body to be passed through unread")
+	}
+	if got := limiter.buckets["voxtral-mini-tts"].tokens.available; got != 980 {
+		t.Errorf("expected the estimate to be charged, got %v available", got)
+	}
+}
+
+func TestRateLimiterAdaptsToHeaders(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Limit-Req-Minute", "10")
+		w.Header().Set("X-Ratelimit-Remaining-Req-Minute", "0")
+		MockEmbeddingsResponse().Write(w)
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{})
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+	if _, err := client.Embeddings("mistral-embed", []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "mistral-embed", 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the exhausted server budget to block, got %v", err)
+	}
+	if got := limiter.buckets["mistral-embed"].requests.available; got != 0 {
+		t.Errorf("expected cancelled reservation to be refunded, got %v available", got)
+	}
+}
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockFIMResponse().Write(w)
+	})
+	defer mock.Close()
+
+	limiter := NewRateLimiter(RateLimit{})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+	limiter.SetModelLimit("codestral-latest", RateLimit{RequestsPerMinute: 6000})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRateLimiter(limiter))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.FIM(&FIMRequestParams{Model: "codestral-latest", Prompt: "def f():"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := limiter.buckets["codestral-latest"].requests.available; got != 5992 {
+		t.Errorf("expected 8 requests to be charged, got %v available", got)
+	}
+}
+
+func TestEstimatePromptTokens(t *testing.T) {
+	payload := map[string]any{
+		"messages": []any{map[string]any{"role": "user", "content": "0123456789ab"}},
+	}
+	// "user" + 12 characters = 16 characters = 4 tokens, plus 4 tokens of message overhead.
+	if got := estimatePromptTokens("m", payload); got != 8 {
+		t.Errorf("expected 8 tokens, got %d", got)
+	}
+}
@@ -73,14 +73,17 @@ type RealtimeConnection struct {
 }
 
 func (c *MistralClient) RealtimeTranscriptionConnect(ctx context.Context, model string, params *RealtimeTranscriptionConnectParams) (*RealtimeConnection, error) {
+	ctx = withOperation(ctx, "audio.realtime.transcriptions")
 	wsURL, err := c.realtimeTranscriptionURL(model)
 	if err != nil {
 		return nil, err
 	}
 
 	headers := http.Header{}
-	headers.Set("Authorization", "Bearer "+c.apiKey)
-	headers.Set("User-Agent", UserAgent)
+	c.setHeaders(headers)
+	if _, err := c.authorize(ctx, headers); err != nil {
+		return nil, err
+	}
 	if params != nil {
 		for key, values := range params.Headers {
 			for _, value := range values {
@@ -89,10 +92,24 @@ func (c *MistralClient) RealtimeTranscriptionConnect(ctx context.Context, model
 		}
 	}
 
-	conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{
-		HTTPHeader: headers,
-	})
+	// The websocket library rejects clients with a Timeout; ctx bounds the dial instead.
+	httpClient := *c.client()
+	httpClient.Timeout = 0
+	if len(c.middleware) > 0 {
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = &middlewareTransport{client: c, next: next}
+	}
+	dialOptions := &websocket.DialOptions{HTTPHeader: headers, HTTPClient: &httpClient}
+
+	conn, resp, err := websocket.Dial(ctx, wsURL, dialOptions)
 	if err != nil {
+		if resp != nil && resp.StatusCode >= 400 {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, newAPIError(resp.StatusCode, resp.Header, body)
+		}
 		return nil, fmt.Errorf("failed to connect realtime transcription websocket: %w", err)
 	}
 
@@ -160,7 +177,7 @@ func (c *MistralClient) RealtimeTranscribeStream(ctx context.Context, model stri
 			select {
 			case err := <-sendErr:
 				if err != nil {
-					out <- RealtimeTranscriptionEvent{Type: "error", Err: err}
+					sendEvent(ctx, out, RealtimeTranscriptionEvent{Type: "error", Err: err})
 					return
 				}
 				sendErr = nil
@@ -168,7 +185,9 @@ func (c *MistralClient) RealtimeTranscribeStream(ctx context.Context, model stri
 				if !ok {
 					return
 				}
-				out <- event
+				if !sendEvent(ctx, out, event) {
+					return
+				}
 				if event.Error != nil || event.Type == "transcription.done" {
 					return
 				}
@@ -240,7 +259,9 @@ func (r *RealtimeConnection) Events(ctx context.Context) <-chan RealtimeTranscri
 		defer close(out)
 		for _, event := range r.initial {
 			r.applySessionUpdate(event)
-			out <- event
+			if !sendEvent(ctx, out, event) {
+				return
+			}
 		}
 		r.initial = nil
 
@@ -248,11 +269,15 @@ func (r *RealtimeConnection) Events(ctx context.Context) <-chan RealtimeTranscri
 			event, err := r.ReadEvent(ctx)
 			if err != nil {
 				if !r.isClosed() && ctx.Err() == nil {
-					out <- RealtimeTranscriptionEvent{Type: "error", Err: err}
+					if !sendEvent(ctx, out, RealtimeTranscriptionEvent{Type: "error", Err: err}) {
+						return
+					}
 				}
 				return
 			}
-			out <- event
+			if !sendEvent(ctx, out, event) {
+				return
+			}
 		}
 	}()
 	return out
@@ -362,18 +387,26 @@ func parseRealtimeEvent(payload []byte) RealtimeTranscriptionEvent {
 }
 
 func realtimeErrorMessage(event RealtimeTranscriptionEvent) error {
+	apiErr := &MistralAPIError{MistralError: MistralError{Message: "realtime transcription error"}}
 	if event.Error == nil {
-		return fmt.Errorf("realtime transcription error")
+		return apiErr
+	}
+	apiErr.Type = event.Error.Type
+	if event.Error.Code != nil {
+		apiErr.Code = *event.Error.Code
+	}
+	if event.Error.Param != nil {
+		apiErr.Param = *event.Error.Param
 	}
 	switch msg := event.Error.Message.(type) {
 	case string:
 		if msg != "" {
-			return fmt.Errorf("realtime transcription error: %s", msg)
+			apiErr.Message = "realtime transcription error: " + msg
 		}
 	case map[string]any:
 		if detail, ok := msg["detail"].(string); ok && detail != "" {
-			return fmt.Errorf("realtime transcription error: %s", detail)
+			apiErr.Message = "realtime transcription error: " + detail
 		}
 	}
-	return fmt.Errorf("realtime transcription error")
+	return apiErr
 }
@@ -0,0 +1,332 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestKind tells a RetryPolicy what sort of call is being retried.
+type RequestKind string
+
+const (
+	RequestKindDefault RequestKind = "default" // Regular JSON request/response calls
+	RequestKindStream  RequestKind = "stream"  // SSE calls; only retried before any bytes have been delivered
+	RequestKindUpload  RequestKind = "upload"  // Multipart uploads
+)
+
+// Default retry budget used by NewDefaultRetryPolicy.
+const (
+	DefaultRetryBaseDelay  = 500 * time.Millisecond
+	DefaultRetryMaxDelay   = 30 * time.Second
+	DefaultRetryJitter     = 0.2
+	DefaultRetryMaxElapsed = 2 * time.Minute
+)
+
+// inferencePaths are POST endpoints without server-side side effects, so they are safe to retry
+// after connection errors and 5xx responses.
+var inferencePaths = []string{
+	"/v1/chat/completions",
+	"/v1/fim/completions",
+	"/v1/agents/completions",
+	"/v1/embeddings",
+	"/v1/moderations",
+	"/v1/chat/moderations",
+	"/v1/classifications",
+	"/v1/chat/classifications",
+	"/v1/ocr",
+	"/v1/audio/transcriptions",
+	"/v1/audio/speech",
+}
+
+// RetryAttempt describes a finished attempt handed to a RetryPolicy.
+type RetryAttempt struct {
+	Request    *http.Request
+	Response   *http.Response // nil when Err is set
+	Err        error
+	Attempt    int           // Zero-based attempt number
+	Elapsed    time.Duration // Time since the first attempt started
+	Kind       RequestKind
+	Idempotent bool // True for idempotent methods and side-effect free inference endpoints
+}
+
+// RetryPolicy decides whether a failed attempt should be retried and how long to wait before the next one.
+type RetryPolicy interface {
+	ShouldRetry(attempt *RetryAttempt) (time.Duration, bool)
+}
+
+// RetryHook is called before the client sleeps for delay and retries attempt.
+type RetryHook func(attempt *RetryAttempt, delay time.Duration)
+
+// DefaultRetryPolicy retries with exponential backoff and jitter, honouring Retry-After and rate-limit reset headers.
+//
+// 429 and 503 responses are always retried because the server did not process the request.
+// Connection errors and the other retryable 5xx statuses are only retried for idempotent requests,
+// so uploads and resource-creating POSTs are never sent twice blindly.
+type DefaultRetryPolicy struct {
+	MaxRetries       int           // Maximum number of attempts for regular calls
+	StreamMaxRetries int           // Maximum number of attempts for streams; 0 uses MaxRetries
+	UploadMaxRetries int           // Maximum number of attempts for multipart uploads; 0 uses MaxRetries
+	BaseDelay        time.Duration // Delay before the first retry; 0 uses DefaultRetryBaseDelay
+	MaxDelay         time.Duration // Upper bound for a single delay; 0 uses DefaultRetryMaxDelay
+	Jitter           float64       // Random fraction (0-1) added to or removed from each delay
+	MaxElapsed       time.Duration // Total retry budget; 0 means unlimited
+}
+
+// NewDefaultRetryPolicy returns a DefaultRetryPolicy with maxRetries attempts and the default backoff settings.
+func NewDefaultRetryPolicy(maxRetries int) *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+		Jitter:     DefaultRetryJitter,
+		MaxElapsed: DefaultRetryMaxElapsed,
+	}
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p *DefaultRetryPolicy) ShouldRetry(attempt *RetryAttempt) (time.Duration, bool) {
+	maxRetries := p.MaxRetries
+	switch {
+	case attempt.Kind == RequestKindStream && p.StreamMaxRetries > 0:
+		maxRetries = p.StreamMaxRetries
+	case attempt.Kind == RequestKindUpload && p.UploadMaxRetries > 0:
+		maxRetries = p.UploadMaxRetries
+	}
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if attempt.Attempt+1 >= maxRetries {
+		return 0, false
+	}
+
+	if attempt.Err != nil {
+		if !attempt.Idempotent || isPermanentNetError(attempt.Err) {
+			return 0, false
+		}
+	} else {
+		status := attempt.Response.StatusCode
+		refused := status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+		if !refused && !(retryStatusCodes[status] && attempt.Idempotent) {
+			return 0, false
+		}
+	}
+
+	delay := p.backoff(attempt.Attempt)
+	if attempt.Response != nil {
+		if serverDelay, ok := retryAfter(attempt.Response.Header); ok {
+			delay = serverDelay
+		}
+	}
+	if p.MaxElapsed > 0 && attempt.Elapsed+delay > p.MaxElapsed {
+		return 0, false
+	}
+	return delay, true
+}
+
+func (p *DefaultRetryPolicy) backoff(attempt int) time.Duration {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	delay := float64(base) * math.Pow(2, float64(attempt))
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// retryAfter reads the server-requested delay from Retry-After or rate-limit reset headers.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	if ms := header.Get("Retry-After-Ms"); ms != "" {
+		if value, err := strconv.ParseFloat(ms, 64); err == nil && value >= 0 {
+			return time.Duration(value * float64(time.Millisecond)), true
+		}
+	}
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return nonNegative(time.Until(at)), true
+		}
+	}
+	for _, name := range []string{"X-RateLimit-Reset", "RateLimit-Reset", "X-RateLimit-Reset-Requests", "X-RateLimit-Reset-Tokens"} {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			// Values this large are Unix timestamps rather than relative seconds.
+			if seconds > 1e9 {
+				return nonNegative(time.Until(time.Unix(int64(seconds), 0))), true
+			}
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+		if d, err := time.ParseDuration(value); err == nil {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// isPermanentNetError reports transport errors that will not go away on retry, such as unknown hosts.
+func isPermanentNetError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return isInferencePath(req.URL.Path)
+	}
+	return false
+}
+
+func isInferencePath(path string) bool {
+	for _, inference := range inferencePaths {
+		if strings.HasSuffix(path, inference) {
+			return true
+		}
+	}
+	return false
+}
+
+// doWithRetry sends req, consulting the client's RetryPolicy after every attempt. Request bodies are
+// rewound with GetBody between attempts; requests whose body cannot be rewound are sent once.
+// The returned response may carry an error status; callers are responsible for closing its body.
+// Calls over a hard budget of the client's UsageTracker fail before they are sent.
+func (c *MistralClient) doWithRetry(req *http.Request, kind RequestKind) (*http.Response, error) {
+	var tracked *trackedCall
+	if c.usageTracker != nil {
+		var err error
+		if tracked, err = c.usageTracker.begin(req); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.observers) == 0 {
+		resp, err := c.sendWithRetry(req, kind, nil)
+		if err == nil && tracked != nil {
+			tracked.watch(resp)
+		}
+		return resp, err
+	}
+	call := c.startCall(req, kind)
+	resp, err := c.sendWithRetry(req.WithContext(call.ctx), kind, call)
+	if err != nil {
+		call.end(err)
+		return nil, err
+	}
+	if tracked != nil {
+		tracked.watch(resp)
+	}
+	call.watch(resp)
+	return resp, nil
+}
+
+// sendWithRetry runs the attempt loop of doWithRetry and reports retries to call when it is not nil.
+func (c *MistralClient) sendWithRetry(req *http.Request, kind RequestKind, call *observedCall) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	reauthorized := false
+	for i := 0; ; i++ {
+		token, err := c.authorize(ctx, req.Header)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(req)
+		if err != nil && ctx.Err() != nil {
+			recordResponseMeta(ctx, nil, i+1, time.Since(start))
+			return nil, newConnectionError(err)
+		}
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !reauthorized && canReplay(req) && c.refreshCredentials(ctx, token) {
+			// The key was rotated under us; retry once with the new one.
+			reauthorized = true
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		attempt := &RetryAttempt{
+			Request:    req,
+			Response:   resp,
+			Err:        err,
+			Attempt:    i,
+			Elapsed:    time.Since(start),
+			Kind:       kind,
+			Idempotent: isIdempotent(req),
+		}
+		delay, retry := c.policy().ShouldRetry(attempt)
+		if retry && !canReplay(req) {
+			retry = false
+		}
+		if !retry {
+			recordResponseMeta(ctx, resp, i+1, attempt.Elapsed)
+			if err != nil {
+				return nil, newConnectionError(err)
+			}
+			return resp, nil
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		c.logRetry(attempt, delay)
+		if call != nil {
+			call.retry(attempt, delay)
+		}
+		if c.onRetry != nil {
+			c.onRetry(attempt, delay)
+		}
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
+		}
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canReplay reports whether req can be sent again: it has no body, or the body can be rewound.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindBody replaces the consumed body of req with a fresh copy before another attempt.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy(maxRetries int) *DefaultRetryPolicy {
+	policy := NewDefaultRetryPolicy(maxRetries)
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 10 * time.Millisecond
+	return policy
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	var bodies []string
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, ReadRequestBody(r))
+		if len(bodies) < 3 {
+			MockErrorResponse(http.StatusServiceUnavailable, "busy").Write(w)
+			return
+		}
+		MockChatResponse().Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(fastRetryPolicy(5)))
+	if _, err := client.Chat("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body == "" || body != bodies[0] {
+			t.Errorf("attempt %d sent body %q, want %q", i, body, bodies[0])
+		}
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	attempts := 0
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After-Ms", "50")
+			MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+			return
+		}
+		MockListModelsResponse().Write(w)
+	})
+	defer mock.Close()
+
+	var delays []time.Duration
+	client := NewClient(
+		WithBaseURL(mock.Server.URL),
+		WithRetryPolicy(fastRetryPolicy(3)),
+		WithOnRetry(func(attempt *RetryAttempt, delay time.Duration) {
+			delays = append(delays, delay)
+		}),
+	)
+	if _, err := client.ListModels(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(delays) != 1 || delays[0] != 50*time.Millisecond {
+		t.Errorf("expected a single 50ms delay from Retry-After-Ms, got %v", delays)
+	}
+}
+
+func TestRetryAfterHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{"seconds", http.Header{"Retry-After": []string{"2"}}, 2 * time.Second, true},
+		{"milliseconds", http.Header{"Retry-After-Ms": []string{"1500"}}, 1500 * time.Millisecond, true},
+		{"rate limit reset", http.Header{"X-Ratelimit-Reset": []string{"3"}}, 3 * time.Second, true},
+		{"past date", http.Header{"Retry-After": []string{"Mon, 02 Jan 2006 15:04:05 GMT"}}, 0, true},
+		{"missing", http.Header{}, 0, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := retryAfter(tc.header)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("retryAfter = %v, %v; want %v, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestRetrySkipsNonIdempotentServerErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockErrorResponse(http.StatusInternalServerError, "boom").Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(fastRetryPolicy(5)))
+	if _, err := client.CreateBatchJob(&CreateBatchJobRequest{InputFiles: []string{"file-1"}, Endpoint: "/v1/chat/completions"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(mock.Requests) != 1 {
+		t.Errorf("expected resource-creating POST to be sent once, got %d attempts", len(mock.Requests))
+	}
+}
+
+func TestRetryStopsAtMaxElapsed(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+	})
+	defer mock.Close()
+
+	policy := fastRetryPolicy(5)
+	policy.MaxElapsed = time.Second
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(policy))
+
+	start := time.Now()
+	_, err := client.ListModels()
+	if !IsRateLimited(err) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected retry budget to stop waiting, took %v", time.Since(start))
+	}
+	if len(mock.Requests) != 1 {
+		t.Errorf("expected a single attempt, got %d", len(mock.Requests))
+	}
+}
+
+func TestRetryUploadResendsMultipartBody(t *testing.T) {
+	var bodies []string
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, ReadRequestBody(r))
+		if len(bodies) == 1 {
+			MockErrorResponse(http.StatusTooManyRequests, "slow down").Write(w)
+			return
+		}
+		MockFileUploadResponse().Write(w)
+	})
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithRetryPolicy(fastRetryPolicy(3)))
+	if _, err := client.UploadFile(strings.NewReader("line one\nline two"), "data.jsonl", FilePurposeFineTune); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	if bodies[0] != bodies[1] || !strings.Contains(bodies[1], "line two") {
+		t.Errorf("expected the retried upload to carry the full file, got %q", bodies[1])
+	}
+}
+
+func TestDefaultRetryPolicyPerKindLimits(t *testing.T) {
+	policy := fastRetryPolicy(5)
+	policy.StreamMaxRetries = 1
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	if _, retry := policy.ShouldRetry(&RetryAttempt{Response: resp, Kind: RequestKindStream}); retry {
+		t.Error("expected streams to stop after a single attempt")
+	}
+	if _, retry := policy.ShouldRetry(&RetryAttempt{Response: resp, Kind: RequestKindDefault}); !retry {
+		t.Error("expected regular calls to be retried")
+	}
+}
@@ -0,0 +1,321 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Errors reported by ModelRouter.
+var (
+	// ErrNoRoute is returned when every model of the route failed or was skipped. It wraps the last
+	// error.
+	ErrNoRoute = errors.New("mistral: no model in the route could serve the request")
+	// ErrModelContextTooSmall is recorded for models skipped because the prompt does not fit their
+	// context window.
+	ErrModelContextTooSmall = errors.New("mistral: prompt does not fit the model's context window")
+	// ErrModelMissingCapability is recorded for models skipped because they lack a capability the
+	// request needs.
+	ErrModelMissingCapability = errors.New("mistral: model lacks a required capability")
+)
+
+// RouteAttempt is one model, or agent, of a routed call.
+type RouteAttempt struct {
+	Model   string
+	Skipped bool  // True when the model was not called
+	Err     error // Why the model was skipped or failed; nil for the model that served the call
+}
+
+// RouteResult reports how a routed call was served.
+type RouteResult struct {
+	Model    string         // Model, or agent ID, that served the call; empty when none did
+	Attempts []RouteAttempt // Every model considered, in order
+}
+
+// ModelRouter sends chat completions to the first model of an ordered chain that can serve them,
+// such as mistral-large-latest, then mistral-medium-latest, then mistral-small-latest.
+//
+// Before calling a model, the router skips it when its ModelCard says the prompt, estimated with
+// the router's MessageCounter plus max_tokens, exceeds MaxContextLength, or when it lacks a
+// capability the request needs: function calling for requests with tools, vision for image parts,
+// audio for audio parts, and those set with WithRequiredCapabilities. Cards are retrieved with
+// RetrieveModel once per model, or supplied with WithModelCards; models without a card, such as
+// those RetrieveModel reports as not found, are not skipped.
+//
+// A call that fails with an error accepted by the fallback rule, DefaultFallbackOn by default,
+// moves on to the next model. After a context-length error, models whose context window is not
+// larger than the failed model's are skipped.
+//
+//	router := sdk.NewModelRouter(client, []string{"mistral-large-latest", "mistral-medium-latest", "mistral-small-latest"})
+//	resp, route, err := router.Chat(messages, nil)
+//	log.Printf("served by %s", route.Model)
+//
+// A ModelRouter is safe for concurrent use.
+type ModelRouter struct {
+	client     *MistralClient
+	models     []string
+	fallbackOn func(err error) bool
+	counter    MessageCounter
+	required   ModelCapabilities
+
+	mu    sync.Mutex
+	cards map[string]*ModelCard
+}
+
+// RouterOption configures a ModelRouter.
+type RouterOption func(*ModelRouter)
+
+// WithFallbackOn sets which errors move a call on to the next model, DefaultFallbackOn by default.
+func WithFallbackOn(fallbackOn func(err error) bool) RouterOption {
+	return func(r *ModelRouter) {
+		r.fallbackOn = fallbackOn
+	}
+}
+
+// WithRouterCounter sets how prompt tokens are counted for the context window rule. By default
+// they are estimated at four characters per token.
+func WithRouterCounter(counter MessageCounter) RouterOption {
+	return func(r *ModelRouter) {
+		r.counter = counter
+	}
+}
+
+// WithRequiredCapabilities adds capabilities every request needs, on top of the ones the router
+// infers from the request.
+func WithRequiredCapabilities(capabilities ModelCapabilities) RouterOption {
+	return func(r *ModelRouter) {
+		r.required = mergeCapabilities(r.required, capabilities)
+	}
+}
+
+// WithModelCards supplies model cards, so that the router does not retrieve them.
+func WithModelCards(cards ...ModelCard) RouterOption {
+	return func(r *ModelRouter) {
+		for i := range cards {
+			card := cards[i]
+			r.cards[card.ID] = &card
+		}
+	}
+}
+
+// NewModelRouter returns a router trying models in order through client.
+func NewModelRouter(client *MistralClient, models []string, opts ...RouterOption) *ModelRouter {
+	r := &ModelRouter{
+		client:     client,
+		models:     append([]string(nil), models...),
+		fallbackOn: DefaultFallbackOn,
+		counter:    estimateMessageTokens,
+		cards:      map[string]*ModelCard{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// DefaultFallbackOn accepts rate limits, server errors, context-length errors, and connection
+// failures other than cancellation.
+func DefaultFallbackOn(err error) bool {
+	var apiErr *MistralAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRateLimited() || apiErr.IsServerError() || apiErr.IsContextLengthExceeded()
+	}
+	var connErr *MistralConnectionError
+	return errors.As(err, &connErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// Chat sends a chat completion to the first model of the route that serves it. params may be nil.
+func (r *ModelRouter) Chat(messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, *RouteResult, error) {
+	return r.ChatCtx(context.Background(), messages, params)
+}
+
+// ChatCtx is like Chat but uses ctx for cancellation and deadlines.
+func (r *ModelRouter) ChatCtx(ctx context.Context, messages []ChatMessage, params *ChatRequestParams) (*ChatCompletionResponse, *RouteResult, error) {
+	var resp *ChatCompletionResponse
+	result, err := r.route(ctx, messages, params, func(ctx context.Context, model string) (err error) {
+		resp, err = r.client.ChatCtx(ctx, model, messages, params)
+		return err
+	})
+	return resp, result, err
+}
+
+// ChatStream is like Chat but opens a stream. Falling back only happens before the stream starts.
+func (r *ModelRouter) ChatStream(messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], *RouteResult, error) {
+	return r.ChatStreamCtx(context.Background(), messages, params)
+}
+
+// ChatStreamCtx is like ChatStream but uses ctx for cancellation and deadlines.
+func (r *ModelRouter) ChatStreamCtx(ctx context.Context, messages []ChatMessage, params *ChatRequestParams) (*Stream[ChatCompletionStreamResponse], *RouteResult, error) {
+	var stream *Stream[ChatCompletionStreamResponse]
+	result, err := r.route(ctx, messages, params, func(ctx context.Context, model string) (err error) {
+		stream, err = r.client.ChatStreamCtx(ctx, model, messages, params)
+		return err
+	})
+	return stream, result, err
+}
+
+// AgentComplete tries the agents in order, moving on to the next one on errors accepted by the
+// fallback rule. Agents carry their own model, so the context window and capability rules do not
+// apply. RouteResult.Model is the ID of the agent that served the call.
+func (r *ModelRouter) AgentComplete(agentIDs []string, messages []ChatMessage, params *AgentCompletionRequest) (*ChatCompletionResponse, *RouteResult, error) {
+	return r.AgentCompleteCtx(context.Background(), agentIDs, messages, params)
+}
+
+// AgentCompleteCtx is like AgentComplete but uses ctx for cancellation and deadlines.
+func (r *ModelRouter) AgentCompleteCtx(ctx context.Context, agentIDs []string, messages []ChatMessage, params *AgentCompletionRequest) (*ChatCompletionResponse, *RouteResult, error) {
+	result := &RouteResult{}
+	var lastErr error
+	for _, agentID := range agentIDs {
+		request := &AgentCompletionRequest{}
+		if params != nil {
+			*request = *params
+		}
+		resp, err := r.client.AgentCompleteCtx(ctx, agentID, messages, request)
+		result.Attempts = append(result.Attempts, RouteAttempt{Model: agentID, Err: err})
+		if err == nil {
+			result.Model = agentID
+			return resp, result, nil
+		}
+		if ctx.Err() != nil || !r.fallbackOn(err) {
+			return nil, result, err
+		}
+		lastErr = err
+	}
+	return nil, result, noRoute(lastErr)
+}
+
+func (r *ModelRouter) route(ctx context.Context, messages []ChatMessage, params *ChatRequestParams, call func(ctx context.Context, model string) error) (*RouteResult, error) {
+	result := &RouteResult{}
+	tools := paramsTools(params)
+	required := mergeCapabilities(r.required, requestCapabilities(messages, tools))
+	needed := r.counter(messages, tools)
+	if params != nil && params.MaxTokens != nil {
+		needed += *params.MaxTokens
+	}
+
+	var lastErr error
+	failedContext := 0 // Context window of the largest model that failed with a context-length error
+	for _, model := range r.models {
+		card := r.card(ctx, model)
+		if err := checkCard(card, needed, failedContext, required); err != nil {
+			result.Attempts = append(result.Attempts, RouteAttempt{Model: model, Skipped: true, Err: err})
+			lastErr = err
+			continue
+		}
+
+		err := call(ctx, model)
+		result.Attempts = append(result.Attempts, RouteAttempt{Model: model, Err: err})
+		if err == nil {
+			result.Model = model
+			return result, nil
+		}
+		if ctx.Err() != nil || !r.fallbackOn(err) {
+			return result, err
+		}
+		if IsContextLengthExceeded(err) && card != nil && card.MaxContextLength != nil && *card.MaxContextLength > failedContext {
+			failedContext = *card.MaxContextLength
+		}
+		lastErr = err
+	}
+	return result, noRoute(lastErr)
+}
+
+func noRoute(lastErr error) error {
+	if lastErr == nil {
+		return ErrNoRoute
+	}
+	return fmt.Errorf("%w: %w", ErrNoRoute, lastErr)
+}
+
+// card returns the card of model, retrieving it on first use, or nil when it is unknown. A 404, such
+// as for a fine-tuned model, is remembered, so the card is not asked for again; other failures are
+// retried on the next call.
+func (r *ModelRouter) card(ctx context.Context, model string) *ModelCard {
+	r.mu.Lock()
+	card, ok := r.cards[model]
+	r.mu.Unlock()
+	if ok {
+		return card
+	}
+	card, err := r.client.RetrieveModelCtx(ctx, model)
+	if err != nil {
+		var apiErr *MistralAPIError
+		if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusNotFound {
+			r.mu.Lock()
+			r.cards[model] = nil
+			r.mu.Unlock()
+		}
+		return nil
+	}
+	r.mu.Lock()
+	r.cards[model] = card
+	r.mu.Unlock()
+	return card
+}
+
+// checkCard returns why a model with card cannot serve a prompt of needed tokens, or nil.
+func checkCard(card *ModelCard, needed, failedContext int, required ModelCapabilities) error {
+	if card == nil {
+		return nil
+	}
+	if limit := card.MaxContextLength; limit != nil {
+		if needed > *limit {
+			return fmt.Errorf("%w: about %d tokens needed, %s has %d", ErrModelContextTooSmall, needed, card.ID, *limit)
+		}
+		if failedContext > 0 && *limit <= failedContext {
+			return fmt.Errorf("%w: %s has %d tokens, a %d-token model already rejected the prompt", ErrModelContextTooSmall, card.ID, *limit, failedContext)
+		}
+	}
+	if missing := missingCapabilities(card.Capabilities, required); len(missing) > 0 {
+		return fmt.Errorf("%w: %s has no %s", ErrModelMissingCapability, card.ID, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// requestCapabilities returns the capabilities needed by messages and tools.
+func requestCapabilities(messages []ChatMessage, tools []Tool) ModelCapabilities {
+	var required ModelCapabilities
+	required.FunctionCalling = len(tools) > 0
+	for _, message := range messages {
+		for _, part := range message.ContentParts {
+			switch part.Type {
+			case ContentPartImageURL:
+				required.Vision = true
+			case ContentPartInputAudio:
+				required.Audio = true
+			}
+		}
+	}
+	return required
+}
+
+func mergeCapabilities(a, b ModelCapabilities) ModelCapabilities {
+	va, vb := reflect.ValueOf(&a).Elem(), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		if vb.Field(i).Bool() {
+			va.Field(i).SetBool(true)
+		}
+	}
+	return a
+}
+
+// missingCapabilities returns the JSON names of the required capabilities that has lacks.
+// A card without capabilities is assumed to have them all.
+func missingCapabilities(has *ModelCapabilities, required ModelCapabilities) []string {
+	if has == nil {
+		return nil
+	}
+	var missing []string
+	vh, vr := reflect.ValueOf(*has), reflect.ValueOf(required)
+	for i := 0; i < vr.NumField(); i++ {
+		if vr.Field(i).Bool() && !vh.Field(i).Bool() {
+			name, _, _ := strings.Cut(vr.Type().Field(i).Tag.Get("json"), ",")
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,225 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// routerServer answers chat calls with the status given for the requested model, 200 by default.
+// Cards of models starting with ft: are not found, and those starting with denied: are forbidden.
+func routerServer(t *testing.T, statuses map[string]int, served *[]string) *MockHTTPServer {
+	return NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/models/") {
+			id := strings.TrimPrefix(r.URL.Path, "/v1/models/")
+			if strings.HasPrefix(id, "ft:") {
+				MockJSONResponse(http.StatusNotFound, `{"message":"Model not found"}`).Write(w)
+				return
+			}
+			if strings.HasPrefix(id, "denied:") {
+				MockJSONResponse(http.StatusForbidden, `{"message":"Forbidden"}`).Write(w)
+				return
+			}
+			MockJSONResponse(http.StatusOK, `{"id":"`+id+`","object":"model","max_context_length":1000,"capabilities":{"completion_chat":true}}`).Write(w)
+			return
+		}
+		var req struct {
+			Model   string `json:"model"`
+			AgentID string `json:"agent_id"`
+			Stream  bool   `json:"stream"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		model := req.Model + req.AgentID
+		*served = append(*served, model)
+		switch statuses[model] {
+		case http.StatusTooManyRequests:
+			MockJSONResponse(http.StatusTooManyRequests, `{"message":"Requests rate limit exceeded","type":"rate_limited"}`).Write(w)
+		case http.StatusServiceUnavailable:
+			MockJSONResponse(http.StatusServiceUnavailable, `{"message":"Service unavailable"}`).Write(w)
+		case http.StatusBadRequest:
+			MockJSONResponse(http.StatusBadRequest, `{"message":"Prompt contains 40000 tokens, too large for model with 32768 maximum context length","type":"invalid_request_error"}`).Write(w)
+		case http.StatusUnauthorized:
+			MockJSONResponse(http.StatusUnauthorized, `{"message":"Unauthorized"}`).Write(w)
+		default:
+			if req.Stream {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = io.WriteString(w, `data: {"id":"c1","model":"`+model+`","choices":[{"index":0,"delta":{"content":"hi"},"finish_reason":"stop"}]}`+"\n\ndata: [DONE]\n\n")
+				return
+			}
+			MockJSONResponse(http.StatusOK, `{"id":"c1","model":"`+model+`","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`).Write(w)
+		}
+	})
+}
+
+func card(id string, contextLength int, capabilities ModelCapabilities) ModelCard {
+	return ModelCard{ID: id, MaxContextLength: &contextLength, Capabilities: &capabilities}
+}
+
+var chatCapabilities = ModelCapabilities{CompletionChat: true, FunctionCalling: true}
+
+func TestModelRouterFallsBackOnRateLimitsAndServerErrors(t *testing.T) {
+	var served []string
+	mock := routerServer(t, map[string]int{"large": http.StatusTooManyRequests, "medium": http.StatusServiceUnavailable}, &served)
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1))
+	router := NewModelRouter(client, []string{"large", "medium", "small"}, WithModelCards(
+		card("large", 128000, chatCapabilities), card("medium", 128000, chatCapabilities), card("small", 32000, chatCapabilities),
+	))
+	resp, route, err := router.Chat([]ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	if route.Model != "small" || resp.Model != "small" {
+		t.Errorf("served by %q, response model %q", route.Model, resp.Model)
+	}
+	if !reflect.DeepEqual(served, []string{"large", "medium", "small"}) {
+		t.Errorf("calls = %v", served)
+	}
+	if len(route.Attempts) != 3 || !IsRateLimited(route.Attempts[0].Err) || route.Attempts[2].Err != nil {
+		t.Errorf("attempts = %+v", route.Attempts)
+	}
+}
+
+func TestModelRouterStopsOnOtherErrors(t *testing.T) {
+	var served []string
+	mock := routerServer(t, map[string]int{"large": http.StatusUnauthorized}, &served)
+	defer mock.Close()
+
+	router := NewModelRouter(NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1)), []string{"large", "small"}, WithModelCards(card("large", 1000, chatCapabilities), card("small", 1000, chatCapabilities)))
+	if _, _, err := router.Chat([]ChatMessage{UserMessage("hi")}, nil); !IsAuthError(err) || errors.Is(err, ErrNoRoute) {
+		t.Errorf("expected the auth error as is, got %v", err)
+	}
+	if len(served) != 1 {
+		t.Errorf("calls = %v", served)
+	}
+}
+
+func TestModelRouterSkipsModelsByContextAndCapabilities(t *testing.T) {
+	var served []string
+	mock := routerServer(t, map[string]int{"mid-context": http.StatusBadRequest}, &served)
+	defer mock.Close()
+
+	client := NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1))
+	cards := WithModelCards(
+		card("small-context", 8000, chatCapabilities),
+		card("mid-context", 32000, chatCapabilities),
+		card("same-context", 32000, chatCapabilities),
+		card("no-tools", 256000, ModelCapabilities{CompletionChat: true}),
+		card("long-context", 256000, chatCapabilities),
+	)
+	router := NewModelRouter(client, []string{"small-context", "mid-context", "same-context", "no-tools", "long-context"}, cards,
+		WithRouterCounter(func(messages []ChatMessage, tools []Tool) int { return 9000 }))
+
+	params := &ChatRequestParams{Tools: []Tool{{Type: ToolTypeFunction, Function: Function{Name: "f"}}}}
+	_, route, err := router.Chat([]ChatMessage{UserMessage("hi")}, params)
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	if route.Model != "long-context" || !reflect.DeepEqual(served, []string{"mid-context", "long-context"}) {
+		t.Fatalf("served by %q after %v", route.Model, served)
+	}
+	attempts := route.Attempts
+	if !attempts[0].Skipped || !errors.Is(attempts[0].Err, ErrModelContextTooSmall) {
+		t.Errorf("small-context: %+v", attempts[0])
+	}
+	if attempts[1].Skipped || !IsContextLengthExceeded(attempts[1].Err) {
+		t.Errorf("mid-context: %+v", attempts[1])
+	}
+	if !attempts[2].Skipped || !errors.Is(attempts[2].Err, ErrModelContextTooSmall) {
+		t.Errorf("same-context: %+v", attempts[2])
+	}
+	if !attempts[3].Skipped || !errors.Is(attempts[3].Err, ErrModelMissingCapability) || !strings.Contains(attempts[3].Err.Error(), "function_calling") {
+		t.Errorf("no-tools: %+v", attempts[3])
+	}
+
+	// Image parts need vision; no model has it.
+	served = nil
+	image := UserMessageParts(TextPart("what is this?"), ImageURLPart("https://example.com/cat.png"))
+	_, route, err = router.Chat([]ChatMessage{image}, nil)
+	if !errors.Is(err, ErrNoRoute) || !errors.Is(err, ErrModelMissingCapability) || len(served) != 0 {
+		t.Errorf("expected no route without vision, got %v after %v", err, served)
+	}
+	if route.Model != "" || len(route.Attempts) != 5 {
+		t.Errorf("route = %+v", route)
+	}
+}
+
+func TestModelRouterRetrievesCardsOnce(t *testing.T) {
+	var served []string
+	mock := routerServer(t, nil, &served)
+	defer mock.Close()
+
+	router := NewModelRouter(NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1)), []string{"tiny", "other"})
+	long := strings.Repeat("word ", 1000) // About 1250 tokens against a 1000-token window
+	for i := 0; i < 2; i++ {
+		if _, _, err := router.Chat([]ChatMessage{UserMessage(long)}, nil); !errors.Is(err, ErrModelContextTooSmall) {
+			t.Fatalf("expected every model to be too small, got %v", err)
+		}
+	}
+	if lookups := cardLookups(mock); lookups != 2 || len(served) != 0 {
+		t.Errorf("%d card lookups and calls %v, want 2 lookups and no calls", lookups, served)
+	}
+}
+
+func TestModelRouterRemembersMissingCards(t *testing.T) {
+	var served []string
+	mock := routerServer(t, nil, &served)
+	defer mock.Close()
+
+	router := NewModelRouter(NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1)), []string{"ft:custom"})
+	for i := 0; i < 2; i++ {
+		if _, route, err := router.Chat([]ChatMessage{UserMessage("hi")}, nil); err != nil || route.Model != "ft:custom" {
+			t.Fatalf("expected the model without a card to be called, got %+v, %v", route, err)
+		}
+	}
+	if lookups := cardLookups(mock); lookups != 1 || len(served) != 2 {
+		t.Errorf("%d card lookups and calls %v, want 1 lookup and 2 calls", lookups, served)
+	}
+
+	// Other failures may be transient, such as a key being rotated, and are asked again.
+	router = NewModelRouter(NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1)), []string{"denied:model"})
+	for i := 0; i < 2; i++ {
+		if _, _, err := router.Chat([]ChatMessage{UserMessage("hi")}, nil); err != nil {
+			t.Fatalf("Chat: %v", err)
+		}
+	}
+	if lookups := cardLookups(mock); lookups != 3 {
+		t.Errorf("%d card lookups, want the forbidden card asked on each call", lookups)
+	}
+}
+
+func cardLookups(mock *MockHTTPServer) int {
+	lookups := 0
+	for _, r := range mock.Requests {
+		if strings.HasPrefix(r.URL.Path, "/v1/models/") {
+			lookups++
+		}
+	}
+	return lookups
+}
+
+func TestModelRouterStreamAndAgents(t *testing.T) {
+	var served []string
+	mock := routerServer(t, map[string]int{"large": http.StatusServiceUnavailable, "agent-a": http.StatusTooManyRequests}, &served)
+	defer mock.Close()
+
+	router := NewModelRouter(NewClient(WithBaseURL(mock.Server.URL), WithMaxRetries(1)), []string{"large", "small"}, WithModelCards(card("large", 1000, chatCapabilities), card("small", 1000, chatCapabilities)))
+	stream, route, err := router.ChatStream([]ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("ChatStream: %v", err)
+	}
+	resp, err := AccumulateChatStream(stream, nil)
+	if err != nil || route.Model != "small" || resp.Choices[0].Message.Content != "hi" {
+		t.Errorf("stream served by %q: %+v, %v", route.Model, resp, err)
+	}
+
+	_, route, err = router.AgentComplete([]string{"agent-a", "agent-b"}, []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil || route.Model != "agent-b" || len(route.Attempts) != 2 {
+		t.Errorf("agent route = %+v, %v", route, err)
+	}
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -92,6 +93,11 @@ type ListVoicesParams struct {
 }
 
 func (c *MistralClient) Speech(req *SpeechRequest) (*SpeechResponse, error) {
+	return c.SpeechCtx(context.Background(), req)
+}
+
+func (c *MistralClient) SpeechCtx(ctx context.Context, req *SpeechRequest) (*SpeechResponse, error) {
+	ctx = withOperation(ctx, "audio.speech")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -104,18 +110,19 @@ func (c *MistralClient) Speech(req *SpeechRequest) (*SpeechResponse, error) {
 		"ref_audio":       req.RefAudio,
 		"response_format": req.ResponseFormat,
 	})
-	response, err := c.requestMap(http.MethodPost, body, "v1/audio/speech")
-	if err != nil {
-		return nil, err
-	}
 	var speech SpeechResponse
-	if err := mapToStruct(map[string]interface{}(response), &speech); err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, body, "v1/audio/speech", &speech); err != nil {
 		return nil, err
 	}
 	return &speech, nil
 }
 
-func (c *MistralClient) SpeechStream(req *SpeechRequest) (<-chan StreamEvent, error) {
+func (c *MistralClient) SpeechStream(req *SpeechRequest) (*Stream[StreamEvent], error) {
+	return c.SpeechStreamCtx(context.Background(), req)
+}
+
+func (c *MistralClient) SpeechStreamCtx(ctx context.Context, req *SpeechRequest) (*Stream[StreamEvent], error) {
+	ctx = withOperation(ctx, "audio.speech.stream")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -130,7 +137,7 @@ func (c *MistralClient) SpeechStream(req *SpeechRequest) (<-chan StreamEvent, er
 		"ref_audio":       req.RefAudio,
 		"response_format": req.ResponseFormat,
 	})
-	response, err := c.request(http.MethodPost, body, "v1/audio/speech", true, nil)
+	response, err := c.request(ctx, http.MethodPost, body, "v1/audio/speech", true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -138,10 +145,29 @@ func (c *MistralClient) SpeechStream(req *SpeechRequest) (<-chan StreamEvent, er
 	if !ok {
 		return nil, fmt.Errorf("invalid response type: %T", response)
 	}
-	return parseGenericStream(bodyStream), nil
+	return newStream(ctx, bodyStream, c.streamIdleTimeout, decodeStreamEvent, nil), nil
+}
+
+// SpeechStreamChan is like SpeechStream but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) SpeechStreamChan(req *SpeechRequest) (<-chan StreamEvent, error) {
+	return c.SpeechStreamChanCtx(context.Background(), req)
+}
+
+// SpeechStreamChanCtx is like SpeechStreamChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) SpeechStreamChanCtx(ctx context.Context, req *SpeechRequest) (<-chan StreamEvent, error) {
+	stream, err := c.SpeechStreamCtx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return stream.channel(ctx, func(err error) StreamEvent { return StreamEvent{Error: err} }), nil
 }
 
 func (c *MistralClient) ListVoices(params *ListVoicesParams) (*VoiceListResponse, error) {
+	return c.ListVoicesCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListVoicesCtx(ctx context.Context, params *ListVoicesParams) (*VoiceListResponse, error) {
+	ctx = withOperation(ctx, "audio.voices.list")
 	if params == nil {
 		params = &ListVoicesParams{}
 	}
@@ -158,18 +184,19 @@ func (c *MistralClient) ListVoices(params *ListVoicesParams) (*VoiceListResponse
 			query += "&" + typeQuery
 		}
 	}
-	response, err := c.requestMap(http.MethodGet, nil, appendQuery("v1/audio/voices", query))
-	if err != nil {
-		return nil, err
-	}
 	var voices VoiceListResponse
-	if err := mapToStruct(map[string]interface{}(response), &voices); err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, appendQuery("v1/audio/voices", query), &voices); err != nil {
 		return nil, err
 	}
 	return &voices, nil
 }
 
 func (c *MistralClient) CreateVoice(req *VoiceRequest) (*Voice, error) {
+	return c.CreateVoiceCtx(context.Background(), req)
+}
+
+func (c *MistralClient) CreateVoiceCtx(ctx context.Context, req *VoiceRequest) (*Voice, error) {
+	ctx = withOperation(ctx, "audio.voices.create")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -185,30 +212,32 @@ func (c *MistralClient) CreateVoice(req *VoiceRequest) (*Voice, error) {
 		"retention_notice": req.RetentionNotice,
 		"sample_filename":  req.SampleFilename,
 	})
-	response, err := c.requestMap(http.MethodPost, body, "v1/audio/voices")
-	if err != nil {
-		return nil, err
-	}
 	var voice Voice
-	if err := mapToStruct(map[string]interface{}(response), &voice); err != nil {
+	if err := c.requestInto(ctx, http.MethodPost, body, "v1/audio/voices", &voice); err != nil {
 		return nil, err
 	}
 	return &voice, nil
 }
 
 func (c *MistralClient) DeleteVoice(voiceID string) (*Voice, error) {
-	response, err := c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/audio/voices/%s", voiceID))
-	if err != nil {
-		return nil, err
-	}
+	return c.DeleteVoiceCtx(context.Background(), voiceID)
+}
+
+func (c *MistralClient) DeleteVoiceCtx(ctx context.Context, voiceID string) (*Voice, error) {
+	ctx = withOperation(ctx, "audio.voices.delete")
 	var voice Voice
-	if err := mapToStruct(map[string]interface{}(response), &voice); err != nil {
+	if err := c.requestInto(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/audio/voices/%s", voiceID), &voice); err != nil {
 		return nil, err
 	}
 	return &voice, nil
 }
 
 func (c *MistralClient) UpdateVoice(voiceID string, req *UpdateVoiceRequest) (*Voice, error) {
+	return c.UpdateVoiceCtx(context.Background(), voiceID, req)
+}
+
+func (c *MistralClient) UpdateVoiceCtx(ctx context.Context, voiceID string, req *UpdateVoiceRequest) (*Voice, error) {
+	ctx = withOperation(ctx, "audio.voices.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -222,29 +251,31 @@ func (c *MistralClient) UpdateVoice(voiceID string, req *UpdateVoiceRequest) (*V
 		"color":            req.Color,
 		"retention_notice": req.RetentionNotice,
 	})
-	response, err := c.requestMap(http.MethodPatch, body, fmt.Sprintf("v1/audio/voices/%s", voiceID))
-	if err != nil {
-		return nil, err
-	}
 	var voice Voice
-	if err := mapToStruct(map[string]interface{}(response), &voice); err != nil {
+	if err := c.requestInto(ctx, http.MethodPatch, body, fmt.Sprintf("v1/audio/voices/%s", voiceID), &voice); err != nil {
 		return nil, err
 	}
 	return &voice, nil
 }
 
 func (c *MistralClient) GetVoice(voiceID string) (*Voice, error) {
-	response, err := c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/audio/voices/%s", voiceID))
-	if err != nil {
-		return nil, err
-	}
+	return c.GetVoiceCtx(context.Background(), voiceID)
+}
+
+func (c *MistralClient) GetVoiceCtx(ctx context.Context, voiceID string) (*Voice, error) {
+	ctx = withOperation(ctx, "audio.voices.get")
 	var voice Voice
-	if err := mapToStruct(map[string]interface{}(response), &voice); err != nil {
+	if err := c.requestInto(ctx, http.MethodGet, nil, fmt.Sprintf("v1/audio/voices/%s", voiceID), &voice); err != nil {
 		return nil, err
 	}
 	return &voice, nil
 }
 
 func (c *MistralClient) GetVoiceSampleAudio(voiceID string) ([]byte, error) {
-	return c.requestBytes(http.MethodGet, fmt.Sprintf("v1/audio/voices/%s/sample", voiceID), "audio/wav")
+	return c.GetVoiceSampleAudioCtx(context.Background(), voiceID)
+}
+
+func (c *MistralClient) GetVoiceSampleAudioCtx(ctx context.Context, voiceID string) ([]byte, error) {
+	ctx = withOperation(ctx, "audio.voices.get_sample_audio")
+	return c.requestBytes(ctx, http.MethodGet, fmt.Sprintf("v1/audio/voices/%s/sample", voiceID), "audio/wav")
 }
@@ -0,0 +1,214 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStreamIdle is returned, wrapped, by streams that received nothing for longer than the
+// WithStreamIdleTimeout duration.
+var ErrStreamIdle = errors.New("mistral: stream idle timeout")
+
+// SSEEvent is one event of a text/event-stream response.
+type SSEEvent struct {
+	Event string        // Event type from the event: field; empty for the default "message" type
+	Data  []byte        // data: lines joined with "\n"
+	ID    string        // Last event ID, carried over from earlier events when the event has none
+	Retry time.Duration // Reconnection delay from the latest retry: field, zero when the server sent none
+}
+
+// SSEDecoder reads server-sent events as specified by the HTML Living Standard. It accepts
+// "\n", "\r\n" and "\r" line endings, lines of any length, multi-line data, comments, and the
+// event, id and retry fields.
+type SSEDecoder struct {
+	r      io.Reader
+	buf    []byte
+	start  int
+	err    error
+	skipLF bool // The previous line ended with "\r", so a leading "\n" belongs to it
+	begun  bool
+
+	event       string
+	data        []byte
+	hasData     bool
+	lastEventID string
+	retry       time.Duration
+}
+
+// NewSSEDecoder returns a decoder reading events from r.
+func NewSSEDecoder(r io.Reader) *SSEDecoder {
+	return &SSEDecoder{r: r, buf: make([]byte, 0, 4096)}
+}
+
+// LastEventID returns the last event ID received, for resuming the stream.
+func (d *SSEDecoder) LastEventID() string {
+	return d.lastEventID
+}
+
+// Next returns the next event. It returns io.EOF once the stream ends; an event that was not
+// terminated by a blank line before the end is discarded.
+func (d *SSEDecoder) Next() (*SSEEvent, error) {
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 {
+			if !d.hasData {
+				d.event = ""
+				continue
+			}
+			event := &SSEEvent{
+				Event: d.event,
+				Data:  bytes.TrimSuffix(d.data, []byte("\n")),
+				ID:    d.lastEventID,
+				Retry: d.retry,
+			}
+			d.event, d.data, d.hasData = "", nil, false
+			return event, nil
+		}
+		if line[0] == ':' {
+			continue
+		}
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			d.event = string(value)
+		case "data":
+			d.data = append(append(d.data, value...), '\n')
+			d.hasData = true
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				d.lastEventID = string(value)
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(string(value), 10, 63); err == nil {
+				d.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine returns the next line without its ending. The line is only valid until the next call.
+func (d *SSEDecoder) readLine() ([]byte, error) {
+	for {
+		pending := d.buf[d.start:]
+		if d.skipLF && len(pending) > 0 {
+			d.skipLF = false
+			if pending[0] == '\n' {
+				d.start++
+				pending = pending[1:]
+			}
+		}
+		if !d.begun && (len(pending) >= 3 || d.err != nil) {
+			d.begun = true
+			if bytes.HasPrefix(pending, []byte("\xEF\xBB\xBF")) {
+				d.start += 3
+				pending = pending[3:]
+			}
+		}
+		if d.begun {
+			if i := bytes.IndexAny(pending, "\r\n"); i >= 0 {
+				d.start += i + 1
+				d.skipLF = pending[i] == '\r'
+				return pending[:i], nil
+			}
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+
+		if d.start > 0 {
+			n := copy(d.buf, d.buf[d.start:])
+			d.buf, d.start = d.buf[:n], 0
+		}
+		if len(d.buf) == cap(d.buf) {
+			grown := make([]byte, len(d.buf), 2*cap(d.buf))
+			copy(grown, d.buf)
+			d.buf = grown
+		}
+		n, err := d.r.Read(d.buf[len(d.buf):cap(d.buf)])
+		d.buf = d.buf[:len(d.buf)+n]
+		if err != nil {
+			d.err = err
+		}
+	}
+}
+
+// WithStreamIdleTimeout ends streams that wait longer than timeout for their next bytes, counting
+// keep-alive comments and the wait for the first event. The stream then delivers an error wrapping
+// ErrStreamIdle, or reconnects when the endpoint supports resuming. Zero, the default, waits
+// indefinitely.
+func WithStreamIdleTimeout(timeout time.Duration) Option {
+	return func(c *MistralClient) {
+		c.streamIdleTimeout = timeout
+	}
+}
+
+// idleBody closes the body it wraps when a read waits longer than timeout. Time spent between reads,
+// while the consumer handles an event, does not count.
+type idleBody struct {
+	io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	idle    atomic.Bool
+}
+
+func newIdleBody(body io.ReadCloser, timeout time.Duration) io.ReadCloser {
+	if timeout <= 0 {
+		return body
+	}
+	b := &idleBody{ReadCloser: body, timeout: timeout}
+	b.timer = time.AfterFunc(timeout, func() {
+		b.idle.Store(true)
+		body.Close()
+	})
+	b.timer.Stop()
+	return b
+}
+
+func (b *idleBody) Read(p []byte) (int, error) {
+	b.timer.Reset(b.timeout)
+	n, err := b.ReadCloser.Read(p)
+	b.timer.Stop()
+	if b.idle.Load() {
+		return n, fmt.Errorf("no data for %v: %w", b.timeout, ErrStreamIdle)
+	}
+	return n, err
+}
+
+func (b *idleBody) Close() error {
+	b.timer.Stop()
+	return b.ReadCloser.Close()
+}
+
+// decodeChunk decodes the completion chunks of chat, FIM and agent streams.
+func decodeChunk[T any](event *SSEEvent) (T, error) {
+	var chunk T
+	if err := json.Unmarshal(event.Data, &chunk); err != nil {
+		var zero T
+		return zero, fmt.Errorf("error decoding stream response: %w", err)
+	}
+	return chunk, nil
+}
+
+// decodeEventPayload decodes the JSON object of an event and its type, which falls back to the SSE
+// event name when the payload has no "type" field.
+func decodeEventPayload(event *SSEEvent) (string, map[string]any, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return "", nil, fmt.Errorf("error decoding stream event: %w", err)
+	}
+	eventType, ok := payload["type"].(string)
+	if !ok {
+		eventType = event.Event
+	}
+	return eventType, payload, nil
+}
@@ -0,0 +1,162 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readSSE(t *testing.T, r io.Reader) []SSEEvent {
+	t.Helper()
+	decoder := NewSSEDecoder(r)
+	var events []SSEEvent
+	for {
+		event, err := decoder.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events = append(events, SSEEvent{Event: event.Event, Data: append([]byte(nil), event.Data...), ID: event.ID, Retry: event.Retry})
+	}
+}
+
+func TestSSEDecoder(t *testing.T) {
+	long := strings.Repeat("x", 10000)
+	testCases := []struct {
+		name     string
+		input    string
+		expected []SSEEvent
+	}{
+		{"data lines", "data: a\n\ndata: b\n\n", []SSEEvent{{Data: []byte("a")}, {Data: []byte("b")}}},
+		{"multi-line data", "data: one\ndata: two\n\n", []SSEEvent{{Data: []byte("one\ntwo")}}},
+		{"CRLF endings", "data: a\r\n\r\ndata: b\r\n\r\n", []SSEEvent{{Data: []byte("a")}, {Data: []byte("b")}}},
+		{"CR endings", "data: a\r\rdata: b\r\r", []SSEEvent{{Data: []byte("a")}, {Data: []byte("b")}}},
+		{"comments", ": keep-alive\ndata: a\n: more\n\n", []SSEEvent{{Data: []byte("a")}}},
+		{"no space after colon", "data:a\n\n", []SSEEvent{{Data: []byte("a")}}},
+		{"event names", "event: conversation.response.started\ndata: {}\n\ndata: {}\n\n", []SSEEvent{{Event: "conversation.response.started", Data: []byte("{}")}, {Data: []byte("{}")}}},
+		{"IDs carry over", "id: 7\ndata: a\n\ndata: b\n\nid\ndata: c\n\n", []SSEEvent{{ID: "7", Data: []byte("a")}, {ID: "7", Data: []byte("b")}, {Data: []byte("c")}}},
+		{"retry", "retry: 250\ndata: a\n\nretry: soon\ndata: b\n\n", []SSEEvent{{Retry: 250 * time.Millisecond, Data: []byte("a")}, {Retry: 250 * time.Millisecond, Data: []byte("b")}}},
+		{"events without data are skipped", "event: ping\n\ndata: a\n\n", []SSEEvent{{Data: []byte("a")}}},
+		{"byte order mark", "\xEF\xBB\xBFdata: a\n\n", []SSEEvent{{Data: []byte("a")}}},
+		{"long lines", "data: " + long + "\n\n", []SSEEvent{{Data: []byte(long)}}},
+		{"unterminated event is dropped", "data: a\n\ndata: b\n", []SSEEvent{{Data: []byte("a")}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if events := readSSE(t, strings.NewReader(tc.input)); !reflect.DeepEqual(events, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, events)
+			}
+			// Byte-at-a-time reads split every line ending, including "\r\n".
+			if events := readSSE(t, &oneByteReader{r: strings.NewReader(tc.input)}); !reflect.DeepEqual(events, tc.expected) {
+				t.Errorf("expected %q when read byte by byte, got %q", tc.expected, events)
+			}
+		})
+	}
+}
+
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p[:1])
+}
+
+func TestConversationStreamEventNames(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: conversation.response.started\r\ndata: {\"conversation_id\":\"conv-1\"}\r\n\r\n")
+		_, _ = io.WriteString(w, "event: message.output.delta\r\ndata: {\"type\":\"message.output.delta\",\r\ndata: \"content\":\"hi\"}\r\n\r\n")
+		_, _ = io.WriteString(w, "data: [DONE]\r\n\r\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().StartConversationStream(&ConversationStartRequest{Inputs: []ConversationInput{{Type: "message.input", Content: "hi"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var received []ConversationStreamEvent
+	for stream.Next() {
+		received = append(received, stream.Current())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+	if len(received) != 2 || received[0].Type != "conversation.response.started" || received[1].Data["content"] != "hi" {
+		t.Errorf("expected the event name as type and multi-line data, got %+v", received)
+	}
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		// Keep-alive comments hold the stream open past the idle timeout.
+		for i := 0; i < 4; i++ {
+			_, _ = io.WriteString(w, ": ping\n\n")
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer mock.Close()
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(mock.Server.URL), WithStreamIdleTimeout(50*time.Millisecond))
+
+	stream, err := client.ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stream.Next() || stream.Current().Choices[0].Delta.Content != "a" {
+		t.Fatalf("expected the chunk after the keep-alives, got %+v, %v", stream.Current(), stream.Err())
+	}
+	if stream.Next() || !errors.Is(stream.Err(), ErrStreamIdle) {
+		t.Fatalf("expected an idle timeout error, got %v", stream.Err())
+	}
+}
+
+func TestWorkflowStreamResumesFromLastEventID(t *testing.T) {
+	var lastEventIDs []string
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.URL.Query().Get("last_event_id"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		if r.URL.Query().Get("last_event_id") == "" {
+			_, _ = io.WriteString(w, "retry: 1\nid: evt-1\nevent: log\ndata: {\"message\":\"first\"}\n\n")
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler) // Drop the connection mid-stream
+		}
+		_, _ = io.WriteString(w, "id: evt-2\nevent: log\ndata: {\"message\":\"second\"}\n\n")
+	})
+	defer mock.Close()
+
+	events, err := mock.GetClient().StreamWorkflowExecutionLogs("exec", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var received []StreamEvent
+	for event := range events {
+		if event.Error != nil {
+			t.Fatalf("unexpected stream error: %v", event.Error)
+		}
+		received = append(received, event)
+	}
+	if len(received) != 2 || received[0].ID != "evt-1" || received[1].Data["message"] != "second" || received[1].Type != "log" {
+		t.Errorf("expected both events across the reconnect, got %+v", received)
+	}
+	if !reflect.DeepEqual(lastEventIDs, []string{"", "evt-1"}) {
+		t.Errorf("expected a reconnect with last_event_id=evt-1, got %q", lastEventIDs)
+	}
+}
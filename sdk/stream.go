@@ -0,0 +1,207 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxStreamResumes bounds how often a resumable stream reconnects after a read error.
+const maxStreamResumes = 3
+
+// defaultSSERetry is the reconnection delay used until the server sends a retry: field.
+const defaultSSERetry = time.Second
+
+// Stream reads the events of a streaming response one at a time:
+//
+//	stream, err := client.ChatStream(model, messages, nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for stream.Next() {
+//		fmt.Print(stream.Current().Choices[0].Delta.Content)
+//	}
+//	return stream.Err()
+//
+// The stream ends at the end of the response, on a "[DONE]" event, or on the first error. A Stream
+// is not safe for concurrent use, except that Close may be called from another goroutine to abort
+// a blocked Next.
+type Stream[T any] struct {
+	ctx     context.Context
+	decoder *SSEDecoder
+	idle    time.Duration
+	decode  func(event *SSEEvent) (T, error)
+	resume  func(ctx context.Context, lastEventID string) (io.ReadCloser, error)
+	resumes int
+	current T
+	err     error
+	done    bool
+
+	// skipBadEvents keeps the stream going past events that fail to decode, reporting them through
+	// eventErr instead. The ...Chan wrappers set it, as the channel API always did.
+	skipBadEvents bool
+	eventErr      error
+
+	mu      sync.Mutex
+	body    io.ReadCloser
+	closed  bool
+	onClose func() // Called once when the stream is closed or ends
+}
+
+// newStream returns a Stream decoding the events of body with decode. When resume is not nil, a
+// read error after an event with an ID reopens the stream with resume.
+func newStream[T any](ctx context.Context, body io.ReadCloser, idle time.Duration, decode func(event *SSEEvent) (T, error), resume func(ctx context.Context, lastEventID string) (io.ReadCloser, error)) *Stream[T] {
+	body = newIdleBody(body, idle)
+	return &Stream[T]{
+		ctx:     ctx,
+		decoder: NewSSEDecoder(body),
+		idle:    idle,
+		decode:  decode,
+		resume:  resume,
+		body:    body,
+	}
+}
+
+// Next advances to the next event, which Current then returns. It returns false once the stream
+// has ended or failed; Err tells which.
+func (s *Stream[T]) Next() bool {
+	if s.done {
+		return false
+	}
+	for {
+		event, err := s.decoder.Next()
+		if err != nil {
+			if err == io.EOF || s.isClosed() {
+				s.finish(nil)
+				return false
+			}
+			if err := s.reconnect(err); err != nil {
+				s.finish(err)
+				return false
+			}
+			continue
+		}
+		s.resumes = 0
+
+		if bytes.Equal(event.Data, []byte("[DONE]")) {
+			s.finish(nil)
+			return false
+		}
+		if apiErr := streamAPIError(event.Data); apiErr != nil {
+			s.finish(apiErr)
+			return false
+		}
+		if event.Event == "error" {
+			s.finish(newAPIError(0, nil, event.Data))
+			return false
+		}
+		value, err := s.decode(event)
+		if err != nil && !s.skipBadEvents {
+			s.finish(err)
+			return false
+		}
+		s.current, s.eventErr = value, err
+		return true
+	}
+}
+
+// Current returns the event read by the last call to Next.
+func (s *Stream[T]) Current() T {
+	return s.current
+}
+
+// Err returns the error that ended the stream, or nil when it ended normally or was closed.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close aborts the underlying connection. It is safe to call more than once and after the
+// stream has ended.
+func (s *Stream[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.onClose != nil {
+		s.onClose()
+	}
+	return s.body.Close()
+}
+
+func (s *Stream[T]) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *Stream[T]) finish(err error) {
+	s.err, s.done = err, true
+	s.Close()
+}
+
+// reconnect reopens a resumable stream after readErr. It returns the error that ends the stream
+// when the stream cannot be resumed.
+func (s *Stream[T]) reconnect(readErr error) error {
+	lastEventID := s.decoder.LastEventID()
+	if s.resume == nil || lastEventID == "" || s.resumes >= maxStreamResumes || s.ctx.Err() != nil {
+		return fmt.Errorf("error reading stream response: %w", readErr)
+	}
+	s.resumes++
+	delay := defaultSSERetry
+	if s.decoder.retry > 0 {
+		delay = s.decoder.retry
+	}
+
+	s.mu.Lock()
+	s.body.Close()
+	s.mu.Unlock()
+	if err := sleepCtx(s.ctx, delay); err != nil {
+		return err
+	}
+	next, err := s.resume(s.ctx, lastEventID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		next.Close()
+		return nil
+	}
+	s.body = newIdleBody(next, s.idle)
+	s.decoder = &SSEDecoder{r: s.body, buf: s.decoder.buf[:0], lastEventID: lastEventID, retry: s.decoder.retry}
+	return nil
+}
+
+// channel delivers the events of s on a channel, the form returned by the ...Chan methods. An
+// event that fails to decode is delivered through fail and the stream goes on; an error that ends
+// the stream is delivered through fail too. The channel is closed, and s with it, once the stream
+// ends or ctx is done.
+func (s *Stream[T]) channel(ctx context.Context, fail func(err error) T) <-chan T {
+	out := make(chan T)
+	s.skipBadEvents = true
+	go func() {
+		defer close(out)
+		defer s.Close()
+		for s.Next() {
+			event := s.Current()
+			if s.eventErr != nil {
+				event = fail(s.eventErr)
+			}
+			if !sendEvent(ctx, out, event) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			sendEvent(ctx, out, fail(err))
+		}
+	}()
+	return out
+}
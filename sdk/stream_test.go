@@ -0,0 +1,159 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStreamIteration(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	var text string
+	for stream.Next() {
+		text += stream.Current().Choices[0].Delta.Content
+	}
+	if err := stream.Err(); err != nil || text != "ab" {
+		t.Errorf("expected \"ab\" and no error, got %q, %v", text, err)
+	}
+	if stream.Next() {
+		t.Error("expected Next to keep returning false after the end")
+	}
+	if err := stream.Close(); err != nil {
+		t.Errorf("expected Close after the end to succeed, got %v", err)
+	}
+}
+
+func TestStreamErrorEvent(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"object\":\"error\",\"message\":\"Service overloaded\",\"type\":\"service_unavailable\",\"code\":\"503\"}\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().FIMStream(&FIMRequestParams{Model: "codestral-latest", Prompt: "def"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chunks := 0
+	for stream.Next() {
+		chunks++
+	}
+	var apiErr *MistralAPIError
+	if chunks != 1 || !errors.As(stream.Err(), &apiErr) || apiErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected one chunk and a 503 error, got %d chunks and %v", chunks, stream.Err())
+	}
+}
+
+func TestStreamDecodeErrors(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	// The iterator stops at the first event that cannot be decoded.
+	stream, err := mock.GetClient().ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chunks := 0
+	for stream.Next() {
+		chunks++
+	}
+	if chunks != 1 || stream.Err() == nil {
+		t.Errorf("expected one chunk and a decode error, got %d chunks and %v", chunks, stream.Err())
+	}
+
+	// The channel delivers the error and goes on.
+	events, err := mock.GetClient().ChatStreamChan("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for event := range events {
+		if event.Error != nil {
+			got = append(got, "error")
+			continue
+		}
+		got = append(got, event.Choices[0].Delta.Content)
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "error" || got[2] != "b" {
+		t.Errorf("expected a, an error, and b, got %v", got)
+	}
+}
+
+func TestStreamKeepsTypedEventsWithErrorFields(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: tool.execution.done\ndata: {\"type\":\"tool.execution.done\",\"name\":\"web_search\",\"error\":\"timeout\"}\n\n")
+		_, _ = io.WriteString(w, "event: conversation.response.done\ndata: {\"type\":\"conversation.response.done\"}\n\n")
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().StartConversationStream(&ConversationStartRequest{Inputs: []ConversationInput{{Type: "text", Content: "hi"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var types []string
+	for stream.Next() {
+		types = append(types, stream.Current().Type)
+	}
+	if err := stream.Err(); err != nil || len(types) != 2 || types[0] != "tool.execution.done" {
+		t.Errorf("expected both events and no error, got %v, %v", types, err)
+	}
+}
+
+func TestStreamCloseAbortsConnection(t *testing.T) {
+	aborted := make(chan struct{})
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+			close(aborted)
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer mock.Close()
+
+	stream, err := mock.GetClient().ChatStream("mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stream.Next() {
+		t.Fatalf("expected a first chunk, got %v", stream.Err())
+	}
+
+	// Close from another goroutine unblocks a Next waiting for data.
+	time.AfterFunc(50*time.Millisecond, func() { stream.Close() })
+	if stream.Next() {
+		t.Fatal("expected no more chunks after Close")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("expected no error after Close, got %v", err)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Close to abort the connection")
+	}
+}
@@ -0,0 +1,555 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchema is the json_schema of a structured output response format.
+type JSONSchema struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema"`
+	Strict      bool           `json:"strict"`
+}
+
+// StructuredOutputError is returned when a reply does not match the requested JSON schema.
+type StructuredOutputError struct {
+	Content string // The reply content
+	Err     error
+}
+
+func (e *StructuredOutputError) Error() string {
+	return "mistral: reply does not match the JSON schema: " + e.Err.Error()
+}
+
+func (e *StructuredOutputError) Unwrap() error {
+	return e.Err
+}
+
+type structuredConfig struct {
+	name        string
+	description string
+	strict      bool
+	repair      bool
+}
+
+// StructuredOption configures NewJSONSchema and the structured output helpers.
+type StructuredOption func(*structuredConfig)
+
+// WithSchemaName sets the schema name, which defaults to the name of the Go type.
+func WithSchemaName(name string) StructuredOption {
+	return func(c *structuredConfig) {
+		c.name = name
+	}
+}
+
+// WithSchemaDescription sets the schema description.
+func WithSchemaDescription(description string) StructuredOption {
+	return func(c *structuredConfig) {
+		c.description = description
+	}
+}
+
+// WithStrictSchema sets whether the model must follow the schema strictly. It defaults to true.
+func WithStrictSchema(strict bool) StructuredOption {
+	return func(c *structuredConfig) {
+		c.strict = strict
+	}
+}
+
+// WithRepairRetry makes ChatStructured and AgentCompleteStructured retry once when the reply does
+// not match the schema, sending the reply back with the validation error and asking for a fix.
+func WithRepairRetry() StructuredOption {
+	return func(c *structuredConfig) {
+		c.repair = true
+	}
+}
+
+func newStructuredConfig(opts []StructuredOption) *structuredConfig {
+	config := &structuredConfig{strict: true}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+var schemaNameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// NewJSONSchema reflects a JSON schema from T. Struct fields are named after their json tags and
+// are required unless tagged omitempty; pointer fields also accept null, and structs do not allow
+// additional properties. A description tag documents a field and an enum tag lists its allowed
+// values, separated by commas:
+//
+//	type Ticket struct {
+//		Title    string   `json:"title" description:"One-line summary"`
+//		Priority string   `json:"priority" enum:"low,medium,high"`
+//		Labels   []string `json:"labels,omitempty"`
+//	}
+//
+// Recursive types are not supported.
+func NewJSONSchema[T any](opts ...StructuredOption) (*JSONSchema, error) {
+	config := newStructuredConfig(opts)
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	schema, err := (&schemaBuilder{seen: map[reflect.Type]bool{}}).build(t)
+	if err != nil {
+		return nil, err
+	}
+	name := config.name
+	if name == "" {
+		name = schemaNameInvalid.ReplaceAllString(t.Name(), "_")
+	}
+	if name == "" {
+		name = "response"
+	}
+	return &JSONSchema{Name: name, Description: config.description, Schema: schema, Strict: config.strict}, nil
+}
+
+// ResponseFormat returns the response_format requesting output that matches s.
+func (s *JSONSchema) ResponseFormat() *ResponseFormatSpec {
+	return &ResponseFormatSpec{Type: ResponseFormatJsonSchema, JSONSchema: s}
+}
+
+type schemaBuilder struct {
+	seen map[reflect.Type]bool
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+func (b *schemaBuilder) build(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}, nil // encoding/json uses base64
+		}
+		items, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("mistral: unsupported map key type %s in JSON schema", t.Key())
+		}
+		values, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if b.seen[t] {
+			return nil, fmt.Errorf("mistral: recursive type %s is not supported in JSON schema", t)
+		}
+		b.seen[t] = true
+		defer delete(b.seen, t)
+		schema := map[string]any{
+			"type":                 "object",
+			"properties":           map[string]any{},
+			"required":             []string{},
+			"additionalProperties": false,
+		}
+		if err := b.addFields(schema, t); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	}
+	return nil, fmt.Errorf("mistral: unsupported type %s in JSON schema", t)
+}
+
+// addFields adds the fields of struct type t, and of its embedded structs, to an object schema.
+func (b *schemaBuilder) addFields(schema map[string]any, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if err := b.addFields(schema, fieldType); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := b.build(field.Type)
+		if err != nil {
+			return err
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			property["description"] = description
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			target := property
+			if items, ok := property["items"].(map[string]any); ok {
+				target = items
+			}
+			values, err := enumValues(enum, target["type"])
+			if err != nil {
+				return fmt.Errorf("mistral: field %s: %w", field.Name, err)
+			}
+			target["enum"] = values
+		}
+		if field.Type.Kind() == reflect.Pointer {
+			nullable(property)
+		}
+		schema["properties"].(map[string]any)[name] = property
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			schema["required"] = append(schema["required"].([]string), name)
+		}
+	}
+	return nil
+}
+
+// nullable makes a typed schema also accept null, as encoding/json does for pointers.
+func nullable(schema map[string]any) {
+	schemaType, ok := schema["type"].(string)
+	if !ok {
+		return // Untyped schemas already accept null
+	}
+	schema["type"] = []string{schemaType, "null"}
+	if enum, ok := schema["enum"].([]any); ok {
+		schema["enum"] = append(enum, nil)
+	}
+}
+
+func enumValues(enum string, schemaType any) ([]any, error) {
+	var values []any
+	for _, value := range strings.Split(enum, ",") {
+		switch schemaType {
+		case "string":
+			values = append(values, value)
+		case "integer":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer enum value %q", value)
+			}
+			values = append(values, n)
+		default:
+			return nil, fmt.Errorf("enum is only supported for strings and integers")
+		}
+	}
+	return values, nil
+}
+
+// Validate reports whether the JSON document content matches the schema. It supports the subset
+// of JSON Schema produced by NewJSONSchema: type, properties, required, additionalProperties,
+// items and enum.
+func (s *JSONSchema) Validate(content []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return validateSchema(s.Schema, value, "$")
+}
+
+func validateSchema(schema map[string]any, value any, path string) error {
+	if types := schemaTypes(schema); len(types) > 0 && !hasAnySchemaType(value, types) {
+		return fmt.Errorf("%s: expected %s", path, strings.Join(types, " or "))
+	}
+	if enum, ok := schema["enum"]; ok {
+		found := false
+		for _, allowed := range anySlice(enum) {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		for _, name := range anySlice(schema["required"]) {
+			if _, ok := typed[fmt.Sprint(name)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, item := range typed {
+			itemSchema, ok := properties[name].(map[string]any)
+			if !ok {
+				switch additional := schema["additionalProperties"].(type) {
+				case bool:
+					if !additional {
+						return fmt.Errorf("%s: unexpected property %q", path, name)
+					}
+					continue
+				case map[string]any:
+					itemSchema = additional
+				default:
+					continue
+				}
+			}
+			if err := validateSchema(itemSchema, item, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range typed {
+				if err := validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the types a schema allows, given as a single type or a list.
+func schemaTypes(schema map[string]any) []string {
+	if schemaType, ok := schema["type"].(string); ok {
+		return []string{schemaType}
+	}
+	var types []string
+	for _, schemaType := range anySlice(schema["type"]) {
+		types = append(types, fmt.Sprint(schemaType))
+	}
+	return types
+}
+
+func hasAnySchemaType(value any, types []string) bool {
+	for _, schemaType := range types {
+		if hasSchemaType(value, schemaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSchemaType(value any, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+// anySlice returns the elements of a []any or []string from a schema.
+func anySlice(v any) []any {
+	switch typed := v.(type) {
+	case []any:
+		return typed
+	case []string:
+		out := make([]any, len(typed))
+		for i, s := range typed {
+			out[i] = s
+		}
+		return out
+	}
+	return nil
+}
+
+// DecodeStructured validates content against schema and decodes it into a T. Surrounding whitespace
+// and a Markdown code fence are ignored. Validation failures are returned as *StructuredOutputError.
+func DecodeStructured[T any](schema *JSONSchema, content string) (T, error) {
+	var out T
+	data := []byte(stripCodeFence(content))
+	if err := schema.Validate(data); err != nil {
+		return out, &StructuredOutputError{Content: content, Err: err}
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, &StructuredOutputError{Content: content, Err: err}
+	}
+	return out, nil
+}
+
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimSuffix(content, "```")
+	if i := strings.IndexByte(content, '\n'); i >= 0 {
+		content = content[i+1:]
+	}
+	return strings.TrimSpace(content)
+}
+
+// repairMessages returns the messages asking the model to fix a reply that failed validation.
+func repairMessages(messages []ChatMessage, reply ChatMessage, err error) []ChatMessage {
+	repaired := append([]ChatMessage(nil), messages...)
+	return append(repaired,
+		AssistantMessage(reply.Text()),
+		UserMessage(fmt.Sprintf("Your reply did not match the required JSON schema (%v). Reply again with only the corrected JSON.", err)),
+	)
+}
+
+// decodeChoice decodes the first choice of resp into a T.
+func decodeChoice[T any](schema *JSONSchema, resp *ChatCompletionResponse) (T, error) {
+	if len(resp.Choices) == 0 {
+		var zero T
+		return zero, &StructuredOutputError{Err: fmt.Errorf("no choices in the response")}
+	}
+	return DecodeStructured[T](schema, resp.Choices[0].Message.Text())
+}
+
+// ChatStructured asks for a reply matching the JSON schema reflected from T, with NewJSONSchema,
+// and decodes it. params may be nil; its ResponseFormat is replaced. A reply that does not match
+// is returned as *StructuredOutputError, along with the response, unless WithRepairRetry fixes it.
+func ChatStructured[T any](client *MistralClient, model string, messages []ChatMessage, params *ChatRequestParams, opts ...StructuredOption) (T, *ChatCompletionResponse, error) {
+	return ChatStructuredCtx[T](context.Background(), client, model, messages, params, opts...)
+}
+
+// ChatStructuredCtx is like ChatStructured but uses ctx for cancellation and deadlines.
+func ChatStructuredCtx[T any](ctx context.Context, client *MistralClient, model string, messages []ChatMessage, params *ChatRequestParams, opts ...StructuredOption) (T, *ChatCompletionResponse, error) {
+	var zero T
+	config := newStructuredConfig(opts)
+	schema, err := NewJSONSchema[T](opts...)
+	if err != nil {
+		return zero, nil, err
+	}
+	structuredParams := NewChatRequestParams()
+	if params != nil {
+		*structuredParams = *params
+	}
+	structuredParams.ResponseFormat = schema.ResponseFormat()
+
+	resp, err := client.ChatCtx(ctx, model, messages, structuredParams)
+	if err != nil {
+		return zero, nil, err
+	}
+	out, err := decodeChoice[T](schema, resp)
+	if err == nil || !config.repair || len(resp.Choices) == 0 {
+		return out, resp, err
+	}
+	if resp, err = client.ChatCtx(ctx, model, repairMessages(messages, resp.Choices[0].Message, err), structuredParams); err != nil {
+		return zero, nil, err
+	}
+	out, err = decodeChoice[T](schema, resp)
+	return out, resp, err
+}
+
+// AgentCompleteStructured is like ChatStructured for agent completions.
+func AgentCompleteStructured[T any](client *MistralClient, agentID string, messages []ChatMessage, params *AgentCompletionRequest, opts ...StructuredOption) (T, *ChatCompletionResponse, error) {
+	return AgentCompleteStructuredCtx[T](context.Background(), client, agentID, messages, params, opts...)
+}
+
+// AgentCompleteStructuredCtx is like AgentCompleteStructured but uses ctx for cancellation and deadlines.
+func AgentCompleteStructuredCtx[T any](ctx context.Context, client *MistralClient, agentID string, messages []ChatMessage, params *AgentCompletionRequest, opts ...StructuredOption) (T, *ChatCompletionResponse, error) {
+	var zero T
+	config := newStructuredConfig(opts)
+	schema, err := NewJSONSchema[T](opts...)
+	if err != nil {
+		return zero, nil, err
+	}
+	structuredParams := &AgentCompletionRequest{}
+	if params != nil {
+		*structuredParams = *params
+	}
+	structuredParams.ResponseFormat = schema.ResponseFormat()
+
+	resp, err := client.AgentCompleteCtx(ctx, agentID, messages, structuredParams)
+	if err != nil {
+		return zero, nil, err
+	}
+	out, err := decodeChoice[T](schema, resp)
+	if err == nil || !config.repair || len(resp.Choices) == 0 {
+		return out, resp, err
+	}
+	if resp, err = client.AgentCompleteCtx(ctx, agentID, repairMessages(messages, resp.Choices[0].Message, err), structuredParams); err != nil {
+		return zero, nil, err
+	}
+	out, err = decodeChoice[T](schema, resp)
+	return out, resp, err
+}
+
+// ProcessOCRStructured runs OCR with a document annotation schema reflected from T and decodes the
+// document annotation. params may be nil; its DocumentAnnotationSchema is replaced. Use
+// NewJSONSchema for OCRRequest.BboxAnnotationSchema and DecodeStructured on the image annotations
+// to get typed bounding box annotations.
+func ProcessOCRStructured[T any](client *MistralClient, model string, document OCRDocument, params *OCRRequest, opts ...StructuredOption) (T, *OCRResponse, error) {
+	return ProcessOCRStructuredCtx[T](context.Background(), client, model, document, params, opts...)
+}
+
+// ProcessOCRStructuredCtx is like ProcessOCRStructured but uses ctx for cancellation and deadlines.
+func ProcessOCRStructuredCtx[T any](ctx context.Context, client *MistralClient, model string, document OCRDocument, params *OCRRequest, opts ...StructuredOption) (T, *OCRResponse, error) {
+	var zero T
+	schema, err := NewJSONSchema[T](opts...)
+	if err != nil {
+		return zero, nil, err
+	}
+	structuredParams := &OCRRequest{}
+	if params != nil {
+		*structuredParams = *params
+	}
+	structuredParams.DocumentAnnotationSchema = schema
+
+	resp, err := client.ProcessOCRCtx(ctx, model, document, structuredParams)
+	if err != nil {
+		return zero, nil, err
+	}
+	if resp.DocumentAnnotation == nil {
+		return zero, resp, &StructuredOutputError{Err: fmt.Errorf("no document annotation in the response")}
+	}
+	out, err := DecodeStructured[T](schema, *resp.DocumentAnnotation)
+	return out, resp, err
+}
@@ -0,0 +1,261 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country,omitempty"`
+}
+
+type schemaBase struct {
+	ID string `json:"id"`
+}
+
+type schemaTicket struct {
+	schemaBase
+	Title    string         `json:"title" description:"One-line summary"`
+	Priority string         `json:"priority" enum:"low,medium,high"`
+	Severity int            `json:"severity" enum:"1,2,3"`
+	Labels   []string       `json:"labels,omitempty" enum:"bug,feature"`
+	Due      *time.Time     `json:"due,omitempty"`
+	Address  *schemaAddress `json:"address"`
+	Extra    map[string]int `json:"extra,omitempty"`
+	Score    float64        `json:"score"`
+	Done     bool           `json:"done"`
+	Ignored  string         `json:"-"`
+	internal string
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+type schemaNode struct {
+	Children []schemaNode `json:"children"`
+}
+
+func TestNewJSONSchema(t *testing.T) {
+	schema, err := NewJSONSchema[schemaTicket](WithSchemaDescription("A support ticket"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(schema)
+	expected := `{"name":"schemaTicket","description":"A support ticket","schema":{"additionalProperties":false,"properties":{` +
+		`"address":{"additionalProperties":false,"properties":{"city":{"type":"string"},"country":{"type":"string"}},"required":["city"],"type":["object","null"]},` +
+		`"done":{"type":"boolean"},"due":{"format":"date-time","type":["string","null"]},"extra":{"additionalProperties":{"type":"integer"},"type":"object"},` +
+		`"id":{"type":"string"},"labels":{"items":{"enum":["bug","feature"],"type":"string"},"type":"array"},"priority":{"enum":["low","medium","high"],"type":"string"},` +
+		`"raw":{},"score":{"type":"number"},"severity":{"enum":[1,2,3],"type":"integer"},"tags":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"title":{"description":"One-line summary","type":"string"}},` +
+		`"required":["id","title","priority","severity","address","score","done"],"type":"object"},"strict":true}`
+	if string(data) != expected {
+		t.Errorf("expected %s\n got %s", expected, data)
+	}
+
+	if _, err := NewJSONSchema[schemaNode](); err == nil {
+		t.Error("expected an error for a recursive type")
+	}
+	if schema, err := NewJSONSchema[[]string](WithSchemaName("names"), WithStrictSchema(false)); err != nil || schema.Name != "names" || schema.Strict {
+		t.Errorf("unexpected schema %+v, %v", schema, err)
+	}
+}
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema, err := NewJSONSchema[schemaTicket]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := `{"id":"1","title":"t","priority":"low","severity":2,"address":{"city":"Paris"},"score":0.5,"done":false}`
+	testCases := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"valid", valid, ""},
+		{"missing required", `{"id":"1"}`, `missing required property "title"`},
+		{"wrong type", strings.Replace(valid, `"done":false`, `"done":"no"`, 1), "$.done: expected boolean"},
+		{"not an integer", strings.Replace(valid, `"severity":2`, `"severity":2.5`, 1), "$.severity: expected integer"},
+		{"enum", strings.Replace(valid, `"low"`, `"urgent"`, 1), "$.priority: urgent is not one of"},
+		{"enum items", strings.Replace(valid, `"done":false`, `"done":false,"labels":["bug","spam"]`, 1), "$.labels[1]"},
+		{"nested", strings.Replace(valid, `{"city":"Paris"}`, `{"country":"FR"}`, 1), `$.address: missing required property "city"`},
+		{"null pointer", strings.Replace(valid, `{"city":"Paris"}`, `null`, 1), ""},
+		{"null value", strings.Replace(valid, `"title":"t"`, `"title":null`, 1), "$.title: expected string"},
+		{"additional property", strings.Replace(valid, `"done":false`, `"done":false,"owner":"me"`, 1), `unexpected property "owner"`},
+		{"map values", strings.Replace(valid, `"done":false`, `"done":false,"extra":{"a":"b"}`, 1), "$.extra.a: expected integer"},
+		{"invalid JSON", `{"id":`, "invalid JSON"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.Validate([]byte(tc.content))
+			if tc.errText == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.errText != "" && (err == nil || !strings.Contains(err.Error(), tc.errText)) {
+				t.Errorf("expected an error containing %q, got %v", tc.errText, err)
+			}
+		})
+	}
+}
+
+type schemaAssignment struct {
+	Owner *string `json:"owner" enum:"alice,bob"`
+}
+
+func TestJSONSchemaNullablePointers(t *testing.T) {
+	schema, err := NewJSONSchema[schemaAssignment]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(schema.Schema["properties"])
+	if expected := `{"owner":{"enum":["alice","bob",null],"type":["string","null"]}}`; string(data) != expected {
+		t.Errorf("expected %s\n got %s", expected, data)
+	}
+
+	assignment, err := DecodeStructured[schemaAssignment](schema, `{"owner":null}`)
+	if err != nil || assignment.Owner != nil {
+		t.Errorf("expected a nil owner, got %+v, %v", assignment, err)
+	}
+	if assignment, err := DecodeStructured[schemaAssignment](schema, `{"owner":"bob"}`); err != nil || assignment.Owner == nil || *assignment.Owner != "bob" {
+		t.Errorf("expected bob, got %+v, %v", assignment, err)
+	}
+	if err := schema.Validate([]byte(`{"owner":"carol"}`)); err == nil || !strings.Contains(err.Error(), "is not one of") {
+		t.Errorf("expected an enum error, got %v", err)
+	}
+	if err := schema.Validate([]byte(`{"owner":1}`)); err == nil || !strings.Contains(err.Error(), "$.owner: expected string or null") {
+		t.Errorf("expected a type error, got %v", err)
+	}
+}
+
+type weather struct {
+	City        string  `json:"city"`
+	Temperature float64 `json:"temperature" description:"Degrees Celsius"`
+}
+
+func chatReply(content string) string {
+	data, _ := json.Marshal(map[string]any{
+		"id":      "cmpl-1",
+		"object":  "chat.completion",
+		"model":   "mistral-small-latest",
+		"choices": []any{map[string]any{"index": 0, "finish_reason": "stop", "message": map[string]any{"role": "assistant", "content": content}}},
+	})
+	return string(data)
+}
+
+func TestChatStructured(t *testing.T) {
+	var formats []map[string]any
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResponseFormat map[string]any `json:"response_format"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		formats = append(formats, body.ResponseFormat)
+		MockJSONResponse(http.StatusOK, chatReply("```json\n{\"city\":\"Paris\",\"temperature\":21.5}\n```")).Write(w)
+	})
+	defer mock.Close()
+
+	params := &ChatRequestParams{Temperature: Float64Ptr(0.1), ResponseFormat: ResponseFormatText}
+	out, resp, err := ChatStructured[weather](mock.GetClient(), "mistral-small-latest", []ChatMessage{UserMessage("Weather in Paris?")}, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != (weather{City: "Paris", Temperature: 21.5}) || resp.ID != "cmpl-1" {
+		t.Errorf("unexpected result %+v, %+v", out, resp)
+	}
+	jsonSchema, _ := formats[0]["json_schema"].(map[string]any)
+	if formats[0]["type"] != "json_schema" || jsonSchema["name"] != "weather" || jsonSchema["strict"] != true || jsonSchema["schema"] == nil {
+		t.Errorf("unexpected response_format %v", formats[0])
+	}
+	if params.ResponseFormat != ResponseFormatText {
+		t.Error("expected the caller's params to be left alone")
+	}
+}
+
+func TestChatStructuredRepairRetry(t *testing.T) {
+	var requests []ChatMessage
+	replies := []string{`{"city":"Paris"}`, `{"city":"Paris","temperature":21}`}
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []ChatMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body.Messages[len(body.Messages)-1])
+		MockJSONResponse(http.StatusOK, chatReply(replies[(len(requests)-1)%len(replies)])).Write(w)
+	})
+	defer mock.Close()
+	client := mock.GetClient()
+	messages := []ChatMessage{UserMessage("Weather in Paris?")}
+
+	_, resp, err := ChatStructured[weather](client, "mistral-small-latest", messages, nil)
+	var outputErr *StructuredOutputError
+	if !errors.As(err, &outputErr) || outputErr.Content != `{"city":"Paris"}` || resp == nil {
+		t.Fatalf("expected a StructuredOutputError with the reply, got %v", err)
+	}
+
+	requests = nil
+	out, _, err := ChatStructured[weather](client, "mistral-small-latest", messages, nil, WithRepairRetry())
+	if err != nil || out.Temperature != 21 {
+		t.Fatalf("expected the repair retry to succeed, got %+v, %v", out, err)
+	}
+	if len(requests) != 2 || !strings.Contains(requests[1].Content, `missing required property "temperature"`) {
+		t.Errorf("expected a repair request with the validation error, got %+v", requests)
+	}
+}
+
+func TestAgentCompleteStructured(t *testing.T) {
+	var body map[string]any
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		MockJSONResponse(http.StatusOK, chatReply(`{"city":"Rome","temperature":25}`)).Write(w)
+	})
+	defer mock.Close()
+
+	out, _, err := AgentCompleteStructured[weather](mock.GetClient(), "agent-1", []ChatMessage{UserMessage("Rome?")}, nil)
+	if err != nil || out.City != "Rome" {
+		t.Fatalf("unexpected result %+v, %v", out, err)
+	}
+	if format, _ := body["response_format"].(map[string]any); format["type"] != "json_schema" || body["agent_id"] != "agent-1" {
+		t.Errorf("unexpected request %v", body)
+	}
+}
+
+func TestProcessOCRStructured(t *testing.T) {
+	type invoice struct {
+		Number string  `json:"number"`
+		Total  float64 `json:"total"`
+	}
+	type figure struct {
+		Caption string `json:"caption"`
+	}
+	var body map[string]any
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		MockJSONResponse(http.StatusOK, fmt.Sprintf(`{"id":"ocr-1","model":"mistral-ocr-latest","pages":[{"page_number":0,"text":"","images":[{"image_annotation":%q}]}],"document_annotation":%q}`,
+			`{"caption":"Logo"}`, `{"number":"INV-7","total":99.5}`)).Write(w)
+	})
+	defer mock.Close()
+
+	bbox, err := NewJSONSchema[figure]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "https://example.com/invoice.pdf"
+	out, resp, err := ProcessOCRStructured[invoice](mock.GetClient(), "mistral-ocr-latest", OCRDocument{URL: &url}, &OCRRequest{BboxAnnotationSchema: bbox})
+	if err != nil || out.Number != "INV-7" || out.Total != 99.5 {
+		t.Fatalf("unexpected result %+v, %v", out, err)
+	}
+	for _, key := range []string{"document_annotation_format", "bbox_annotation_format"} {
+		if format, _ := body[key].(map[string]any); format["type"] != "json_schema" || format["json_schema"] == nil {
+			t.Errorf("expected a json_schema %s, got %v", key, body[key])
+		}
+	}
+	caption, err := DecodeStructured[figure](bbox, *resp.Pages[0].Images[0].ImageAnnotation)
+	if err != nil || caption.Caption != "Logo" {
+		t.Errorf("unexpected bbox annotation %+v, %v", caption, err)
+	}
+}
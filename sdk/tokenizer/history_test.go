@@ -0,0 +1,31 @@
+package tokenizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+func TestCountMessagesBudgetsChatHistory(t *testing.T) {
+	tok := loadTekken(t)
+	var _ sdk.MessageCounter = tok.CountMessages
+
+	last := []sdk.ChatMessage{{Role: sdk.RoleUser, Content: "hello"}}
+	history := sdk.NewChatHistory("", sdk.WithHistoryBudget(tok.CountMessages(last, nil)), sdk.WithHistoryCounter(tok.CountMessages))
+	history.Add(
+		sdk.ChatMessage{Role: sdk.RoleUser, Content: "hello hello"},
+		sdk.ChatMessage{Role: sdk.RoleAssistant, Content: "hello"},
+	)
+	history.Add(last...)
+
+	if err := history.Fit(context.Background(), nil); err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	if got := history.Messages(); len(got) != 1 || got[0].Content != "hello" {
+		t.Errorf("Messages = %+v, want the last turn", got)
+	}
+	if got, want := history.Tokens(nil), tok.CountMessages(last, nil); got != want {
+		t.Errorf("Tokens = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package tokenizer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// SentencePiece piece types, from sentencepiece_model.proto.
+const (
+	pieceNormal      = 1
+	pieceUnknown     = 2
+	pieceControl     = 3
+	pieceUserDefined = 4
+	pieceUnused      = 5
+	pieceByte        = 6
+)
+
+const sentencePieceBPE = 2
+
+var errTruncated = errors.New("tokenizer: truncated SentencePiece model")
+
+type sentencePiece struct {
+	ids                    map[string]int
+	scores                 []float32
+	types                  []int
+	unk                    int
+	addDummyPrefix         bool
+	removeExtraWhitespaces bool
+}
+
+// ParseSentencePiece reads a SentencePiece tokenizer.model file. Only BPE models, which all
+// Mistral SentencePiece vocabularies are, are supported.
+func ParseSentencePiece(data []byte) (*Tokenizer, error) {
+	sp := &sentencePiece{ids: make(map[string]int), addDummyPrefix: true, removeExtraWhitespaces: true}
+	modelType := 1
+	err := readProto(data, func(field int, value uint64, raw []byte) error {
+		switch field {
+		case 1:
+			return sp.readPiece(raw)
+		case 2:
+			return readProto(raw, func(field int, value uint64, _ []byte) error {
+				if field == 3 {
+					modelType = int(value)
+				}
+				return nil
+			})
+		case 3:
+			return readProto(raw, func(field int, value uint64, _ []byte) error {
+				switch field {
+				case 3:
+					sp.addDummyPrefix = value != 0
+				case 4:
+					sp.removeExtraWhitespaces = value != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sp.scores) == 0 {
+		return nil, errors.New("tokenizer: SentencePiece model has no pieces")
+	}
+	if modelType != sentencePieceBPE {
+		return nil, fmt.Errorf("tokenizer: unsupported SentencePiece model type %d", modelType)
+	}
+
+	specials := make(map[string]int)
+	for piece, id := range sp.ids {
+		switch sp.types[id] {
+		case pieceControl:
+			specials[piece] = id
+		case pieceUnknown:
+			sp.unk = id
+		}
+	}
+	return &Tokenizer{encode: sp.encode, specials: specials}, nil
+}
+
+func (sp *sentencePiece) readPiece(data []byte) error {
+	piece, score, kind := "", float32(0), pieceNormal
+	err := readProto(data, func(field int, value uint64, raw []byte) error {
+		switch field {
+		case 1:
+			piece = string(raw)
+		case 2:
+			score = math.Float32frombits(uint32(value))
+		case 3:
+			kind = int(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if _, ok := sp.ids[piece]; !ok {
+		sp.ids[piece] = len(sp.scores)
+	}
+	sp.scores = append(sp.scores, score)
+	sp.types = append(sp.types, kind)
+	return nil
+}
+
+// readProto calls fn with every field of a protobuf message: the value of varint and fixed
+// fields, and the bytes of length-delimited ones.
+func readProto(data []byte, fn func(field int, value uint64, raw []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		var value uint64
+		var raw []byte
+		switch key & 7 {
+		case 0:
+			if value, n = binary.Uvarint(data); n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			value, data = binary.LittleEndian.Uint64(data), data[8:]
+		case 2:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return errTruncated
+			}
+			raw, data = data[n:n+int(size)], data[n+int(size):]
+		case 5:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		default:
+			return fmt.Errorf("tokenizer: invalid SentencePiece model: wire type %d", key&7)
+		}
+		if err := fn(int(key>>3), value, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode normalizes text like SentencePiece, with spaces as "▁", then merges adjacent pieces,
+// highest score first. Characters outside the vocabulary are encoded as byte pieces.
+func (sp *sentencePiece) encode(text string) []int {
+	if sp.removeExtraWhitespaces {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	if text == "" {
+		return nil
+	}
+	if sp.addDummyPrefix {
+		text = " " + text
+	}
+	text = strings.ReplaceAll(text, " ", "▁")
+
+	symbols := make([]string, 0, utf8.RuneCountInString(text))
+	for _, r := range text {
+		symbols = append(symbols, string(r))
+	}
+	for len(symbols) > 1 {
+		best, bestScore := -1, float32(0)
+		for i := 0; i+1 < len(symbols); i++ {
+			id, ok := sp.ids[symbols[i]+symbols[i+1]]
+			if !ok || sp.types[id] != pieceNormal && sp.types[id] != pieceUserDefined {
+				continue
+			}
+			if best < 0 || sp.scores[id] > bestScore {
+				best, bestScore = i, sp.scores[id]
+			}
+		}
+		if best < 0 {
+			break
+		}
+		symbols[best] += symbols[best+1]
+		symbols = append(symbols[:best+1], symbols[best+2:]...)
+	}
+
+	ids := make([]int, 0, len(symbols))
+	for _, symbol := range symbols {
+		if id, ok := sp.ids[symbol]; ok && sp.types[id] != pieceUnused {
+			ids = append(ids, id)
+			continue
+		}
+		for _, b := range []byte(symbol) {
+			if id, ok := sp.ids[fmt.Sprintf("<0x%02X>", b)]; ok {
+				ids = append(ids, id)
+			} else {
+				ids = append(ids, sp.unk)
+			}
+		}
+	}
+	return ids
+}
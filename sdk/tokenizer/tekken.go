@@ -0,0 +1,181 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTekkenSpecials are the control tokens of tekken files that predate the special_tokens
+// list, in rank order.
+var defaultTekkenSpecials = []string{
+	"<unk>", BOS, EOS, BeginInst, EndInst, BeginTools, EndTools, BeginToolResult, EndToolResult,
+	ToolCalls, "[IMG]", "<pad>", "[IMG_BREAK]", "[IMG_END]", Prefix, Middle, Suffix, BeginSystem,
+	EndSystem, "[TOOL_CONTENT]",
+}
+
+type tekkenFile struct {
+	Config struct {
+		Pattern                 string `json:"pattern"`
+		DefaultVocabSize        int    `json:"default_vocab_size"`
+		DefaultNumSpecialTokens int    `json:"default_num_special_tokens"`
+	} `json:"config"`
+	Vocab []struct {
+		Rank       int    `json:"rank"`
+		TokenBytes string `json:"token_bytes"`
+	} `json:"vocab"`
+	SpecialTokens []struct {
+		Rank     int    `json:"rank"`
+		TokenStr string `json:"token_str"`
+	} `json:"special_tokens"`
+}
+
+// lookaheadWhitespace is the alternative of the tekken pattern that needs a lookahead, which Go's
+// regexp package does not support. It is emulated by tekken.split.
+const lookaheadWhitespace = `\s+(?!\S)|`
+
+type tekken struct {
+	pattern    *regexp.Regexp
+	lookahead  bool
+	ranks      map[string]int
+	numSpecial int
+}
+
+// ParseTekken reads a tekken.json vocabulary.
+func ParseTekken(data []byte) (*Tokenizer, error) {
+	var file tekkenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("tokenizer: invalid tekken file: %w", err)
+	}
+	if len(file.Vocab) == 0 {
+		return nil, fmt.Errorf("tokenizer: tekken file has no vocabulary")
+	}
+
+	t := &tekken{ranks: make(map[string]int, len(file.Vocab)), numSpecial: file.Config.DefaultNumSpecialTokens}
+	if t.numSpecial == 0 {
+		t.numSpecial = 1000
+	}
+	pattern := file.Config.Pattern
+	if strings.Contains(pattern, lookaheadWhitespace) {
+		pattern = strings.Replace(pattern, lookaheadWhitespace, "", 1)
+		t.lookahead = true
+	}
+	var err error
+	if t.pattern, err = regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("tokenizer: unsupported tekken pattern: %w", err)
+	}
+
+	limit := len(file.Vocab)
+	if file.Config.DefaultVocabSize > 0 && file.Config.DefaultVocabSize-t.numSpecial < limit {
+		limit = file.Config.DefaultVocabSize - t.numSpecial
+	}
+	for _, token := range file.Vocab {
+		if token.Rank >= limit {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(token.TokenBytes)
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: invalid token bytes for rank %d: %w", token.Rank, err)
+		}
+		t.ranks[string(raw)] = token.Rank
+	}
+
+	specials := make(map[string]int)
+	if len(file.SpecialTokens) > 0 {
+		for _, special := range file.SpecialTokens {
+			specials[special.TokenStr] = special.Rank
+		}
+	} else {
+		for rank, name := range defaultTekkenSpecials {
+			specials[name] = rank
+		}
+	}
+	return &Tokenizer{encode: t.encode, specials: specials}, nil
+}
+
+func (t *tekken) encode(text string) []int {
+	var ids []int
+	for _, piece := range t.split(text) {
+		for _, rank := range bytePairEncode([]byte(piece), t.ranks) {
+			ids = append(ids, rank+t.numSpecial)
+		}
+	}
+	return ids
+}
+
+// split pre-tokenizes text with the pattern. Without the lookahead alternative, a run of
+// whitespace before a word would be matched whole; like the original pattern, the last whitespace
+// character is left to the word.
+func (t *tekken) split(text string) []string {
+	var pieces []string
+	for len(text) > 0 {
+		loc := t.pattern.FindStringIndex(text)
+		if loc == nil {
+			pieces = append(pieces, text)
+			break
+		}
+		start, end := loc[0], loc[1]
+		if start > 0 {
+			pieces = append(pieces, text[:start])
+		}
+		if end == start {
+			_, size := utf8.DecodeRuneInString(text[start:])
+			end = start + size
+		}
+		match := text[start:end]
+		if t.lookahead && end < len(text) && isSpaceOnly(match) && !strings.ContainsAny(match[len(match)-1:], "\r\n") {
+			next, _ := utf8.DecodeRuneInString(text[end:])
+			if _, lastSize := utf8.DecodeLastRuneInString(match); !unicode.IsSpace(next) && len(match) > lastSize {
+				end -= lastSize
+				match = match[:len(match)-lastSize]
+			}
+		}
+		pieces = append(pieces, match)
+		text = text[end:]
+	}
+	return pieces
+}
+
+func isSpaceOnly(s string) bool {
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// bytePairEncode splits piece into tokens of ranks by merging the adjacent pair with the lowest
+// rank until no pair is in the vocabulary. Bytes missing from the vocabulary become token 0.
+func bytePairEncode(piece []byte, ranks map[string]int) []int {
+	if rank, ok := ranks[string(piece)]; ok {
+		return []int{rank}
+	}
+	// bounds holds the start of every part, then len(piece).
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+	for len(bounds) > 2 {
+		best, bestRank := -1, 0
+		for i := 0; i+2 < len(bounds); i++ {
+			if rank, ok := ranks[string(piece[bounds[i]:bounds[i+2]])]; ok && (best < 0 || rank < bestRank) {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+
+	ids := make([]int, 0, len(bounds)-1)
+	for i := 0; i+1 < len(bounds); i++ {
+		ids = append(ids, ranks[string(piece[bounds[i]:bounds[i+1]])])
+	}
+	return ids
+}
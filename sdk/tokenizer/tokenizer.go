@@ -0,0 +1,250 @@
+// Package tokenizer counts tokens locally with Mistral's published tokenizer files, so prompts can
+// be checked against a model's context window without calling the API.
+//
+//	tok, err := tokenizer.Load("tekken.json") // or tokenizer.model.v3
+//	if err != nil {
+//		return err
+//	}
+//	used := tok.CountMessages(messages, tools)
+//	left, err := tok.Remaining(card, messages, tools)
+//
+// Both tekken (tekken.json) and SentencePiece (tokenizer.model.*) vocabularies are supported, as
+// shipped with the open-weight models. The vocabulary is always supplied by the caller; nothing is
+// downloaded.
+//
+// Chat messages are counted with the instruct template: control tokens around user turns, tool
+// definitions and tool results, and the end-of-sequence token after assistant turns. The template
+// has changed between model versions, so counts can differ from the API's prompt_tokens by a few
+// tokens. Image and audio content is not counted.
+package tokenizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+// Control tokens of the instruct and FIM templates.
+const (
+	BOS             = "<s>"
+	EOS             = "</s>"
+	BeginInst       = "[INST]"
+	EndInst         = "[/INST]"
+	BeginTools      = "[AVAILABLE_TOOLS]"
+	EndTools        = "[/AVAILABLE_TOOLS]"
+	BeginToolResult = "[TOOL_RESULTS]"
+	EndToolResult   = "[/TOOL_RESULTS]"
+	ToolCalls       = "[TOOL_CALLS]"
+	BeginSystem     = "[SYSTEM_PROMPT]"
+	EndSystem       = "[/SYSTEM_PROMPT]"
+	Prefix          = "[PREFIX]"
+	Middle          = "[MIDDLE]"
+	Suffix          = "[SUFFIX]"
+)
+
+// ErrUnknownContextLength is returned by Remaining for model cards without a max context length.
+var ErrUnknownContextLength = errors.New("tokenizer: model card has no max context length")
+
+// Tokenizer encodes text into token IDs. It is safe for concurrent use.
+type Tokenizer struct {
+	encode   func(text string) []int
+	specials map[string]int
+}
+
+// Load reads a tokenizer file: a tekken.json vocabulary or a SentencePiece tokenizer.model file.
+func Load(path string) (*Tokenizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return ParseTekken(data)
+	}
+	return ParseSentencePiece(data)
+}
+
+// Encode returns the token IDs of text, without control tokens. Control token names in text, such
+// as "[INST]", are encoded as plain text.
+func (t *Tokenizer) Encode(text string) []int {
+	if text == "" {
+		return nil
+	}
+	return t.encode(text)
+}
+
+// Count returns the number of tokens of text, without control tokens.
+func (t *Tokenizer) Count(text string) int {
+	return len(t.Encode(text))
+}
+
+// Special returns the ID of a control token such as BOS or BeginInst, and whether the vocabulary
+// defines it.
+func (t *Tokenizer) Special(name string) (int, bool) {
+	id, ok := t.specials[name]
+	return id, ok
+}
+
+// countSpecial counts a control token: one token when the vocabulary defines it, and its encoded
+// name otherwise, as older vocabularies spell the template out in text.
+func (t *Tokenizer) countSpecial(name string) int {
+	if _, ok := t.specials[name]; ok {
+		return 1
+	}
+	return t.Count(name)
+}
+
+// CountMessages returns the number of prompt tokens of a chat request with messages and tools,
+// including the instruct template.
+func (t *Tokenizer) CountMessages(messages []sdk.ChatMessage, tools []sdk.Tool) int {
+	_, separateSystem := t.specials[BeginSystem]
+	lastUser := -1
+	var system []string
+	for i, message := range messages {
+		switch message.Role {
+		case sdk.RoleUser:
+			lastUser = i
+		case sdk.RoleSystem:
+			system = append(system, message.Text())
+		}
+	}
+
+	n := t.countSpecial(BOS)
+	for i, message := range messages {
+		switch message.Role {
+		case sdk.RoleSystem:
+			if separateSystem {
+				n += t.countSpecial(BeginSystem) + t.Count(message.Text()) + t.countSpecial(EndSystem)
+			}
+		case sdk.RoleUser:
+			if i == lastUser && len(tools) > 0 {
+				n += t.countSpecial(BeginTools) + t.Count(pythonJSON(tools)) + t.countSpecial(EndTools)
+			}
+			text := message.Text()
+			if i == lastUser && !separateSystem && len(system) > 0 {
+				// Older templates prepend the system prompt to the last user message.
+				text = strings.Join(system, "\n\n") + "\n\n" + text
+			}
+			n += t.countSpecial(BeginInst) + t.Count(text) + t.countSpecial(EndInst)
+		case sdk.RoleAssistant:
+			n += t.Count(message.Text())
+			if len(message.ToolCalls) > 0 {
+				calls := make([]map[string]any, len(message.ToolCalls))
+				for j, call := range message.ToolCalls {
+					calls[j] = map[string]any{"name": call.Function.Name, "arguments": json.RawMessage(validJSON(call.Function.Arguments)), "id": call.Id}
+				}
+				n += t.countSpecial(ToolCalls) + t.Count(pythonJSON(calls))
+			}
+			if i < len(messages)-1 {
+				n += t.countSpecial(EOS)
+			}
+		case sdk.RoleTool:
+			result := map[string]any{"content": message.Text(), "call_id": message.ToolCallID}
+			n += t.countSpecial(BeginToolResult) + t.Count(pythonJSON(result)) + t.countSpecial(EndToolResult)
+		}
+	}
+	return n
+}
+
+// CountFIM returns the number of prompt tokens of a FIM request with prompt and suffix.
+func (t *Tokenizer) CountFIM(prompt, suffix string) int {
+	return t.countSpecial(BOS) + t.countSpecial(Suffix) + t.Count(suffix) + t.countSpecial(Prefix) + t.Count(prompt)
+}
+
+// Remaining returns how many tokens of the model's context window are left for the completion
+// after the prompt made of messages and tools. It is negative when the prompt does not fit.
+func (t *Tokenizer) Remaining(card *sdk.ModelCard, messages []sdk.ChatMessage, tools []sdk.Tool) (int, error) {
+	if card == nil || card.MaxContextLength == nil {
+		return 0, ErrUnknownContextLength
+	}
+	return *card.MaxContextLength - t.CountMessages(messages, tools), nil
+}
+
+// Estimator returns a token estimator for sdk.RateLimiter.SetTokenEstimator that counts the
+// messages and tools, or the FIM prompt and suffix, of a request payload with t.
+func (t *Tokenizer) Estimator() sdk.TokenEstimator {
+	return func(model string, payload map[string]any) int {
+		if prompt, ok := payload["prompt"].(string); ok {
+			suffix, _ := payload["suffix"].(string)
+			return t.CountFIM(prompt, suffix)
+		}
+		var request struct {
+			Messages []sdk.ChatMessage `json:"messages"`
+			Tools    []sdk.Tool        `json:"tools"`
+		}
+		if data, err := json.Marshal(map[string]any{"messages": payload["messages"], "tools": payload["tools"]}); err == nil {
+			_ = json.Unmarshal(data, &request)
+		}
+		return t.CountMessages(request.Messages, request.Tools)
+	}
+}
+
+func validJSON(arguments string) string {
+	if json.Valid([]byte(arguments)) {
+		return arguments
+	}
+	data, _ := json.Marshal(arguments)
+	return string(data)
+}
+
+// pythonJSON formats v the way the reference template does, with Python's json.dumps separators.
+func pythonJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return string(data)
+	}
+	var b strings.Builder
+	writePythonJSON(&b, value)
+	return b.String()
+}
+
+func writePythonJSON(b *strings.Builder, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writePythonJSON(b, key)
+			b.WriteString(": ")
+			writePythonJSON(b, v[key])
+		}
+		b.WriteByte('}')
+	case []any:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writePythonJSON(b, item)
+		}
+		b.WriteByte(']')
+	case string:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(v)
+		b.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	case nil:
+		b.WriteString("null")
+	default:
+		fmt.Fprint(b, v)
+	}
+}
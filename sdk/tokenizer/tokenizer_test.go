@@ -0,0 +1,279 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ZaguanLabs/mistral-go/v2/sdk"
+)
+
+const tekkenPattern = `[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*|\p{N}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+`
+
+// tekkenFixture returns a tekken file with every byte, a few merges and the control tokens of the
+// v3 template, without [SYSTEM_PROMPT].
+func tekkenFixture(t *testing.T) []byte {
+	t.Helper()
+	type token struct {
+		Rank       int    `json:"rank"`
+		TokenBytes string `json:"token_bytes"`
+	}
+	type special struct {
+		Rank     int    `json:"rank"`
+		TokenStr string `json:"token_str"`
+	}
+	var vocab []token
+	for b := 0; b < 256; b++ {
+		vocab = append(vocab, token{b, base64.StdEncoding.EncodeToString([]byte{byte(b)})})
+	}
+	for _, merge := range []string{"he", "ll", "hell", "hello"} {
+		vocab = append(vocab, token{len(vocab), base64.StdEncoding.EncodeToString([]byte(merge))})
+	}
+	var specials []special
+	for _, name := range []string{"<unk>", BOS, EOS, BeginInst, EndInst, BeginTools, EndTools, BeginToolResult, EndToolResult, ToolCalls, Prefix, Suffix} {
+		specials = append(specials, special{len(specials), name})
+	}
+	data, err := json.Marshal(map[string]any{
+		"config":         map[string]any{"pattern": tekkenPattern, "default_vocab_size": len(vocab) + len(specials), "default_num_special_tokens": len(specials)},
+		"vocab":          vocab,
+		"special_tokens": specials,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func loadTekken(t *testing.T) *Tokenizer {
+	t.Helper()
+	tok, err := ParseTekken(tekkenFixture(t))
+	if err != nil {
+		t.Fatalf("ParseTekken: %v", err)
+	}
+	return tok
+}
+
+func TestTekkenEncode(t *testing.T) {
+	tok := loadTekken(t)
+
+	// "hello" is one token; " hello" merges down to " " and "hello". IDs follow the 12 specials.
+	if got, want := tok.Encode("hello hello"), []int{12 + 259, 12 + ' ', 12 + 259}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Encode = %v, want %v", got, want)
+	}
+	if got := tok.Count("[INST]"); got != 6 {
+		t.Errorf("Count of control token name = %d, want 6", got)
+	}
+	if id, ok := tok.Special(BeginInst); !ok || id != 3 {
+		t.Errorf("Special(BeginInst) = %d, %v", id, ok)
+	}
+	if _, ok := tok.Special(BeginSystem); ok {
+		t.Error("Special(BeginSystem) found, want missing")
+	}
+}
+
+func TestTekkenSplitLeavesLastSpaceToWord(t *testing.T) {
+	tk := &tekken{pattern: regexp.MustCompile(strings.Replace(tekkenPattern, lookaheadWhitespace, "", 1)), lookahead: true}
+	got := tk.split("a   b\n  c ")
+	want := []string{"a", "  ", " b", "\n", " ", " c", " "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("split = %q, want %q", got, want)
+	}
+}
+
+func TestCountMessages(t *testing.T) {
+	tok := loadTekken(t)
+
+	messages := []sdk.ChatMessage{
+		{Role: sdk.RoleSystem, Content: "hello"},
+		{Role: sdk.RoleUser, Content: "hello"},
+	}
+	// <s> [INST] "hello" "\n\n" "hello" [/INST]: the system prompt joins the last user message.
+	if got := tok.CountMessages(messages, nil); got != 7 {
+		t.Errorf("CountMessages = %d, want 7", got)
+	}
+
+	messages = append(messages, sdk.ChatMessage{Role: sdk.RoleAssistant, Content: "hello"})
+	if got := tok.CountMessages(messages, nil); got != 8 {
+		t.Errorf("CountMessages with final assistant = %d, want 8", got)
+	}
+	messages = append(messages, sdk.ChatMessage{Role: sdk.RoleUser, Content: "hello"})
+	// The assistant turn is closed with </s>, and the system prompt moves to the last user message.
+	if got := tok.CountMessages(messages, nil); got != 1+3+1+1+6 {
+		t.Errorf("CountMessages with two turns = %d, want %d", got, 1+3+1+1+6)
+	}
+
+	// The fixture has no merges for the tool JSON, so it is one token per byte.
+	tools := []sdk.Tool{{Type: sdk.ToolTypeFunction, Function: sdk.Function{Name: "f", Parameters: map[string]any{}}}}
+	definitions := `[{"function": {"description": "", "name": "f", "parameters": {}}, "type": "function"}]`
+	if got := tok.CountMessages(messages, tools) - tok.CountMessages(messages, nil); got != 2+len(definitions) {
+		t.Errorf("tool definitions counted as %d tokens, want %d", got, 2+len(definitions))
+	}
+}
+
+func TestPythonJSON(t *testing.T) {
+	tools := []sdk.Tool{{Type: sdk.ToolTypeFunction, Function: sdk.Function{Name: "f", Description: "<d>", Parameters: map[string]any{"b": 1.5, "a": []any{true, nil}}}}}
+	want := `[{"function": {"description": "<d>", "name": "f", "parameters": {"a": [true, null], "b": 1.5}}, "type": "function"}]`
+	if got := pythonJSON(tools); got != want {
+		t.Errorf("pythonJSON = %s, want %s", got, want)
+	}
+}
+
+func TestCountFIMAndRemaining(t *testing.T) {
+	tok := loadTekken(t)
+
+	// <s> [SUFFIX] "hello" [PREFIX] "hello"
+	if got := tok.CountFIM("hello", "hello"); got != 5 {
+		t.Errorf("CountFIM = %d, want 5", got)
+	}
+
+	messages := []sdk.ChatMessage{{Role: sdk.RoleUser, Content: "hello"}}
+	limit := 100
+	left, err := tok.Remaining(&sdk.ModelCard{MaxContextLength: &limit}, messages, nil)
+	if err != nil || left != 96 {
+		t.Errorf("Remaining = %d, %v, want 96", left, err)
+	}
+	if _, err := tok.Remaining(&sdk.ModelCard{}, messages, nil); !errors.Is(err, ErrUnknownContextLength) {
+		t.Errorf("Remaining without context length: %v", err)
+	}
+}
+
+func TestEstimator(t *testing.T) {
+	estimate := loadTekken(t).Estimator()
+
+	chat := map[string]any{"model": "m", "messages": []map[string]any{{"role": "user", "content": "hello"}}}
+	if got := estimate("m", chat); got != 4 {
+		t.Errorf("chat estimate = %d, want 4", got)
+	}
+	fim := map[string]any{"model": "m", "prompt": "hello", "suffix": "hello"}
+	if got := estimate("m", fim); got != 5 {
+		t.Errorf("FIM estimate = %d, want 5", got)
+	}
+}
+
+// protoField encodes one protobuf field: a []byte or string as length-delimited, a float32 as
+// fixed32 and an int as varint.
+func protoField(field int, value any) []byte {
+	var out []byte
+	switch v := value.(type) {
+	case string:
+		out = binary.AppendUvarint(out, uint64(field<<3|2))
+		out = binary.AppendUvarint(out, uint64(len(v)))
+		out = append(out, v...)
+	case []byte:
+		out = binary.AppendUvarint(out, uint64(field<<3|2))
+		out = binary.AppendUvarint(out, uint64(len(v)))
+		out = append(out, v...)
+	case float32:
+		out = binary.AppendUvarint(out, uint64(field<<3|5))
+		out = binary.LittleEndian.AppendUint32(out, math.Float32bits(v))
+	case int:
+		out = binary.AppendUvarint(out, uint64(field<<3))
+		out = binary.AppendUvarint(out, uint64(v))
+	}
+	return out
+}
+
+func sentencePieceFixture() []byte {
+	pieces := []struct {
+		piece string
+		score float32
+		kind  int
+	}{
+		{"<unk>", 0, pieceUnknown},
+		{"<s>", 0, pieceControl},
+		{"</s>", 0, pieceControl},
+		{"<0x21>", 0, pieceByte},
+		{"▁", -10, pieceNormal},
+		{"h", -10, pieceNormal},
+		{"e", -10, pieceNormal},
+		{"l", -10, pieceNormal},
+		{"o", -10, pieceNormal},
+		{"▁h", -1, pieceNormal},
+		{"ll", -2, pieceNormal},
+		{"▁he", -3, pieceNormal},
+		{"llo", -4, pieceNormal},
+		{"▁hello", -5, pieceNormal},
+	}
+	var model []byte
+	for _, p := range pieces {
+		var piece []byte
+		piece = append(piece, protoField(1, p.piece)...)
+		piece = append(piece, protoField(2, p.score)...)
+		piece = append(piece, protoField(3, p.kind)...)
+		model = append(model, protoField(1, piece)...)
+	}
+	model = append(model, protoField(2, protoField(3, sentencePieceBPE))...)
+	return model
+}
+
+func TestSentencePieceEncode(t *testing.T) {
+	tok, err := ParseSentencePiece(sentencePieceFixture())
+	if err != nil {
+		t.Fatalf("ParseSentencePiece: %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want []int
+	}{
+		{"hello", []int{13}},
+		{"  hello   hello", []int{13, 13}},
+		{"hello!", []int{13, 3}},
+		{"hé", []int{9, 0, 0}},
+	}
+	for _, tt := range tests {
+		if got := tok.Encode(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Encode(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+	if id, ok := tok.Special(BOS); !ok || id != 1 {
+		t.Errorf("Special(BOS) = %d, %v", id, ok)
+	}
+	// Without [INST] in the vocabulary, the template is spelled out in text.
+	if got := tok.countSpecial(BeginInst); got == 1 {
+		t.Errorf("countSpecial(BeginInst) = 1 for a vocabulary without it")
+	}
+}
+
+func TestSentencePieceRejectsUnigram(t *testing.T) {
+	model := append(protoField(1, protoField(1, "a")), protoField(2, protoField(3, 1))...)
+	if _, err := ParseSentencePiece(model); err == nil {
+		t.Fatal("expected an error for a unigram model")
+	}
+	if _, err := ParseSentencePiece([]byte{0x0a, 0x05, 0x01}); err == nil {
+		t.Fatal("expected an error for a truncated model")
+	}
+}
+
+func TestLoadDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	tekkenPath := filepath.Join(dir, "tekken.json")
+	spPath := filepath.Join(dir, "tokenizer.model.v3")
+	if err := os.WriteFile(tekkenPath, tekkenFixture(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(spPath, sentencePieceFixture(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]int{tekkenPath: 12 + 259, spPath: 13} {
+		tok, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", path, err)
+		}
+		if got := tok.Encode("hello"); len(got) != 1 || got[0] != want {
+			t.Errorf("Load(%s).Encode = %v, want [%d]", path, got, want)
+		}
+	}
+	if _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
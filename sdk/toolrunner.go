@@ -0,0 +1,376 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultMaxToolIterations is the number of model calls a ToolRunner makes before giving up.
+const DefaultMaxToolIterations = 10
+
+// ErrMaxToolIterations is returned, with the transcript so far, when the model still asks for tool
+// calls after the maximum number of iterations.
+var ErrMaxToolIterations = errors.New("mistral: tool runner reached the maximum number of iterations")
+
+// ToolApprover decides whether a call to a tool registered with WithApprovalRequired may run. A
+// denied call is reported to the model as such; an error aborts the run.
+type ToolApprover func(ctx context.Context, call ToolCall) (bool, error)
+
+// ToolRunner runs the tool-calling loop: it sends the conversation with the registered tools, runs
+// the tool calls the model asks for, concurrently, sends back their results, and repeats until the
+// model answers without calling a tool.
+//
+//	runner := sdk.NewToolRunner(client, sdk.WithToolTimeout(10*time.Second))
+//	err := sdk.RegisterTool(runner, "get_weather", "Get the current weather in a city",
+//		func(ctx context.Context, args struct {
+//			City string `json:"city" description:"City name"`
+//		}) (Weather, error) {
+//			return lookupWeather(ctx, args.City)
+//		})
+//	result, err := runner.Run("mistral-large-latest", messages, nil)
+//
+// Register tools before calling Run; a ToolRunner is then safe for concurrent runs.
+type ToolRunner struct {
+	client        *MistralClient
+	tools         map[string]*registeredTool
+	order         []string
+	maxIterations int
+	timeout       time.Duration
+	approver      ToolApprover
+}
+
+type registeredTool struct {
+	function         Function
+	call             func(ctx context.Context, arguments string) (string, error)
+	timeout          time.Duration
+	requiresApproval bool
+}
+
+// ToolRunnerOption configures a ToolRunner.
+type ToolRunnerOption func(*ToolRunner)
+
+// WithMaxToolIterations sets how many times the runner calls the model, DefaultMaxToolIterations by
+// default.
+func WithMaxToolIterations(n int) ToolRunnerOption {
+	return func(r *ToolRunner) {
+		r.maxIterations = n
+	}
+}
+
+// WithToolTimeout sets the default time limit of each tool call. Zero, the default, sets no limit.
+func WithToolTimeout(timeout time.Duration) ToolRunnerOption {
+	return func(r *ToolRunner) {
+		r.timeout = timeout
+	}
+}
+
+// WithToolApprover sets the hook asked before running tools registered with WithApprovalRequired.
+// Without one, such calls are denied.
+func WithToolApprover(approver ToolApprover) ToolRunnerOption {
+	return func(r *ToolRunner) {
+		r.approver = approver
+	}
+}
+
+// NewToolRunner returns a ToolRunner calling the model through client.
+func NewToolRunner(client *MistralClient, opts ...ToolRunnerOption) *ToolRunner {
+	r := &ToolRunner{client: client, tools: map[string]*registeredTool{}, maxIterations: DefaultMaxToolIterations}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// ToolOption configures a tool registered with RegisterTool.
+type ToolOption func(*registeredTool)
+
+// WithCallTimeout overrides the runner's WithToolTimeout for one tool.
+func WithCallTimeout(timeout time.Duration) ToolOption {
+	return func(t *registeredTool) {
+		t.timeout = timeout
+	}
+}
+
+// WithApprovalRequired marks a tool as sensitive: each call must be allowed by the runner's
+// ToolApprover.
+func WithApprovalRequired() ToolOption {
+	return func(t *registeredTool) {
+		t.requiresApproval = true
+	}
+}
+
+// RegisterTool registers fn as the tool name. The parameters schema is reflected from the argument
+// struct A like NewJSONSchema does, and the model's arguments are decoded into an A. A string result
+// is sent to the model as is; other results are sent as JSON. Errors, including invalid arguments
+// and timeouts, are reported to the model so that it can recover.
+func RegisterTool[A, R any](r *ToolRunner, name, description string, fn func(ctx context.Context, args A) (R, error), opts ...ToolOption) error {
+	argsType := reflect.TypeOf((*A)(nil)).Elem()
+	for argsType.Kind() == reflect.Pointer {
+		argsType = argsType.Elem()
+	}
+	if argsType.Kind() != reflect.Struct {
+		return fmt.Errorf("mistral: arguments of tool %s must be a struct, got %s", name, argsType)
+	}
+	parameters, err := (&schemaBuilder{seen: map[reflect.Type]bool{}}).build(argsType)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.tools[name]; ok {
+		return fmt.Errorf("mistral: tool %s is already registered", name)
+	}
+
+	tool := &registeredTool{
+		function: Function{Name: name, Description: description, Parameters: parameters},
+		call: func(ctx context.Context, arguments string) (string, error) {
+			var args A
+			if arguments != "" {
+				if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+					return "", fmt.Errorf("invalid arguments: %w", err)
+				}
+			}
+			result, err := fn(ctx, args)
+			if err != nil {
+				return "", err
+			}
+			if text, ok := any(result).(string); ok {
+				return text, nil
+			}
+			data, err := json.Marshal(result)
+			return string(data), err
+		},
+		timeout: r.timeout,
+	}
+	for _, opt := range opts {
+		opt(tool)
+	}
+	r.tools[name] = tool
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Tools returns the definitions of the registered tools, in registration order.
+func (r *ToolRunner) Tools() []Tool {
+	tools := make([]Tool, len(r.order))
+	for i, name := range r.order {
+		tools[i] = Tool{Type: ToolTypeFunction, Function: r.tools[name].function}
+	}
+	return tools
+}
+
+// ToolRunResult is the outcome of a ToolRunner run.
+type ToolRunResult struct {
+	Response   *ChatCompletionResponse // The last model response
+	Messages   []ChatMessage           // The whole transcript: the input messages, then every assistant and tool message
+	Iterations int                     // Model calls made
+	Usage      UsageInfo               // Tokens used over all model calls
+}
+
+// Run loops on Chat until the model answers without calling a tool. params may be nil; the
+// registered tools are added to its Tools, and ToolChoice defaults to auto. On error, the result
+// holds the transcript so far.
+func (r *ToolRunner) Run(model string, messages []ChatMessage, params *ChatRequestParams) (*ToolRunResult, error) {
+	return r.RunCtx(context.Background(), model, messages, params)
+}
+
+// RunCtx is like Run but uses ctx for cancellation and deadlines.
+func (r *ToolRunner) RunCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams) (*ToolRunResult, error) {
+	chatParams, err := r.chatParams(params)
+	if err != nil {
+		return &ToolRunResult{Messages: append([]ChatMessage(nil), messages...)}, err
+	}
+	return r.run(ctx, messages, func(ctx context.Context, messages []ChatMessage) (*ChatCompletionResponse, error) {
+		return r.client.ChatCtx(ctx, model, messages, chatParams)
+	})
+}
+
+// RunStream is like Run but streams every model call with ChatStream, passing content deltas to
+// onContent, which may be nil, as they arrive.
+func (r *ToolRunner) RunStream(model string, messages []ChatMessage, params *ChatRequestParams, onContent func(delta string)) (*ToolRunResult, error) {
+	return r.RunStreamCtx(context.Background(), model, messages, params, onContent)
+}
+
+// RunStreamCtx is like RunStream but uses ctx for cancellation and deadlines.
+func (r *ToolRunner) RunStreamCtx(ctx context.Context, model string, messages []ChatMessage, params *ChatRequestParams, onContent func(delta string)) (*ToolRunResult, error) {
+	chatParams, err := r.chatParams(params)
+	if err != nil {
+		return &ToolRunResult{Messages: append([]ChatMessage(nil), messages...)}, err
+	}
+	return r.run(ctx, messages, func(ctx context.Context, messages []ChatMessage) (*ChatCompletionResponse, error) {
+		stream, err := r.client.ChatStreamCtx(ctx, model, messages, chatParams)
+		if err != nil {
+			return nil, err
+		}
+		acc := &StreamAccumulator{}
+		if onContent != nil {
+			acc.OnContent = func(choice int, delta string) {
+				if choice == 0 {
+					onContent(delta)
+				}
+			}
+		}
+		return AccumulateChatStream(stream, acc)
+	})
+}
+
+// RunAgent is like Run but loops on AgentComplete. The registered tools are added to the tools
+// the agent already has.
+func (r *ToolRunner) RunAgent(agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*ToolRunResult, error) {
+	return r.RunAgentCtx(context.Background(), agentID, messages, params)
+}
+
+// RunAgentCtx is like RunAgent but uses ctx for cancellation and deadlines.
+func (r *ToolRunner) RunAgentCtx(ctx context.Context, agentID string, messages []ChatMessage, params *AgentCompletionRequest) (*ToolRunResult, error) {
+	agentParams := &AgentCompletionRequest{}
+	if params != nil {
+		*agentParams = *params
+	}
+	agentParams.Tools = append(append([]Tool(nil), agentParams.Tools...), r.Tools()...)
+	if agentParams.ToolChoice == nil {
+		agentParams.ToolChoice = ToolChoiceAuto
+	}
+	return r.run(ctx, messages, func(ctx context.Context, messages []ChatMessage) (*ChatCompletionResponse, error) {
+		request := *agentParams
+		return r.client.AgentCompleteCtx(ctx, agentID, messages, &request)
+	})
+}
+
+// chatParams copies params with the registered tools added. Tools given in another form than
+// []Tool, such as []map[string]any or raw JSON, are converted through JSON.
+func (r *ToolRunner) chatParams(params *ChatRequestParams) (*ChatRequestParams, error) {
+	chatParams := NewChatRequestParams()
+	if params != nil {
+		*chatParams = *params
+	}
+	var existing []Tool
+	switch tools := chatParams.Tools.(type) {
+	case nil:
+	case []Tool:
+		existing = tools
+	default:
+		data, err := json.Marshal(tools)
+		if err == nil {
+			err = json.Unmarshal(data, &existing)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("mistral: params.Tools is not a list of tools: %w", err)
+		}
+	}
+	chatParams.Tools = append(append([]Tool(nil), existing...), r.Tools()...)
+	if chatParams.ToolChoice == nil {
+		chatParams.ToolChoice = ToolChoiceAuto
+	}
+	return chatParams, nil
+}
+
+func (r *ToolRunner) run(ctx context.Context, messages []ChatMessage, complete func(ctx context.Context, messages []ChatMessage) (*ChatCompletionResponse, error)) (*ToolRunResult, error) {
+	result := &ToolRunResult{Messages: append([]ChatMessage(nil), messages...)}
+	for result.Iterations < r.maxIterations {
+		resp, err := complete(ctx, result.Messages)
+		if err != nil {
+			return result, err
+		}
+		result.Iterations++
+		result.Response = resp
+		result.Usage.PromptTokens += resp.Usage.PromptTokens
+		result.Usage.CompletionTokens += resp.Usage.CompletionTokens
+		result.Usage.TotalTokens += resp.Usage.TotalTokens
+		if len(resp.Choices) == 0 {
+			return result, fmt.Errorf("mistral: no choices in the response")
+		}
+
+		message := resp.Choices[0].Message
+		result.Messages = append(result.Messages, message)
+		if len(message.ToolCalls) == 0 {
+			return result, nil
+		}
+		toolMessages, err := r.callTools(ctx, message.ToolCalls)
+		if err != nil {
+			return result, err
+		}
+		result.Messages = append(result.Messages, toolMessages...)
+	}
+	return result, ErrMaxToolIterations
+}
+
+// callTools runs calls concurrently and returns their tool messages, in the order of calls.
+func (r *ToolRunner) callTools(ctx context.Context, calls []ToolCall) ([]ChatMessage, error) {
+	messages := make([]ChatMessage, len(calls))
+	errs := make([]error, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call ToolCall) {
+			defer wg.Done()
+			content, err := r.callTool(ctx, call)
+			messages[i] = ChatMessage{Role: RoleTool, Content: content, ToolCallID: call.Id, Name: call.Function.Name}
+			errs[i] = err
+		}(i, call)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+// callTool runs one tool call and returns the content reported to the model. The error is only
+// set when the whole run must stop.
+func (r *ToolRunner) callTool(ctx context.Context, call ToolCall) (string, error) {
+	tool, ok := r.tools[call.Function.Name]
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %q", call.Function.Name), nil
+	}
+	if tool.requiresApproval {
+		approved := false
+		if r.approver != nil {
+			var err error
+			if approved, err = r.approver(ctx, call); err != nil {
+				return "", err
+			}
+		}
+		if !approved {
+			return "error: the call was not approved", nil
+		}
+	}
+
+	if tool.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, tool.timeout)
+		defer cancel()
+	}
+	type outcome struct {
+		content string
+		err     error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", p)}
+			}
+		}()
+		content, err := tool.call(ctx, call.Function.Arguments)
+		done <- outcome{content, err}
+	}()
+
+	// Tools that ignore ctx are abandoned when it is done.
+	select {
+	case result := <-done:
+		if result.err != nil {
+			return "error: " + result.err.Error(), nil
+		}
+		return result.content, nil
+	case <-ctx.Done():
+		return "error: " + ctx.Err().Error(), nil
+	}
+}
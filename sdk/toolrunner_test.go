@@ -0,0 +1,284 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type toolRequest struct {
+	Messages []ChatMessage `json:"messages"`
+	Tools    []Tool        `json:"tools"`
+	Stream   bool          `json:"stream"`
+}
+
+// toolServer answers with toolCalls while the conversation has no tool results yet, and with
+// answer once it does.
+func toolServer(t *testing.T, requests *[]toolRequest, toolCalls []ToolCall, answer string) *MockHTTPServer {
+	return NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req toolRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		*requests = append(*requests, req)
+		message := ChatMessage{Role: RoleAssistant, Content: answer}
+		finishReason := FinishReasonStop
+		if req.Messages[len(req.Messages)-1].Role != RoleTool {
+			message, finishReason = ChatMessage{Role: RoleAssistant, ToolCalls: toolCalls}, FinishReasonToolCalls
+		}
+		data, _ := json.Marshal(ChatCompletionResponse{
+			ID:      fmt.Sprintf("cmpl-%d", len(*requests)),
+			Choices: []ChatCompletionResponseChoice{{Message: message, FinishReason: finishReason}},
+			Usage:   UsageInfo{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+		})
+		MockJSONResponse(http.StatusOK, string(data)).Write(w)
+	})
+}
+
+func toolCall(id, name, arguments string) ToolCall {
+	return ToolCall{Id: id, Type: ToolTypeFunction, Function: FunctionCall{Name: name, Arguments: arguments}}
+}
+
+type cityArgs struct {
+	City string `json:"city" description:"City name"`
+}
+
+func TestToolRunnerRunsParallelCalls(t *testing.T) {
+	var requests []toolRequest
+	mock := toolServer(t, &requests, []ToolCall{toolCall("call-1", "get_weather", `{"city":"Paris"}`), toolCall("call-2", "get_weather", `{"city":"Rome"}`)}, "Sunny in both.")
+	defer mock.Close()
+
+	// Each call waits for the other to start, so sequential calls would time out.
+	started := make(chan struct{}, 2)
+	runner := NewToolRunner(mock.GetClient(), WithToolTimeout(2*time.Second))
+	err := RegisterTool(runner, "get_weather", "Get the weather", func(ctx context.Context, args cityArgs) (map[string]any, error) {
+		started <- struct{}{}
+		for len(started) < 2 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+		}
+		return map[string]any{"city": args.City, "condition": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("Weather in Paris and Rome?")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var roles []string
+	for _, message := range result.Messages {
+		roles = append(roles, message.Role)
+	}
+	if !reflect.DeepEqual(roles, []string{RoleUser, RoleAssistant, RoleTool, RoleTool, RoleAssistant}) {
+		t.Fatalf("unexpected transcript roles %v", roles)
+	}
+	if result.Messages[2].Content != `{"city":"Paris","condition":"sunny"}` || result.Messages[3].ToolCallID != "call-2" || result.Messages[3].Name != "get_weather" {
+		t.Errorf("unexpected tool messages %+v", result.Messages[2:4])
+	}
+	if result.Iterations != 2 || result.Usage.TotalTokens != 30 || result.Response.Choices[0].Message.Content != "Sunny in both." {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if len(requests[0].Tools) != 1 {
+		t.Fatalf("expected the tool to be sent, got %+v", requests[0].Tools)
+	}
+	parameters, _ := json.Marshal(requests[0].Tools[0].Function.Parameters)
+	if string(parameters) != `{"additionalProperties":false,"properties":{"city":{"description":"City name","type":"string"}},"required":["city"],"type":"object"}` {
+		t.Errorf("unexpected parameters schema %s", parameters)
+	}
+}
+
+func TestToolRunnerKeepsCallerTools(t *testing.T) {
+	var requests []toolRequest
+	mock := toolServer(t, &requests, nil, "Done.")
+	defer mock.Close()
+
+	runner := NewToolRunner(mock.GetClient())
+	if err := RegisterTool(runner, "get_weather", "Get the weather", func(ctx context.Context, args cityArgs) (string, error) {
+		return "sunny", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	search := map[string]any{"type": "function", "function": map[string]any{"name": "search", "parameters": map[string]any{"type": "object"}}}
+	for _, tools := range []any{[]map[string]any{search}, json.RawMessage(`[{"type":"function","function":{"name":"search","parameters":{"type":"object"}}}]`)} {
+		requests = nil
+		if _, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("hi")}, &ChatRequestParams{Tools: tools}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := requests[0].Tools; len(got) != 2 || got[0].Function.Name != "search" || got[1].Function.Name != "get_weather" {
+			t.Errorf("expected the caller's tool and the registered one for %T, got %+v", tools, got)
+		}
+	}
+
+	if _, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("hi")}, &ChatRequestParams{Tools: "search"}); err == nil {
+		t.Error("expected an error for tools that are not a list")
+	}
+}
+
+func TestToolRunnerReportsFailures(t *testing.T) {
+	var requests []toolRequest
+	mock := toolServer(t, &requests, []ToolCall{
+		toolCall("call-1", "delete_file", `{"path":"/etc/passwd"}`),
+		toolCall("call-2", "unknown", `{}`),
+		toolCall("call-3", "get_weather", `{"city":`),
+		toolCall("call-4", "slow", `{}`),
+		toolCall("call-5", "broken", `{}`),
+	}, "Done.")
+	defer mock.Close()
+
+	var asked []string
+	runner := NewToolRunner(mock.GetClient(), WithToolApprover(func(ctx context.Context, call ToolCall) (bool, error) {
+		asked = append(asked, call.Function.Arguments)
+		return false, nil
+	}))
+	deleted := false
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(RegisterTool(runner, "delete_file", "Delete a file", func(ctx context.Context, args struct {
+		Path string `json:"path"`
+	}) (string, error) {
+		deleted = true
+		return "deleted", nil
+	}, WithApprovalRequired()))
+	must(RegisterTool(runner, "get_weather", "Get the weather", func(ctx context.Context, args cityArgs) (string, error) {
+		return "sunny", nil
+	}))
+	release := make(chan struct{})
+	defer close(release)
+	must(RegisterTool(runner, "slow", "Ignores its context", func(ctx context.Context, args struct{}) (string, error) {
+		<-release
+		return "late", nil
+	}, WithCallTimeout(20*time.Millisecond)))
+	must(RegisterTool(runner, "broken", "Panics", func(ctx context.Context, args struct{}) (string, error) {
+		panic("boom")
+	}))
+	if err := RegisterTool(runner, "slow", "Again", func(ctx context.Context, args struct{}) (string, error) { return "", nil }); err == nil {
+		t.Error("expected an error registering a tool twice")
+	}
+	if err := RegisterTool(runner, "bad", "Not a struct", func(ctx context.Context, args string) (string, error) { return "", nil }); err == nil {
+		t.Error("expected an error for non-struct arguments")
+	}
+
+	result, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("Go")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"error: the call was not approved",
+		`error: unknown tool "unknown"`,
+		"error: invalid arguments",
+		"error: context deadline exceeded",
+		"error: panic: boom",
+	}
+	for i, prefix := range expected {
+		if content := result.Messages[2+i].Content; !strings.HasPrefix(content, prefix) {
+			t.Errorf("expected tool message %d to start with %q, got %q", i, prefix, content)
+		}
+	}
+	if deleted || !reflect.DeepEqual(asked, []string{`{"path":"/etc/passwd"}`}) {
+		t.Errorf("expected the approver to be asked and the call to be skipped, got %v, deleted=%v", asked, deleted)
+	}
+}
+
+func TestToolRunnerMaxIterations(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := json.Marshal(ChatCompletionResponse{Choices: []ChatCompletionResponseChoice{{
+			Message:      ChatMessage{Role: RoleAssistant, ToolCalls: []ToolCall{toolCall("call", "noop", "{}")}},
+			FinishReason: FinishReasonToolCalls,
+		}}})
+		MockJSONResponse(http.StatusOK, string(data)).Write(w)
+	})
+	defer mock.Close()
+
+	runner := NewToolRunner(mock.GetClient(), WithMaxToolIterations(3))
+	if err := RegisterTool(runner, "noop", "Does nothing", func(ctx context.Context, args struct{}) (string, error) { return "ok", nil }); err != nil {
+		t.Fatal(err)
+	}
+	result, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("Loop")}, nil)
+	if !errors.Is(err, ErrMaxToolIterations) || result.Iterations != 3 || len(result.Messages) != 7 {
+		t.Errorf("expected to stop after 3 iterations with the transcript, got %v, %+v", err, result)
+	}
+}
+
+func TestToolRunnerApproverErrorStopsRun(t *testing.T) {
+	var requests []toolRequest
+	mock := toolServer(t, &requests, []ToolCall{toolCall("call-1", "pay", "{}")}, "Paid.")
+	defer mock.Close()
+
+	denied := errors.New("approval service down")
+	runner := NewToolRunner(mock.GetClient(), WithToolApprover(func(ctx context.Context, call ToolCall) (bool, error) { return false, denied }))
+	if err := RegisterTool(runner, "pay", "Pay", func(ctx context.Context, args struct{}) (string, error) { return "ok", nil }, WithApprovalRequired()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Run("mistral-large-latest", []ChatMessage{UserMessage("Pay")}, nil); !errors.Is(err, denied) {
+		t.Errorf("expected the approver error, got %v", err)
+	}
+}
+
+func TestToolRunnerStreamAndAgent(t *testing.T) {
+	var paths []string
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req toolRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		paths = append(paths, r.URL.Path)
+		toolTurn := req.Messages[len(req.Messages)-1].Role != RoleTool
+		if !req.Stream {
+			message, finishReason := ChatMessage{Role: RoleAssistant, Content: "It is sunny."}, FinishReasonStop
+			if toolTurn {
+				message, finishReason = ChatMessage{Role: RoleAssistant, ToolCalls: []ToolCall{toolCall("call-1", "get_weather", `{"city":"Paris"}`)}}, FinishReasonToolCalls
+			}
+			data, _ := json.Marshal(ChatCompletionResponse{Choices: []ChatCompletionResponseChoice{{Message: message, FinishReason: finishReason}}})
+			MockJSONResponse(http.StatusOK, string(data)).Write(w)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		if toolTurn {
+			_, _ = io.WriteString(w, `data: {"choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"id":"call-1","index":0,"function":{"name":"get_weather","arguments":"{\"city\":"}}]}}]}`+"\n\n")
+			_, _ = io.WriteString(w, `data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]},"finish_reason":"tool_calls"}]}`+"\n\n")
+		} else {
+			_, _ = io.WriteString(w, `data: {"choices":[{"index":0,"delta":{"role":"assistant","content":"It is "}}]}`+"\n\n")
+			_, _ = io.WriteString(w, `data: {"choices":[{"index":0,"delta":{"content":"sunny."},"finish_reason":"stop"}]}`+"\n\n")
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	var cities []string
+	runner := NewToolRunner(mock.GetClient())
+	if err := RegisterTool(runner, "get_weather", "Get the weather", func(ctx context.Context, args cityArgs) (string, error) {
+		cities = append(cities, args.City)
+		return "sunny", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var streamed strings.Builder
+	result, err := runner.RunStream("mistral-large-latest", []ChatMessage{UserMessage("Weather?")}, nil, func(delta string) { streamed.WriteString(delta) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if streamed.String() != "It is sunny." || result.Response.Choices[0].Message.Content != "It is sunny." || len(result.Messages) != 4 {
+		t.Errorf("unexpected stream result %q, %+v", streamed.String(), result)
+	}
+
+	if _, err := runner.RunAgent("agent-1", []ChatMessage{UserMessage("Weather?")}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cities, []string{"Paris", "Paris"}) || paths[len(paths)-1] != "/v1/agents/completions" {
+		t.Errorf("expected the tool to run for both runs, got %v over %v", cities, paths)
+	}
+}
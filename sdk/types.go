@@ -102,22 +102,25 @@ type ToolCall struct {
 	Id       string       `json:"id"`
 	Type     ToolType     `json:"type"`
 	Function FunctionCall `json:"function"`
+	Index    int          `json:"index,omitempty"` // Position of the call in a stream, where arguments may arrive in fragments
 }
 
 // DeltaMessage represents the delta between the prior state of the message and the new state of the message when streaming responses.
 type DeltaMessage struct {
-	Role      string     `json:"role"`
-	Content   string     `json:"content"`
-	ToolCalls []ToolCall `json:"tool_calls"`
+	Role         string        `json:"role"`
+	Content      string        `json:"content"`
+	ContentParts []ContentPart `json:"-"` // Set when the content arrives as chunks; Content then holds their text
+	ToolCalls    []ToolCall    `json:"tool_calls"`
 }
 
 // ChatMessage represents a single message in a chat.
 type ChatMessage struct {
-	Role       string     `json:"role"`
-	Content    string     `json:"content,omitempty"`
-	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
-	ToolCallID string     `json:"tool_call_id,omitempty"` // For tool role messages
-	Name       string     `json:"name,omitempty"`         // For function/tool messages
+	Role         string        `json:"role"`
+	Content      string        `json:"content,omitempty"`
+	ContentParts []ContentPart `json:"-"` // Multimodal content, sent instead of Content when set
+	ToolCalls    []ToolCall    `json:"tool_calls,omitempty"`
+	ToolCallID   string        `json:"tool_call_id,omitempty"` // For tool role messages
+	Name         string        `json:"name,omitempty"`         // For function/tool messages
 }
 
 // SystemMessage creates a system message
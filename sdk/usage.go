@@ -0,0 +1,436 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ModelPrice is the price of a model per unit of usage, in the currency of the caller's choice.
+// Units the model does not bill are left zero.
+type ModelPrice struct {
+	PromptPerMillion     float64 // Per million prompt tokens
+	CompletionPerMillion float64 // Per million completion tokens
+	PerPage              float64 // Per OCR page
+	PerAudioMinute       float64 // Per minute of transcribed audio
+}
+
+// Cost returns the price of usage.
+func (p ModelPrice) Cost(usage Usage) float64 {
+	return float64(usage.PromptTokens)*p.PromptPerMillion/1e6 +
+		float64(usage.CompletionTokens)*p.CompletionPerMillion/1e6 +
+		float64(usage.Pages)*p.PerPage +
+		usage.AudioSeconds/60*p.PerAudioMinute
+}
+
+// PriceTable maps model IDs, such as "mistral-large-latest", to their prices.
+type PriceTable map[string]ModelPrice
+
+// Usage is the usage and cost aggregated over calls.
+type Usage struct {
+	Requests         int
+	PromptTokens     int
+	CompletionTokens int
+	Pages            int     // OCR pages processed
+	AudioSeconds     float64 // Seconds of audio transcribed
+	Cost             float64
+}
+
+func (u *Usage) add(other Usage) {
+	u.Requests += other.Requests
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.Pages += other.Pages
+	u.AudioSeconds += other.AudioSeconds
+	u.Cost += other.Cost
+}
+
+// Budget caps the cost of calls. Once a hard budget is spent, calls fail with a
+// *BudgetExceededError before they are sent. A soft budget lets calls through and reports them to
+// the handler set with SetSoftBudgetHandler.
+type Budget struct {
+	Limit float64 // Zero or less removes the budget
+	Soft  bool
+}
+
+// BudgetExceededError is returned for a call that would go over a hard budget.
+type BudgetExceededError struct {
+	Scope     string  // "total", "model <id>", or "tag <key>=<value>"
+	Limit     float64 // Budget limit
+	Spent     float64 // Cost recorded so far in the scope
+	Estimated float64 // Estimated prompt cost of the call
+	Soft      bool
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("mistral: %s budget of %.4f exceeded: spent %.4f, call estimated at %.4f", e.Scope, e.Limit, e.Spent, e.Estimated)
+}
+
+type usageTagsKey struct{}
+
+// WithUsageTags returns a context whose calls are recorded under tags, such as tenant or feature,
+// in addition to the tags already in ctx.
+func WithUsageTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := make(map[string]string, len(tags))
+	for key, value := range UsageTags(ctx) {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, usageTagsKey{}, merged)
+}
+
+// UsageTags returns the usage tags carried by ctx.
+func UsageTags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(usageTagsKey{}).(map[string]string)
+	return tags
+}
+
+// UsageTracker records the tokens, OCR pages, and audio seconds of inference calls, converts them
+// into cost with a price table, and aggregates them in total, per model, and per usage tag. It
+// also enforces budgets on those costs.
+//
+//	tracker := sdk.NewUsageTracker(sdk.PriceTable{
+//		"mistral-large-latest": {PromptPerMillion: 2, CompletionPerMillion: 6},
+//	})
+//	tracker.SetTagBudget("tenant", "acme", sdk.Budget{Limit: 100})
+//	client := sdk.NewClient(sdk.WithUsageTracker(tracker))
+//
+//	ctx = sdk.WithUsageTags(ctx, map[string]string{"tenant": "acme", "feature": "search"})
+//	resp, err := client.ChatCtx(ctx, "mistral-large-latest", messages, nil)
+//	byTenant := tracker.ByTag("tenant")
+//
+// Conversation starts, appends, and restarts are tracked too, streaming or not. Appends, restarts,
+// and agent conversations name no model: they count in Total and ByTag but not ByModel, and are
+// priced by the "" entry of the price table, if any.
+//
+// Calls to models missing from the price table are recorded at no cost. Budgets are checked against
+// the recorded cost plus an estimate of the call's prompt, so concurrent calls in flight can
+// together go over a budget. A tracker is safe for concurrent use and may be shared by clients.
+type UsageTracker struct {
+	mu      sync.Mutex
+	prices  PriceTable
+	total   Usage
+	models  map[string]*Usage
+	tags    map[string]map[string]*Usage
+	budgets map[string]Budget // By scope
+	onSoft  func(ctx context.Context, err *BudgetExceededError)
+}
+
+// NewUsageTracker returns a tracker pricing calls with prices, which may be nil.
+func NewUsageTracker(prices PriceTable) *UsageTracker {
+	t := &UsageTracker{
+		prices:  PriceTable{},
+		models:  map[string]*Usage{},
+		tags:    map[string]map[string]*Usage{},
+		budgets: map[string]Budget{},
+	}
+	for model, price := range prices {
+		t.prices[model] = price
+	}
+	return t
+}
+
+// WithUsageTracker records the usage of the client's calls in tracker and enforces its budgets.
+func WithUsageTracker(tracker *UsageTracker) Option {
+	return func(c *MistralClient) {
+		c.usageTracker = tracker
+	}
+}
+
+// SetPrice sets the price of model.
+func (t *UsageTracker) SetPrice(model string, price ModelPrice) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prices[model] = price
+}
+
+// SetBudget sets the budget of all calls.
+func (t *UsageTracker) SetBudget(budget Budget) {
+	t.setBudget("total", budget)
+}
+
+// SetModelBudget sets the budget of the calls to model.
+func (t *UsageTracker) SetModelBudget(model string, budget Budget) {
+	t.setBudget("model "+model, budget)
+}
+
+// SetTagBudget sets the budget of the calls tagged key=value.
+func (t *UsageTracker) SetTagBudget(key, value string, budget Budget) {
+	t.setBudget("tag "+key+"="+value, budget)
+}
+
+func (t *UsageTracker) setBudget(scope string, budget Budget) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if budget.Limit <= 0 {
+		delete(t.budgets, scope)
+		return
+	}
+	t.budgets[scope] = budget
+}
+
+// SetSoftBudgetHandler sets the function called, before the call is sent, for every call that
+// goes over a soft budget.
+func (t *UsageTracker) SetSoftBudgetHandler(handler func(ctx context.Context, err *BudgetExceededError)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onSoft = handler
+}
+
+// Total returns the usage of all calls.
+func (t *UsageTracker) Total() Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// ByModel returns the usage per model.
+func (t *UsageTracker) ByModel() map[string]Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return copyUsage(t.models)
+}
+
+// ByTag returns the usage per value of the tag key. Calls without the tag are not included.
+func (t *UsageTracker) ByTag(key string) map[string]Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return copyUsage(t.tags[key])
+}
+
+// Reset clears the recorded usage, for example at the start of a billing period. Prices and
+// budgets are kept.
+func (t *UsageTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = Usage{}
+	t.models = map[string]*Usage{}
+	t.tags = map[string]map[string]*Usage{}
+}
+
+// Record adds usage of model under tags, for calls the client does not see. Its cost is computed
+// from the price table when usage.Cost is zero.
+func (t *UsageTracker) Record(model string, tags map[string]string, usage Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if usage.Cost == 0 {
+		usage.Cost = t.prices[model].Cost(usage)
+	}
+	t.total.add(usage)
+	if model != "" {
+		if t.models[model] == nil {
+			t.models[model] = &Usage{}
+		}
+		t.models[model].add(usage)
+	}
+	for key, value := range tags {
+		if t.tags[key] == nil {
+			t.tags[key] = map[string]*Usage{}
+		}
+		if t.tags[key][value] == nil {
+			t.tags[key][value] = &Usage{}
+		}
+		t.tags[key][value].add(usage)
+	}
+}
+
+func copyUsage(usage map[string]*Usage) map[string]Usage {
+	out := make(map[string]Usage, len(usage))
+	for key, u := range usage {
+		out[key] = *u
+	}
+	return out
+}
+
+// trackedCall is an inference call the tracker is recording.
+type trackedCall struct {
+	tracker *UsageTracker
+	path    string
+	model   string
+	tags    map[string]string
+}
+
+// begin checks the budgets of an inference or conversation request. It returns nil for other requests.
+func (t *UsageTracker) begin(req *http.Request) (*trackedCall, error) {
+	if req.Method != http.MethodPost || (!isInferencePath(req.URL.Path) && !isConversationPath(req.URL.Path)) {
+		return nil, nil
+	}
+	call := &trackedCall{tracker: t, path: req.URL.Path, tags: UsageTags(req.Context())}
+	estimate := 0
+	if model, payload := jsonPayload(req); model != "" {
+		call.model = model
+		estimate = estimatePromptTokens(model, payload)
+	} else {
+		call.model = multipartModel(req)
+	}
+
+	t.mu.Lock()
+	estimated := t.prices[call.model].Cost(Usage{PromptTokens: estimate})
+	scopes := []string{"total"}
+	if call.model != "" {
+		scopes = append(scopes, "model "+call.model)
+	}
+	keys := make([]string, 0, len(call.tags))
+	for key := range call.tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		scopes = append(scopes, "tag "+key+"="+call.tags[key])
+	}
+	var exceeded []*BudgetExceededError
+	for _, scope := range scopes {
+		budget, ok := t.budgets[scope]
+		if !ok {
+			continue
+		}
+		spent := t.spent(scope, call)
+		if spent >= budget.Limit || spent+estimated > budget.Limit {
+			err := &BudgetExceededError{Scope: scope, Limit: budget.Limit, Spent: spent, Estimated: estimated, Soft: budget.Soft}
+			if !budget.Soft {
+				t.mu.Unlock()
+				return nil, err
+			}
+			exceeded = append(exceeded, err)
+		}
+	}
+	onSoft := t.onSoft
+	t.mu.Unlock()
+
+	if onSoft != nil {
+		for _, err := range exceeded {
+			onSoft(req.Context(), err)
+		}
+	}
+	return call, nil
+}
+
+// isConversationPath reports whether path starts, appends to, or restarts a conversation. Those
+// calls run the model; listing, history, and deletion do not.
+func isConversationPath(path string) bool {
+	_, rest, ok := strings.Cut(path, "/v1/conversations")
+	if !ok || rest == "" {
+		return ok
+	}
+	id, action, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+	return strings.HasPrefix(rest, "/") && id != "" && (action == "" || action == "restart")
+}
+
+// spent returns the cost recorded in scope, which is one of the scopes of call.
+func (t *UsageTracker) spent(scope string, call *trackedCall) float64 {
+	switch {
+	case scope == "total":
+		return t.total.Cost
+	case strings.HasPrefix(scope, "model "):
+		if u := t.models[call.model]; u != nil {
+			return u.Cost
+		}
+	default:
+		key, value, _ := strings.Cut(strings.TrimPrefix(scope, "tag "), "=")
+		if u := t.tags[key][value]; u != nil {
+			return u.Cost
+		}
+	}
+	return 0
+}
+
+// watch records the usage in the response of a successful call: right away for JSON bodies, and
+// once the body is closed for streams.
+func (c *trackedCall) watch(resp *http.Response) {
+	if resp.StatusCode >= 400 {
+		return
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "text/event-stream") {
+		reader := &usageReader{ReadCloser: resp.Body}
+		reader.done = func(int, bool) {
+			c.record(reader.usage)
+		}
+		resp.Body = reader
+		return
+	}
+	if !strings.HasPrefix(contentType, "application/json") {
+		return
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err == nil {
+		c.record(body)
+	}
+}
+
+// record parses the usage of a response body or stream event. Pages default to the pages returned
+// by OCR, and audio seconds to the duration of a transcription.
+func (c *trackedCall) record(data []byte) {
+	var payload struct {
+		Model string `json:"model"`
+		Usage *struct {
+			PromptTokens       int     `json:"prompt_tokens"`
+			CompletionTokens   int     `json:"completion_tokens"`
+			PromptAudioSeconds float64 `json:"prompt_audio_seconds"`
+		} `json:"usage"`
+		UsageInfo *struct {
+			PagesProcessed int `json:"pages_processed"`
+		} `json:"usage_info"`
+		Pages    []json.RawMessage `json:"pages"`
+		Duration *float64          `json:"duration"`
+	}
+	_ = json.Unmarshal(data, &payload)
+
+	usage := Usage{Requests: 1}
+	if payload.Usage != nil {
+		usage.PromptTokens = payload.Usage.PromptTokens
+		usage.CompletionTokens = payload.Usage.CompletionTokens
+		usage.AudioSeconds = payload.Usage.PromptAudioSeconds
+	}
+	if strings.HasSuffix(c.path, "/ocr") {
+		usage.Pages = len(payload.Pages)
+		if payload.UsageInfo != nil && payload.UsageInfo.PagesProcessed > 0 {
+			usage.Pages = payload.UsageInfo.PagesProcessed
+		}
+	}
+	if strings.HasSuffix(c.path, "/audio/transcriptions") && usage.AudioSeconds == 0 && payload.Duration != nil {
+		usage.AudioSeconds = math.Max(*payload.Duration, 0)
+	}
+
+	model := c.model
+	if model == "" {
+		model = payload.Model
+	}
+	c.tracker.Record(model, c.tags, usage)
+}
+
+// multipartModel returns the model field of a multipart request, such as a transcription.
+func multipartModel(req *http.Request) string {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return ""
+	}
+	data, err := RequestBody(req)
+	if err != nil {
+		return ""
+	}
+	reader := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return ""
+		}
+		if part.FormName() == "model" {
+			value, _ := io.ReadAll(io.LimitReader(part, 256))
+			return string(value)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// usageServer answers chat calls with 1000 prompt and 500 completion tokens.
+func usageServer(t *testing.T) *MockHTTPServer {
+	return NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		MockJSONResponse(http.StatusOK, `{"id":"c1","model":"mistral-large-2411","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1000,"completion_tokens":500,"total_tokens":1500}}`).Write(w)
+	})
+}
+
+func TestUsageTrackerAggregatesByModelAndTag(t *testing.T) {
+	mock := usageServer(t)
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{"mistral-large-latest": {PromptPerMillion: 2, CompletionPerMillion: 6}})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+
+	ctx := WithUsageTags(context.Background(), map[string]string{"tenant": "acme"})
+	ctx = WithUsageTags(ctx, map[string]string{"feature": "search"})
+	resp, err := client.ChatCtx(ctx, "mistral-large-latest", []ChatMessage{UserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("ChatCtx: %v", err)
+	}
+	if resp.Usage.TotalTokens != 1500 {
+		t.Errorf("response body was not preserved: %+v", resp.Usage)
+	}
+	if _, err := client.ChatCtx(WithUsageTags(context.Background(), map[string]string{"tenant": "globex"}), "mistral-small-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("ChatCtx: %v", err)
+	}
+
+	total := tracker.Total()
+	if total.Requests != 2 || total.PromptTokens != 2000 || total.CompletionTokens != 1000 || !closeTo(total.Cost, 0.005) {
+		t.Errorf("Total = %+v", total)
+	}
+	byModel := tracker.ByModel()
+	if large := byModel["mistral-large-latest"]; large.Requests != 1 || !closeTo(large.Cost, 0.005) {
+		t.Errorf("large = %+v", large)
+	}
+	if small := byModel["mistral-small-latest"]; small.Requests != 1 || small.Cost != 0 {
+		t.Errorf("unpriced model = %+v", small)
+	}
+	byTenant := tracker.ByTag("tenant")
+	if len(byTenant) != 2 || !closeTo(byTenant["acme"].Cost, 0.005) || byTenant["globex"].PromptTokens != 1000 {
+		t.Errorf("ByTag(tenant) = %+v", byTenant)
+	}
+	if search := tracker.ByTag("feature")["search"]; search.Requests != 1 {
+		t.Errorf("ByTag(feature) = %+v", tracker.ByTag("feature"))
+	}
+
+	tracker.Reset()
+	if tracker.Total() != (Usage{}) || len(tracker.ByModel()) != 0 {
+		t.Errorf("Reset left %+v", tracker.Total())
+	}
+}
+
+func TestUsageTrackerHardBudgetFailsBeforeSending(t *testing.T) {
+	mock := usageServer(t)
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{"mistral-large-latest": {PromptPerMillion: 2, CompletionPerMillion: 6}})
+	tracker.SetTagBudget("tenant", "acme", Budget{Limit: 0.004})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+	acme := WithUsageTags(context.Background(), map[string]string{"tenant": "acme"})
+
+	if _, err := client.ChatCtx(acme, "mistral-large-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	_, err := client.ChatCtx(acme, "mistral-large-latest", []ChatMessage{UserMessage("hi")}, nil)
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected *BudgetExceededError, got %v", err)
+	}
+	if budgetErr.Scope != "tag tenant=acme" || !closeTo(budgetErr.Spent, 0.005) || budgetErr.Limit != 0.004 {
+		t.Errorf("error = %+v", budgetErr)
+	}
+	if len(mock.Requests) != 1 {
+		t.Errorf("server received %d requests, want 1", len(mock.Requests))
+	}
+
+	// Other tenants are not affected.
+	if _, err := client.Chat("mistral-large-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+		t.Errorf("untagged call: %v", err)
+	}
+
+	// The estimated prompt cost counts against the budget too.
+	tracker.SetModelBudget("mistral-large-latest", Budget{Limit: 0.0101})
+	long := strings.Repeat("word ", 1000)
+	if _, err := client.Chat("mistral-large-latest", []ChatMessage{UserMessage(long)}, nil); !errors.As(err, &budgetErr) || budgetErr.Scope != "model mistral-large-latest" || budgetErr.Estimated == 0 {
+		t.Errorf("expected the model budget to reject the long prompt, got %v", err)
+	}
+}
+
+func TestUsageTrackerSoftBudgetReports(t *testing.T) {
+	mock := usageServer(t)
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{"mistral-large-latest": {PromptPerMillion: 2, CompletionPerMillion: 6}})
+	tracker.SetBudget(Budget{Limit: 0.001, Soft: true})
+	var reported []*BudgetExceededError
+	tracker.SetSoftBudgetHandler(func(ctx context.Context, err *BudgetExceededError) {
+		reported = append(reported, err)
+	})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Chat("mistral-large-latest", []ChatMessage{UserMessage("hi")}, nil); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if len(reported) != 1 || reported[0].Scope != "total" || !reported[0].Soft {
+		t.Errorf("reported = %+v", reported)
+	}
+	if len(mock.Requests) != 2 {
+		t.Errorf("server received %d requests, want 2", len(mock.Requests))
+	}
+}
+
+func TestUsageTrackerRecordsStreams(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}]}\n\n")
+		_, _ = io.WriteString(w, "data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}],\"usage\":{\"prompt_tokens\":10,\"completion_tokens\":20,\"total_tokens\":30}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{"codestral-latest": {PromptPerMillion: 1e5, CompletionPerMillion: 1e5}})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+	stream, err := client.FIMStream(&FIMRequestParams{Model: "codestral-latest", Prompt: "def"})
+	if err != nil {
+		t.Fatalf("FIMStream: %v", err)
+	}
+	for stream.Next() {
+	}
+	stream.Close()
+
+	usage := tracker.ByModel()["codestral-latest"]
+	if usage.Requests != 1 || usage.PromptTokens != 10 || usage.CompletionTokens != 20 || !closeTo(usage.Cost, 3) {
+		t.Errorf("usage = %+v", usage)
+	}
+}
+
+func TestUsageTrackerRecordsConversations(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			MockJSONResponse(http.StatusOK, `{"conversation_id":"conv-1","entries":[]}`).Write(w)
+			return
+		}
+		if strings.Contains(ReadRequestBody(r), `"stream":true`) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "event: conversation.response.started\ndata: {\"type\":\"conversation.response.started\",\"conversation_id\":\"conv-1\"}\n\n")
+			_, _ = io.WriteString(w, "event: conversation.response.done\ndata: {\"type\":\"conversation.response.done\",\"usage\":{\"prompt_tokens\":10,\"completion_tokens\":20,\"total_tokens\":30}}\n\n")
+			return
+		}
+		MockJSONResponse(http.StatusOK, `{"conversation_id":"conv-1","object":"conversation.response","outputs":[],"usage":{"prompt_tokens":100,"completion_tokens":200,"total_tokens":300}}`).Write(w)
+	})
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{"mistral-large-latest": {PromptPerMillion: 1e4, CompletionPerMillion: 1e4}})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+	inputs := []ConversationInput{{Type: "text", Content: "hi"}}
+	if _, err := client.StartConversation(&ConversationStartRequest{Model: StringPtr("mistral-large-latest"), Inputs: inputs}); err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	stream, err := client.AppendToConversationStream("conv-1", &ConversationAppendRequest{Inputs: inputs})
+	if err != nil {
+		t.Fatalf("AppendToConversationStream: %v", err)
+	}
+	for stream.Next() {
+	}
+	stream.Close()
+	if _, err := client.RestartConversation("conv-1", inputs); err != nil {
+		t.Fatalf("RestartConversation: %v", err)
+	}
+	if _, err := client.GetConversationHistory("conv-1"); err != nil {
+		t.Fatalf("GetConversationHistory: %v", err)
+	}
+
+	total := tracker.Total()
+	if total.Requests != 3 || total.PromptTokens != 210 || total.CompletionTokens != 420 {
+		t.Errorf("total = %+v", total)
+	}
+	if usage := tracker.ByModel()["mistral-large-latest"]; usage.Requests != 1 || !closeTo(usage.Cost, 3) {
+		t.Errorf("model usage = %+v", usage)
+	}
+}
+
+func TestUsageTrackerRecordsPagesAndAudio(t *testing.T) {
+	mock := NewMockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ocr" {
+			MockJSONResponse(http.StatusOK, `{"model":"mistral-ocr-latest","pages":[{"page_number":0},{"page_number":1},{"page_number":2}],"usage_info":{"pages_processed":3}}`).Write(w)
+			return
+		}
+		MockJSONResponse(http.StatusOK, `{"model":"voxtral-mini-latest","text":"hello","duration":12,"usage":{"prompt_audio_seconds":90,"prompt_tokens":4,"total_tokens":10,"completion_tokens":6}}`).Write(w)
+	})
+	defer mock.Close()
+
+	tracker := NewUsageTracker(PriceTable{
+		"mistral-ocr-latest":  {PerPage: 0.001},
+		"voxtral-mini-latest": {PerAudioMinute: 0.002},
+	})
+	client := NewClient(WithBaseURL(mock.Server.URL), WithUsageTracker(tracker))
+
+	if _, err := client.ProcessOCR("mistral-ocr-latest", OCRDocument{URL: StringPtr("https://example.com/doc.pdf")}, nil); err != nil {
+		t.Fatalf("ProcessOCR: %v", err)
+	}
+	if _, err := client.Transcribe("voxtral-mini-latest", bytes.NewReader([]byte("audio")), "a.mp3", nil); err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+
+	byModel := tracker.ByModel()
+	if ocr := byModel["mistral-ocr-latest"]; ocr.Pages != 3 || !closeTo(ocr.Cost, 0.003) {
+		t.Errorf("OCR usage = %+v", ocr)
+	}
+	if audio := byModel["voxtral-mini-latest"]; audio.AudioSeconds != 90 || !closeTo(audio.Cost, 0.003) {
+		t.Errorf("transcription usage = %+v", audio)
+	}
+}
@@ -1,8 +1,8 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 )
@@ -61,47 +61,92 @@ type WorkflowExecutionLogsStreamParams struct {
 }
 
 func (c *MistralClient) GetWorkflowExecution(executionID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s", executionID))
+	return c.GetWorkflowExecutionCtx(context.Background(), executionID)
+}
+
+func (c *MistralClient) GetWorkflowExecutionCtx(ctx context.Context, executionID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s", executionID))
 }
 
 func (c *MistralClient) GetWorkflowExecutionHistory(executionID string, decodePayloads *bool) (APIResponse, error) {
+	return c.GetWorkflowExecutionHistoryCtx(context.Background(), executionID, decodePayloads)
+}
+
+func (c *MistralClient) GetWorkflowExecutionHistoryCtx(ctx context.Context, executionID string, decodePayloads *bool) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get_history")
 	query := queryWithOptionalValues(map[string]any{"decode_payloads": decodePayloads})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/history", executionID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/history", executionID), query))
 }
 
 func (c *MistralClient) SignalWorkflowExecution(executionID string, req *WorkflowSignalRequest) (APIResponse, error) {
+	return c.SignalWorkflowExecutionCtx(context.Background(), executionID, req)
+}
+
+func (c *MistralClient) SignalWorkflowExecutionCtx(ctx context.Context, executionID string, req *WorkflowSignalRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.signal")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "input": req.Input})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/signals", executionID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/signals", executionID))
 }
 
 func (c *MistralClient) QueryWorkflowExecution(executionID string, req *WorkflowQueryRequest) (APIResponse, error) {
+	return c.QueryWorkflowExecutionCtx(context.Background(), executionID, req)
+}
+
+func (c *MistralClient) QueryWorkflowExecutionCtx(ctx context.Context, executionID string, req *WorkflowQueryRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.query")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "input": req.Input})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/queries", executionID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/queries", executionID))
 }
 
 func (c *MistralClient) TerminateWorkflowExecution(executionID string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, nil, fmt.Sprintf("v1/workflows/executions/%s/terminate", executionID))
+	return c.TerminateWorkflowExecutionCtx(context.Background(), executionID)
+}
+
+func (c *MistralClient) TerminateWorkflowExecutionCtx(ctx context.Context, executionID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.terminate")
+	return c.requestMap(ctx, http.MethodPost, nil, fmt.Sprintf("v1/workflows/executions/%s/terminate", executionID))
 }
 
 func (c *MistralClient) BatchTerminateWorkflowExecutions(executionIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"execution_ids": executionIDs}, "v1/workflows/executions/terminate")
+	return c.BatchTerminateWorkflowExecutionsCtx(context.Background(), executionIDs)
+}
+
+func (c *MistralClient) BatchTerminateWorkflowExecutionsCtx(ctx context.Context, executionIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.batch_terminate")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"execution_ids": executionIDs}, "v1/workflows/executions/terminate")
 }
 
 func (c *MistralClient) CancelWorkflowExecution(executionID string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, nil, fmt.Sprintf("v1/workflows/executions/%s/cancel", executionID))
+	return c.CancelWorkflowExecutionCtx(context.Background(), executionID)
+}
+
+func (c *MistralClient) CancelWorkflowExecutionCtx(ctx context.Context, executionID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.cancel")
+	return c.requestMap(ctx, http.MethodPost, nil, fmt.Sprintf("v1/workflows/executions/%s/cancel", executionID))
 }
 
 func (c *MistralClient) BatchCancelWorkflowExecutions(executionIDs []string) (APIResponse, error) {
-	return c.requestMap(http.MethodPost, map[string]interface{}{"execution_ids": executionIDs}, "v1/workflows/executions/cancel")
+	return c.BatchCancelWorkflowExecutionsCtx(context.Background(), executionIDs)
+}
+
+func (c *MistralClient) BatchCancelWorkflowExecutionsCtx(ctx context.Context, executionIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.batch_cancel")
+	return c.requestMap(ctx, http.MethodPost, map[string]interface{}{"execution_ids": executionIDs}, "v1/workflows/executions/cancel")
 }
 
 func (c *MistralClient) ResetWorkflow(executionID string, req *ResetWorkflowRequest) (APIResponse, error) {
+	return c.ResetWorkflowCtx(context.Background(), executionID, req)
+}
+
+func (c *MistralClient) ResetWorkflowCtx(ctx context.Context, executionID string, req *ResetWorkflowRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.reset")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -111,26 +156,46 @@ func (c *MistralClient) ResetWorkflow(executionID string, req *ResetWorkflowRequ
 		"exclude_signals": req.ExcludeSignals,
 		"exclude_updates": req.ExcludeUpdates,
 	})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/reset", executionID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/reset", executionID))
 }
 
 func (c *MistralClient) UpdateWorkflowExecution(executionID string, req *WorkflowUpdateRequest) (APIResponse, error) {
+	return c.UpdateWorkflowExecutionCtx(context.Background(), executionID, req)
+}
+
+func (c *MistralClient) UpdateWorkflowExecutionCtx(ctx context.Context, executionID string, req *WorkflowUpdateRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"name": req.Name, "input": req.Input})
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/updates", executionID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/executions/%s/updates", executionID))
 }
 
 func (c *MistralClient) GetWorkflowExecutionTraceOTEL(executionID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s/trace/otel", executionID))
+	return c.GetWorkflowExecutionTraceOTELCtx(context.Background(), executionID)
+}
+
+func (c *MistralClient) GetWorkflowExecutionTraceOTELCtx(ctx context.Context, executionID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get_trace_otel")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s/trace/otel", executionID))
 }
 
 func (c *MistralClient) GetWorkflowExecutionTraceSummary(executionID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s/trace/summary", executionID))
+	return c.GetWorkflowExecutionTraceSummaryCtx(context.Background(), executionID)
+}
+
+func (c *MistralClient) GetWorkflowExecutionTraceSummaryCtx(ctx context.Context, executionID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get_trace_summary")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/executions/%s/trace/summary", executionID))
 }
 
 func (c *MistralClient) GetWorkflowExecutionTraceEvents(executionID string, params *WorkflowTraceEventsParams) (APIResponse, error) {
+	return c.GetWorkflowExecutionTraceEventsCtx(context.Background(), executionID, params)
+}
+
+func (c *MistralClient) GetWorkflowExecutionTraceEventsCtx(ctx context.Context, executionID string, params *WorkflowTraceEventsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get_trace_events")
 	if params == nil {
 		params = &WorkflowTraceEventsParams{}
 	}
@@ -138,26 +203,42 @@ func (c *MistralClient) GetWorkflowExecutionTraceEvents(executionID string, para
 		"merge_same_id_events":    params.MergeSameIDEvents,
 		"include_internal_events": params.IncludeInternalEvents,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/trace/events", executionID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/trace/events", executionID), query))
 }
 
-func (c *MistralClient) StreamWorkflowExecution(executionID string, params *WorkflowExecutionStreamParams) (<-chan StreamEvent, error) {
+func (c *MistralClient) StreamWorkflowExecution(executionID string, params *WorkflowExecutionStreamParams) (*Stream[StreamEvent], error) {
+	return c.StreamWorkflowExecutionCtx(context.Background(), executionID, params)
+}
+
+func (c *MistralClient) StreamWorkflowExecutionCtx(ctx context.Context, executionID string, params *WorkflowExecutionStreamParams) (*Stream[StreamEvent], error) {
+	ctx = withOperation(ctx, "workflows.executions.stream")
 	if params == nil {
 		params = &WorkflowExecutionStreamParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"event_source": params.EventSource, "last_event_id": params.LastEventID})
-	response, err := c.request(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/stream", executionID), query), true, nil)
+	return c.streamEvents(ctx, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/stream", executionID), query), true)
+}
+
+// StreamWorkflowExecutionChan is like StreamWorkflowExecution but delivers the events, and an error that ends the stream, on a channel.
+func (c *MistralClient) StreamWorkflowExecutionChan(executionID string, params *WorkflowExecutionStreamParams) (<-chan StreamEvent, error) {
+	return c.StreamWorkflowExecutionChanCtx(context.Background(), executionID, params)
+}
+
+// StreamWorkflowExecutionChanCtx is like StreamWorkflowExecutionChan but uses ctx for cancellation and deadlines.
+func (c *MistralClient) StreamWorkflowExecutionChanCtx(ctx context.Context, executionID string, params *WorkflowExecutionStreamParams) (<-chan StreamEvent, error) {
+	stream, err := c.StreamWorkflowExecutionCtx(ctx, executionID, params)
 	if err != nil {
 		return nil, err
 	}
-	body, ok := response.(io.ReadCloser)
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	return parseGenericStream(body), nil
+	return stream.channel(ctx, func(err error) StreamEvent { return StreamEvent{Error: err} }), nil
 }
 
 func (c *MistralClient) GetWorkflowExecutionLogs(executionID string, params *WorkflowExecutionLogsParams) (APIResponse, error) {
+	return c.GetWorkflowExecutionLogsCtx(context.Background(), executionID, params)
+}
+
+func (c *MistralClient) GetWorkflowExecutionLogsCtx(ctx context.Context, executionID string, params *WorkflowExecutionLogsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.executions.get_logs")
 	if params == nil {
 		params = &WorkflowExecutionLogsParams{}
 	}
@@ -170,10 +251,15 @@ func (c *MistralClient) GetWorkflowExecutionLogs(executionID string, params *Wor
 		"cursor":      params.Cursor,
 		"limit":       params.Limit,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/logs", executionID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/logs", executionID), query))
 }
 
 func (c *MistralClient) StreamWorkflowExecutionLogs(executionID string, params *WorkflowExecutionLogsStreamParams) (<-chan StreamEvent, error) {
+	return c.StreamWorkflowExecutionLogsCtx(context.Background(), executionID, params)
+}
+
+func (c *MistralClient) StreamWorkflowExecutionLogsCtx(ctx context.Context, executionID string, params *WorkflowExecutionLogsStreamParams) (<-chan StreamEvent, error) {
+	ctx = withOperation(ctx, "workflows.executions.stream_logs")
 	if params == nil {
 		params = &WorkflowExecutionLogsStreamParams{}
 	}
@@ -183,13 +269,5 @@ func (c *MistralClient) StreamWorkflowExecutionLogs(executionID string, params *
 		"after":         params.After,
 		"last_event_id": params.LastEventID,
 	})
-	response, err := c.request(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/logs/stream", executionID), query), true, nil)
-	if err != nil {
-		return nil, err
-	}
-	body, ok := response.(io.ReadCloser)
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	return parseGenericStream(body), nil
+	return c.streamEventsChan(ctx, appendQuery(fmt.Sprintf("v1/workflows/executions/%s/logs/stream", executionID), query), true)
 }
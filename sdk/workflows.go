@@ -1,8 +1,8 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 )
@@ -144,6 +144,11 @@ type ExecuteWorkflowAndWaitParams struct {
 }
 
 func (c *MistralClient) GetWorkflows(params *ListWorkflowsParams) (APIResponse, error) {
+	return c.GetWorkflowsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) GetWorkflowsCtx(ctx context.Context, params *ListWorkflowsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.list")
 	if params == nil {
 		params = &ListWorkflowsParams{}
 	}
@@ -161,10 +166,15 @@ func (c *MistralClient) GetWorkflows(params *ListWorkflowsParams) (APIResponse,
 		"cursor":                      params.Cursor,
 		"limit":                       params.Limit,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/workflows", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/workflows", query))
 }
 
 func (c *MistralClient) GetWorkflowRegistrations(params *ListWorkflowRegistrationsParams) (APIResponse, error) {
+	return c.GetWorkflowRegistrationsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) GetWorkflowRegistrationsCtx(ctx context.Context, params *ListWorkflowRegistrationsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_registrations")
 	if params == nil {
 		params = &ListWorkflowRegistrationsParams{}
 	}
@@ -180,18 +190,33 @@ func (c *MistralClient) GetWorkflowRegistrations(params *ListWorkflowRegistratio
 		"limit":                       params.Limit,
 		"cursor":                      params.Cursor,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/workflows/registrations", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/workflows/registrations", query))
 }
 
 func (c *MistralClient) ExecuteWorkflow(workflowIdentifier string, req *ExecuteWorkflowRequest) (APIResponse, error) {
-	return c.executeWorkflowPath(fmt.Sprintf("v1/workflows/%s/execute", workflowIdentifier), req)
+	return c.ExecuteWorkflowCtx(context.Background(), workflowIdentifier, req)
+}
+
+func (c *MistralClient) ExecuteWorkflowCtx(ctx context.Context, workflowIdentifier string, req *ExecuteWorkflowRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.execute")
+	return c.executeWorkflowPath(ctx, fmt.Sprintf("v1/workflows/%s/execute", workflowIdentifier), req)
 }
 
 func (c *MistralClient) ExecuteWorkflowRegistration(workflowRegistrationID string, req *ExecuteWorkflowRequest) (APIResponse, error) {
-	return c.executeWorkflowPath(fmt.Sprintf("v1/workflows/registrations/%s/execute", workflowRegistrationID), req)
+	return c.ExecuteWorkflowRegistrationCtx(context.Background(), workflowRegistrationID, req)
+}
+
+func (c *MistralClient) ExecuteWorkflowRegistrationCtx(ctx context.Context, workflowRegistrationID string, req *ExecuteWorkflowRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.execute_registration")
+	return c.executeWorkflowPath(ctx, fmt.Sprintf("v1/workflows/registrations/%s/execute", workflowRegistrationID), req)
 }
 
 func (c *MistralClient) ExecuteWorkflowAndWait(params *ExecuteWorkflowAndWaitParams) (any, error) {
+	return c.ExecuteWorkflowAndWaitCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ExecuteWorkflowAndWaitCtx(ctx context.Context, params *ExecuteWorkflowAndWaitParams) (any, error) {
+	ctx = withOperation(ctx, "workflows.execute_and_wait")
 	if params == nil {
 		return nil, fmt.Errorf("params cannot be nil")
 	}
@@ -206,13 +231,13 @@ func (c *MistralClient) ExecuteWorkflowAndWait(params *ExecuteWorkflowAndWaitPar
 	if params.UseAPISync {
 		wait := true
 		req.WaitForResult = &wait
-		response, err := c.ExecuteWorkflow(params.WorkflowIdentifier, req)
+		response, err := c.ExecuteWorkflowCtx(ctx, params.WorkflowIdentifier, req)
 		if err != nil {
 			return nil, err
 		}
 		return response["result"], nil
 	}
-	response, err := c.ExecuteWorkflow(params.WorkflowIdentifier, req)
+	response, err := c.ExecuteWorkflowCtx(ctx, params.WorkflowIdentifier, req)
 	if err != nil {
 		return nil, err
 	}
@@ -220,7 +245,7 @@ func (c *MistralClient) ExecuteWorkflowAndWait(params *ExecuteWorkflowAndWaitPar
 	if !ok || executionID == "" {
 		return nil, fmt.Errorf("workflow execution response missing execution_id")
 	}
-	finalExecution, err := c.WaitForWorkflowCompletion(executionID, params.PollingInterval, params.MaxAttempts)
+	finalExecution, err := c.WaitForWorkflowCompletionCtx(ctx, executionID, params.PollingInterval, params.MaxAttempts)
 	if err != nil {
 		return nil, err
 	}
@@ -228,12 +253,17 @@ func (c *MistralClient) ExecuteWorkflowAndWait(params *ExecuteWorkflowAndWaitPar
 }
 
 func (c *MistralClient) WaitForWorkflowCompletion(executionID string, pollingInterval time.Duration, maxAttempts *int) (APIResponse, error) {
+	return c.WaitForWorkflowCompletionCtx(context.Background(), executionID, pollingInterval, maxAttempts)
+}
+
+func (c *MistralClient) WaitForWorkflowCompletionCtx(ctx context.Context, executionID string, pollingInterval time.Duration, maxAttempts *int) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.wait_for_completion")
 	if pollingInterval == 0 {
 		pollingInterval = 5 * time.Second
 	}
 	attempts := 0
 	for {
-		response, err := c.GetWorkflowExecution(executionID)
+		response, err := c.GetWorkflowExecutionCtx(ctx, executionID)
 		if err != nil {
 			return nil, err
 		}
@@ -248,15 +278,27 @@ func (c *MistralClient) WaitForWorkflowCompletion(executionID string, pollingInt
 		if maxAttempts != nil && attempts >= *maxAttempts {
 			return nil, fmt.Errorf("workflow is still running after %d polling attempts", *maxAttempts)
 		}
-		time.Sleep(pollingInterval)
+		if err := sleepCtx(ctx, pollingInterval); err != nil {
+			return nil, err
+		}
 	}
 }
 
 func (c *MistralClient) GetWorkflow(workflowIdentifier string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/%s", workflowIdentifier))
+	return c.GetWorkflowCtx(context.Background(), workflowIdentifier)
+}
+
+func (c *MistralClient) GetWorkflowCtx(ctx context.Context, workflowIdentifier string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/%s", workflowIdentifier))
 }
 
 func (c *MistralClient) UpdateWorkflow(workflowIdentifier string, req *UpdateWorkflowRequest) (APIResponse, error) {
+	return c.UpdateWorkflowCtx(context.Background(), workflowIdentifier, req)
+}
+
+func (c *MistralClient) UpdateWorkflowCtx(ctx context.Context, workflowIdentifier string, req *UpdateWorkflowRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.update")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -265,44 +307,84 @@ func (c *MistralClient) UpdateWorkflow(workflowIdentifier string, req *UpdateWor
 		"description":                 req.Description,
 		"available_in_chat_assistant": req.AvailableInChatAssistant,
 	})
-	return c.requestMap(http.MethodPut, body, fmt.Sprintf("v1/workflows/%s", workflowIdentifier))
+	return c.requestMap(ctx, http.MethodPut, body, fmt.Sprintf("v1/workflows/%s", workflowIdentifier))
 }
 
 func (c *MistralClient) GetWorkflowRegistration(workflowRegistrationID string, params *GetWorkflowRegistrationParams) (APIResponse, error) {
+	return c.GetWorkflowRegistrationCtx(context.Background(), workflowRegistrationID, params)
+}
+
+func (c *MistralClient) GetWorkflowRegistrationCtx(ctx context.Context, workflowRegistrationID string, params *GetWorkflowRegistrationParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_registration")
 	if params == nil {
 		params = &GetWorkflowRegistrationParams{}
 	}
 	query := queryWithOptionalValues(map[string]any{"with_workflow": params.WithWorkflow, "include_shared": params.IncludeShared})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/registrations/%s", workflowRegistrationID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/registrations/%s", workflowRegistrationID), query))
 }
 
 func (c *MistralClient) ArchiveWorkflow(workflowIdentifier string) (APIResponse, error) {
-	return c.requestMap(http.MethodPut, nil, fmt.Sprintf("v1/workflows/%s/archive", workflowIdentifier))
+	return c.ArchiveWorkflowCtx(context.Background(), workflowIdentifier)
+}
+
+func (c *MistralClient) ArchiveWorkflowCtx(ctx context.Context, workflowIdentifier string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.archive")
+	return c.requestMap(ctx, http.MethodPut, nil, fmt.Sprintf("v1/workflows/%s/archive", workflowIdentifier))
 }
 
 func (c *MistralClient) UnarchiveWorkflow(workflowIdentifier string) (APIResponse, error) {
-	return c.requestMap(http.MethodPut, nil, fmt.Sprintf("v1/workflows/%s/unarchive", workflowIdentifier))
+	return c.UnarchiveWorkflowCtx(context.Background(), workflowIdentifier)
+}
+
+func (c *MistralClient) UnarchiveWorkflowCtx(ctx context.Context, workflowIdentifier string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.unarchive")
+	return c.requestMap(ctx, http.MethodPut, nil, fmt.Sprintf("v1/workflows/%s/unarchive", workflowIdentifier))
 }
 
 func (c *MistralClient) BulkArchiveWorkflows(workflowIDs []string) (APIResponse, error) {
+	return c.BulkArchiveWorkflowsCtx(context.Background(), workflowIDs)
+}
+
+func (c *MistralClient) BulkArchiveWorkflowsCtx(ctx context.Context, workflowIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.bulk_archive")
 	body := map[string]interface{}{"workflow_ids": workflowIDs}
-	return c.requestMap(http.MethodPut, body, "v1/workflows/archive")
+	return c.requestMap(ctx, http.MethodPut, body, "v1/workflows/archive")
 }
 
 func (c *MistralClient) BulkUnarchiveWorkflows(workflowIDs []string) (APIResponse, error) {
+	return c.BulkUnarchiveWorkflowsCtx(context.Background(), workflowIDs)
+}
+
+func (c *MistralClient) BulkUnarchiveWorkflowsCtx(ctx context.Context, workflowIDs []string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.bulk_unarchive")
 	body := map[string]interface{}{"workflow_ids": workflowIDs}
-	return c.requestMap(http.MethodPut, body, "v1/workflows/unarchive")
+	return c.requestMap(ctx, http.MethodPut, body, "v1/workflows/unarchive")
 }
 
 func (c *MistralClient) ListWorkflowDeployments() (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, "v1/workflows/deployments")
+	return c.ListWorkflowDeploymentsCtx(context.Background())
+}
+
+func (c *MistralClient) ListWorkflowDeploymentsCtx(ctx context.Context) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.list_deployments")
+	return c.requestMap(ctx, http.MethodGet, nil, "v1/workflows/deployments")
 }
 
 func (c *MistralClient) GetWorkflowDeployment(name string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/deployments/%s", name))
+	return c.GetWorkflowDeploymentCtx(context.Background(), name)
+}
+
+func (c *MistralClient) GetWorkflowDeploymentCtx(ctx context.Context, name string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_deployment")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/deployments/%s", name))
 }
 
 func (c *MistralClient) GetWorkflowDeploymentLogs(name string, params *DeploymentLogsParams) (APIResponse, error) {
+	return c.GetWorkflowDeploymentLogsCtx(context.Background(), name, params)
+}
+
+func (c *MistralClient) GetWorkflowDeploymentLogsCtx(ctx context.Context, name string, params *DeploymentLogsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_deployment_logs")
 	if params == nil {
 		params = &DeploymentLogsParams{}
 	}
@@ -315,14 +397,24 @@ func (c *MistralClient) GetWorkflowDeploymentLogs(name string, params *Deploymen
 		"cursor":        params.Cursor,
 		"limit":         params.Limit,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/deployments/%s/logs", name), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/deployments/%s/logs", name), query))
 }
 
 func (c *MistralClient) GetDeploymentLogs(name string, params *DeploymentLogsParams) (APIResponse, error) {
-	return c.GetWorkflowDeploymentLogs(name, params)
+	return c.GetDeploymentLogsCtx(context.Background(), name, params)
+}
+
+func (c *MistralClient) GetDeploymentLogsCtx(ctx context.Context, name string, params *DeploymentLogsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_deployment_logs")
+	return c.GetWorkflowDeploymentLogsCtx(ctx, name, params)
 }
 
 func (c *MistralClient) StreamWorkflowDeploymentLogs(name string, params *DeploymentLogsStreamParams) (<-chan StreamEvent, error) {
+	return c.StreamWorkflowDeploymentLogsCtx(context.Background(), name, params)
+}
+
+func (c *MistralClient) StreamWorkflowDeploymentLogsCtx(ctx context.Context, name string, params *DeploymentLogsStreamParams) (<-chan StreamEvent, error) {
+	ctx = withOperation(ctx, "workflows.stream_deployment_logs")
 	if params == nil {
 		params = &DeploymentLogsStreamParams{}
 	}
@@ -332,26 +424,33 @@ func (c *MistralClient) StreamWorkflowDeploymentLogs(name string, params *Deploy
 		"after":         params.After,
 		"last_event_id": params.LastEventID,
 	})
-	response, err := c.request(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/deployments/%s/logs/stream", name), query), true, nil)
-	if err != nil {
-		return nil, err
-	}
-	body, ok := response.(io.ReadCloser)
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	return parseGenericStream(body), nil
+	return c.streamEventsChan(ctx, appendQuery(fmt.Sprintf("v1/workflows/deployments/%s/logs/stream", name), query), true)
 }
 
 func (c *MistralClient) StreamDeploymentLogs(name string, params *DeploymentLogsStreamParams) (<-chan StreamEvent, error) {
-	return c.StreamWorkflowDeploymentLogs(name, params)
+	return c.StreamDeploymentLogsCtx(context.Background(), name, params)
+}
+
+func (c *MistralClient) StreamDeploymentLogsCtx(ctx context.Context, name string, params *DeploymentLogsStreamParams) (<-chan StreamEvent, error) {
+	ctx = withOperation(ctx, "workflows.stream_deployment_logs")
+	return c.StreamWorkflowDeploymentLogsCtx(ctx, name, params)
 }
 
 func (c *MistralClient) GetWorkflowMetrics(workflowName string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/%s/metrics", workflowName))
+	return c.GetWorkflowMetricsCtx(context.Background(), workflowName)
+}
+
+func (c *MistralClient) GetWorkflowMetricsCtx(ctx context.Context, workflowName string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_metrics")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/%s/metrics", workflowName))
 }
 
 func (c *MistralClient) ListWorkflowRuns(params *ListWorkflowRunsParams) (APIResponse, error) {
+	return c.ListWorkflowRunsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) ListWorkflowRunsCtx(ctx context.Context, params *ListWorkflowRunsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.list_runs")
 	if params == nil {
 		params = &ListWorkflowRunsParams{}
 	}
@@ -372,19 +471,34 @@ func (c *MistralClient) ListWorkflowRuns(params *ListWorkflowRunsParams) (APIRes
 		"page_size":           params.PageSize,
 		"next_page_token":     params.NextPageToken,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/workflows/runs", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/workflows/runs", query))
 }
 
 func (c *MistralClient) GetWorkflowRun(runID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/runs/%s", runID))
+	return c.GetWorkflowRunCtx(context.Background(), runID)
+}
+
+func (c *MistralClient) GetWorkflowRunCtx(ctx context.Context, runID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_run")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/runs/%s", runID))
 }
 
 func (c *MistralClient) GetWorkflowRunHistory(runID string, decodePayloads *bool) (APIResponse, error) {
+	return c.GetWorkflowRunHistoryCtx(context.Background(), runID, decodePayloads)
+}
+
+func (c *MistralClient) GetWorkflowRunHistoryCtx(ctx context.Context, runID string, decodePayloads *bool) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_run_history")
 	query := queryWithOptionalValues(map[string]any{"decode_payloads": decodePayloads})
-	return c.requestMap(http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/runs/%s/history", runID), query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery(fmt.Sprintf("v1/workflows/runs/%s/history", runID), query))
 }
 
 func (c *MistralClient) GetWorkflowStreamEvents(params *ListWorkflowEventsParams) (<-chan StreamEvent, error) {
+	return c.GetWorkflowStreamEventsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) GetWorkflowStreamEventsCtx(ctx context.Context, params *ListWorkflowEventsParams) (<-chan StreamEvent, error) {
+	ctx = withOperation(ctx, "workflows.get_stream_events")
 	if params == nil {
 		params = &ListWorkflowEventsParams{}
 	}
@@ -395,18 +509,15 @@ func (c *MistralClient) GetWorkflowStreamEvents(params *ListWorkflowEventsParams
 		"page_size":       params.PageSize,
 		"next_page_token": params.NextPageToken,
 	})
-	response, err := c.request(http.MethodGet, nil, appendQuery("v1/workflows/events/stream", query), true, nil)
-	if err != nil {
-		return nil, err
-	}
-	body, ok := response.(io.ReadCloser)
-	if !ok {
-		return nil, fmt.Errorf("invalid response type: %T", response)
-	}
-	return parseGenericStream(body), nil
+	return c.streamEventsChan(ctx, appendQuery("v1/workflows/events/stream", query), false)
 }
 
 func (c *MistralClient) GetWorkflowEvents(params *ListWorkflowEventsParams) (APIResponse, error) {
+	return c.GetWorkflowEventsCtx(context.Background(), params)
+}
+
+func (c *MistralClient) GetWorkflowEventsCtx(ctx context.Context, params *ListWorkflowEventsParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_events")
 	if params == nil {
 		params = &ListWorkflowEventsParams{}
 	}
@@ -417,10 +528,15 @@ func (c *MistralClient) GetWorkflowEvents(params *ListWorkflowEventsParams) (API
 		"page_size":       params.PageSize,
 		"next_page_token": params.NextPageToken,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/workflows/events/list", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/workflows/events/list", query))
 }
 
 func (c *MistralClient) GetWorkflowSchedules(params ...*ListWorkflowSchedulesParams) (APIResponse, error) {
+	return c.GetWorkflowSchedulesCtx(context.Background(), params...)
+}
+
+func (c *MistralClient) GetWorkflowSchedulesCtx(ctx context.Context, params ...*ListWorkflowSchedulesParams) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_schedules")
 	requestParams := &ListWorkflowSchedulesParams{}
 	if len(params) > 0 && params[0] != nil {
 		requestParams = params[0]
@@ -432,10 +548,15 @@ func (c *MistralClient) GetWorkflowSchedules(params ...*ListWorkflowSchedulesPar
 		"page_size":       requestParams.PageSize,
 		"next_page_token": requestParams.NextPageToken,
 	})
-	return c.requestMap(http.MethodGet, nil, appendQuery("v1/workflows/schedules", query))
+	return c.requestMap(ctx, http.MethodGet, nil, appendQuery("v1/workflows/schedules", query))
 }
 
 func (c *MistralClient) ScheduleWorkflow(req *ScheduleWorkflowRequest) (APIResponse, error) {
+	return c.ScheduleWorkflowCtx(context.Background(), req)
+}
+
+func (c *MistralClient) ScheduleWorkflowCtx(ctx context.Context, req *ScheduleWorkflowRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.schedule")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
@@ -448,50 +569,80 @@ func (c *MistralClient) ScheduleWorkflow(req *ScheduleWorkflowRequest) (APIRespo
 		"schedule_id":              req.ScheduleID,
 		"deployment_name":          req.DeploymentName,
 	})
-	return c.requestMap(http.MethodPost, body, "v1/workflows/schedules")
+	return c.requestMap(ctx, http.MethodPost, body, "v1/workflows/schedules")
 }
 
 func (c *MistralClient) UnscheduleWorkflow(scheduleID string) (APIResponse, error) {
-	return c.requestMap(http.MethodDelete, nil, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
+	return c.UnscheduleWorkflowCtx(context.Background(), scheduleID)
+}
+
+func (c *MistralClient) UnscheduleWorkflowCtx(ctx context.Context, scheduleID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.unschedule")
+	return c.requestMap(ctx, http.MethodDelete, nil, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
 }
 
 func (c *MistralClient) GetWorkflowSchedule(scheduleID string) (APIResponse, error) {
-	return c.requestMap(http.MethodGet, nil, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
+	return c.GetWorkflowScheduleCtx(context.Background(), scheduleID)
+}
+
+func (c *MistralClient) GetWorkflowScheduleCtx(ctx context.Context, scheduleID string) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.get_schedule")
+	return c.requestMap(ctx, http.MethodGet, nil, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
 }
 
 func (c *MistralClient) UpdateWorkflowSchedule(scheduleID string, req *UpdateScheduleRequest) (APIResponse, error) {
+	return c.UpdateWorkflowScheduleCtx(context.Background(), scheduleID, req)
+}
+
+func (c *MistralClient) UpdateWorkflowScheduleCtx(ctx context.Context, scheduleID string, req *UpdateScheduleRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.update_schedule")
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 	body := optionalRequestMap(map[string]any{"schedule": req.Schedule})
-	return c.requestMap(http.MethodPatch, body, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
+	return c.requestMap(ctx, http.MethodPatch, body, fmt.Sprintf("v1/workflows/schedules/%s", scheduleID))
 }
 
 func (c *MistralClient) PauseSchedule(scheduleID string, req *ScheduleNoteRequest) (APIResponse, error) {
+	return c.PauseScheduleCtx(context.Background(), scheduleID, req)
+}
+
+func (c *MistralClient) PauseScheduleCtx(ctx context.Context, scheduleID string, req *ScheduleNoteRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.pause_schedule")
 	body := map[string]interface{}{}
 	if req != nil {
 		body = optionalRequestMap(map[string]any{"note": req.Note})
 	}
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/pause", scheduleID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/pause", scheduleID))
 }
 
 func (c *MistralClient) ResumeSchedule(scheduleID string, req *ScheduleNoteRequest) (APIResponse, error) {
+	return c.ResumeScheduleCtx(context.Background(), scheduleID, req)
+}
+
+func (c *MistralClient) ResumeScheduleCtx(ctx context.Context, scheduleID string, req *ScheduleNoteRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.resume_schedule")
 	body := map[string]interface{}{}
 	if req != nil {
 		body = optionalRequestMap(map[string]any{"note": req.Note})
 	}
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/resume", scheduleID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/resume", scheduleID))
 }
 
 func (c *MistralClient) TriggerSchedule(scheduleID string, req *TriggerScheduleRequest) (APIResponse, error) {
+	return c.TriggerScheduleCtx(context.Background(), scheduleID, req)
+}
+
+func (c *MistralClient) TriggerScheduleCtx(ctx context.Context, scheduleID string, req *TriggerScheduleRequest) (APIResponse, error) {
+	ctx = withOperation(ctx, "workflows.trigger_schedule")
 	body := map[string]interface{}{}
 	if req != nil {
 		body = optionalRequestMap(map[string]any{"overlap": req.Overlap})
 	}
-	return c.requestMap(http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/trigger", scheduleID))
+	return c.requestMap(ctx, http.MethodPost, body, fmt.Sprintf("v1/workflows/schedules/%s/trigger", scheduleID))
 }
 
-func (c *MistralClient) executeWorkflowPath(path string, req *ExecuteWorkflowRequest) (APIResponse, error) {
+func (c *MistralClient) executeWorkflowPath(ctx context.Context, path string, req *ExecuteWorkflowRequest) (APIResponse, error) {
 	if req == nil {
 		req = &ExecuteWorkflowRequest{}
 	}
@@ -505,5 +656,5 @@ func (c *MistralClient) executeWorkflowPath(path string, req *ExecuteWorkflowReq
 		"task_queue":                req.TaskQueue,
 		"deployment_name":           req.DeploymentName,
 	})
-	return c.requestMap(http.MethodPost, body, path)
+	return c.requestMap(ctx, http.MethodPost, body, path)
 }